@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/wowsims/cata/sim/core"
@@ -11,6 +12,13 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+var (
+	replaySeed      int64
+	replayIteration int32
+	replayExtremes  int32
+	raceSweep       bool
+)
+
 var simCmd = &cobra.Command{
 	Use:   "sim",
 	Short: "simulate items & settings",
@@ -21,6 +29,10 @@ func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	simCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	simCmd.Flags().Int64Var(&replaySeed, "replay-seed", 0, "instead of a full sim, replay the iteration with this seed (e.g. a max_seed from the results) and write its logs")
+	simCmd.Flags().Int32Var(&replayIteration, "replay-iteration", -1, "instead of a full sim, replay the iteration with this index and write its logs. Needs a fixed random seed")
+	simCmd.Flags().Int32Var(&replayExtremes, "replay-extremes", 0, "run the sim, then replay its N worst and N best iterations and write their logs")
+	simCmd.Flags().BoolVar(&raceSweep, "race-sweep", false, "instead of a full sim, sim every race the class can be and rank them by DPS")
 	simCmd.MarkFlagRequired("infile")
 }
 
@@ -37,6 +49,37 @@ func simMain(cmd *cobra.Command, args []string) {
 	}
 
 	var output []byte
+	replayRequest := &proto.ReplayIterationRequest{Request: input}
+	switch {
+	case replaySeed != 0:
+		replayRequest.Iteration = &proto.ReplayIterationRequest_Seed{Seed: replaySeed}
+	case replayIteration >= 0:
+		replayRequest.Iteration = &proto.ReplayIterationRequest_Index{Index: replayIteration}
+	case replayExtremes > 0:
+		replayRequest.Iteration = &proto.ReplayIterationRequest_NumExtremes{NumExtremes: replayExtremes}
+	}
+	if raceSweep {
+		output = []byte(raceSweepMain(input))
+	} else if replayRequest.Iteration != nil {
+		output = []byte(replayMain(replayRequest))
+	} else {
+		output = runSimMain(input)
+	}
+
+	if outfile == "" {
+		fmt.Print(string(output))
+	} else {
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
+
+func runSimMain(input *proto.RaidSimRequest) []byte {
 	reporter := make(chan *proto.ProgressMetrics, 10)
 	core.RunRaidSimAsync(input, reporter)
 
@@ -51,20 +94,79 @@ func simMain(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
+	output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
 	if err != nil {
 		log.Fatalf("failed to marshal final results: %s", err)
 	}
+	return output
+}
 
-	if outfile == "" {
-		fmt.Print(string(output))
-	} else {
-		err = os.WriteFile(outfile, output, 0666)
-		if err != nil {
-			log.Fatalf("failed to write output file:: %s", err)
+func replayMain(request *proto.ReplayIterationRequest) string {
+	reporter := make(chan *proto.ProgressMetrics, 10)
+	core.ReplayIterationAsync(request, reporter)
+
+	var result *proto.ReplayIterationResult
+	for v := range reporter {
+		if v.FinalReplayResult != nil {
+			result = v.FinalReplayResult
+			break
 		}
 		if verbose {
-			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+			fmt.Printf("Sim Progress: %d / %d\n", v.CompletedIterations, v.TotalIterations)
 		}
 	}
+	if result.ErrorResult != "" {
+		log.Fatalf("replay failed: %s", result.ErrorResult)
+	}
+
+	var sb strings.Builder
+	for _, iteration := range result.Iterations {
+		sb.WriteString(fmt.Sprintf("=== Iteration %d, seed %d, raid DPS %0.1f", iteration.Index, iteration.Seed, iteration.Result.RaidMetrics.Dps.Avg))
+		if iteration.ExpectedDps != 0 {
+			sb.WriteString(fmt.Sprintf(" (%0.1f in the full sim)", iteration.ExpectedDps))
+		}
+		sb.WriteString(" ===\n")
+		sb.WriteString(iteration.Result.Logs)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func raceSweepMain(input *proto.RaidSimRequest) string {
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.CompareRacesAsync(&proto.RaceComparisonRequest{BaseRequest: input}, reporter)
+
+	var result *proto.RaceComparisonResult
+	for v := range reporter {
+		if v.FinalRaceComparisonResult != nil {
+			result = v.FinalRaceComparisonResult
+			break
+		}
+		if verbose {
+			fmt.Printf("Sim Progress: %d / %d (completed %d / %d sims)\n", v.CompletedIterations, v.TotalIterations, v.CompletedSims, v.TotalSims)
+		}
+	}
+	if result.ErrorResult != "" {
+		log.Fatalf("race sweep failed: %s", result.ErrorResult)
+	}
+
+	currentRace := input.Raid.Parties[0].Players[0].Race
+	var sb strings.Builder
+	// Status is current for the player's race, and skipped for races the
+	// class or gear can't be.
+	sb.WriteString("race,status,dps,dps_ci,dps_delta,dps_delta_ci\n")
+	for _, entry := range result.Entries {
+		status := ""
+		if entry.Race == currentRace {
+			status = "current"
+		}
+		sb.WriteString(fmt.Sprintf("%s,%s,%0.2f,%0.2f,%0.2f,%0.2f\n",
+			strings.TrimPrefix(entry.Race.String(), "Race"), status,
+			entry.Dps.Avg, entry.Dps.ConfidenceInterval,
+			entry.DpsDelta.Avg, entry.DpsDelta.ConfidenceInterval))
+	}
+	for _, race := range result.Skipped {
+		sb.WriteString(fmt.Sprintf("%s,skipped,,,,\n", strings.TrimPrefix(race.String(), "Race")))
+	}
+	return sb.String()
 }
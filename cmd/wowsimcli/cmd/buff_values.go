@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	buffValuesAdded  bool
+	buffValuesFormat string
+)
+
+var buffValuesCmd = &cobra.Command{
+	Use:   "buffvalues",
+	Short: "find the DPS value of each buff, debuff and consumable",
+	Long:  "sim without each buff, debuff and consumable (and optionally with each missing one) to find what each is worth to each player",
+	Run:   buffValuesMain,
+}
+
+func init() {
+	buffValuesCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	buffValuesCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	buffValuesCmd.Flags().BoolVar(&buffValuesAdded, "added", false, "also sim each missing buff, debuff and consumable turned on. Only on/off and count fields are added, not choices like flask or food")
+	buffValuesCmd.Flags().StringVar(&buffValuesFormat, "format", "csv", "output format, csv or json")
+	buffValuesCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	buffValuesCmd.MarkFlagRequired("infile")
+}
+
+func buffValuesMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.BuffValuesAsync(&proto.BuffValuesRequest{BaseRequest: input, IncludeAdded: buffValuesAdded}, reporter)
+
+	var result *proto.BuffValuesResult
+	for v := range reporter {
+		if v.FinalBuffValuesResult != nil {
+			result = v.FinalBuffValuesResult
+			break
+		}
+		if verbose {
+			fmt.Printf("Sim Progress: %d / %d (completed %d / %d sims)\n", v.CompletedIterations, v.TotalIterations, v.CompletedSims, v.TotalSims)
+		}
+	}
+	if result.ErrorResult != "" {
+		log.Fatalf("buff values failed: %s", result.ErrorResult)
+	}
+
+	var output []byte
+	switch buffValuesFormat {
+	case "json":
+		output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			log.Fatalf("failed to marshal final results: %s", err)
+		}
+	case "csv":
+		output = []byte(buffValuesCSV(result))
+	default:
+		log.Fatalf("unknown output format %q", buffValuesFormat)
+	}
+
+	if outfile == "" {
+		fmt.Print(string(output))
+	} else {
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
+
+func buffValuesCSV(result *proto.BuffValuesResult) string {
+	var sb strings.Builder
+	sb.WriteString("player,buff,added,dps_value,dps_value_ci,hps_value,hps_value_ci\n")
+
+	for _, player := range result.Players {
+		for _, value := range player.Values {
+			sb.WriteString(fmt.Sprintf("%s,%s,%t,%0.2f,%0.2f,%0.2f,%0.2f\n", player.Name, value.Name, value.Added,
+				value.DpsValue.Avg, value.DpsValue.ConfidenceInterval,
+				value.HpsValue.Avg, value.HpsValue.ConfidenceInterval))
+		}
+	}
+	return sb.String()
+}
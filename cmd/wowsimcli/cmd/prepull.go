@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	prepullParty  int32
+	prepullPlayer int32
+	prepullSpells []string
+	prepullFormat string
+)
+
+var prepullCmd = &cobra.Command{
+	Use:   "prepull",
+	Short: "plan and validate a player's prepull",
+	Long:  "compute do_at times for a player's opener from cast times, GCDs, travel times and shared cooldowns, and report conflicts",
+	Run:   prepullMain,
+}
+
+func init() {
+	prepullCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	prepullCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	prepullCmd.Flags().Int32Var(&prepullParty, "party", 0, "party index of the player")
+	prepullCmd.Flags().Int32Var(&prepullPlayer, "player", 0, "player index of the player in its party")
+	prepullCmd.Flags().StringArrayVar(&prepullSpells, "spell", nil, "spell ID or 'potion' to use in the opener, in order (repeatable), defaults to the player's current prepull actions")
+	prepullCmd.Flags().StringVar(&prepullFormat, "format", "csv", "output format, csv or json")
+	prepullCmd.MarkFlagRequired("infile")
+}
+
+func prepullMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	request := &proto.PrepullPlanRequest{BaseRequest: input, PartyIndex: prepullParty, PlayerIndex: prepullPlayer}
+	for _, spell := range prepullSpells {
+		actionID := core.ActionID{OtherID: proto.OtherAction_OtherActionPotion}
+		if spell != "potion" {
+			spellID, err := strconv.Atoi(spell)
+			if err != nil {
+				log.Fatalf("invalid spell %q, expected a spell ID or 'potion'", spell)
+			}
+			actionID = core.ActionID{SpellID: int32(spellID)}
+		}
+		request.Opener = append(request.Opener, &proto.APLAction{Action: &proto.APLAction_CastSpell{
+			CastSpell: &proto.APLActionCastSpell{SpellId: actionID.ToProto()},
+		}})
+	}
+
+	result := core.PlanPrepull(request)
+	if result.ErrorResult != "" {
+		log.Fatalf("prepull planning failed: %s", result.ErrorResult)
+	}
+
+	var output []byte
+	switch prepullFormat {
+	case "json":
+		output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			log.Fatalf("failed to marshal final results: %s", err)
+		}
+	case "csv":
+		output = []byte(prepullCSV(result))
+	default:
+		log.Fatalf("unknown output format %q", prepullFormat)
+	}
+
+	if outfile == "" {
+		fmt.Print(string(output))
+	} else {
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+	}
+}
+
+func prepullCSV(result *proto.PrepullPlanResult) string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	w.Write([]string{"action", "do_at", "cast_time", "gcd", "travel_time", "conflicts"})
+
+	for _, step := range result.Steps {
+		w.Write([]string{
+			protojson.MarshalOptions{}.Format(step.Action),
+			fmt.Sprintf("%0.3f", step.DoAtSeconds),
+			fmt.Sprintf("%0.3f", step.CastTimeSeconds),
+			fmt.Sprintf("%0.3f", step.GcdSeconds),
+			fmt.Sprintf("%0.3f", step.TravelTimeSeconds),
+			strings.Join(step.Conflicts, "; "),
+		})
+	}
+	w.Flush()
+	return sb.String()
+}
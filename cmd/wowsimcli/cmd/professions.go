@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	professionPairs  []string
+	professionFormat string
+)
+
+var professionsCmd = &cobra.Command{
+	Use:   "professions",
+	Short: "compare DPS with different profession pairs",
+	Long:  "sim each profession pair with the gear adjusted to it (extra sockets, jewelcrafting gems, profession enchants), and rank them against the current professions",
+	Run:   professionsMain,
+}
+
+func init() {
+	professionsCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	professionsCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	professionsCmd.Flags().StringArrayVar(&professionPairs, "pair", nil, "profession pair to compare, e.g. Blacksmithing,Jewelcrafting. Can be repeated, defaults to all pairs")
+	professionsCmd.Flags().StringVar(&professionFormat, "format", "csv", "output format, csv or json")
+	professionsCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	professionsCmd.MarkFlagRequired("infile")
+}
+
+func parseProfessionPair(pair string) *proto.ProfessionPair {
+	names := strings.Split(pair, ",")
+	if len(names) != 2 {
+		log.Fatalf("profession pair %q must have 2 professions", pair)
+	}
+	var professions [2]proto.Profession
+	for i, name := range names {
+		value, ok := proto.Profession_value[strings.TrimSpace(name)]
+		if !ok {
+			log.Fatalf("unknown profession %q", name)
+		}
+		professions[i] = proto.Profession(value)
+	}
+	return &proto.ProfessionPair{Profession1: professions[0], Profession2: professions[1]}
+}
+
+func professionsMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	request := &proto.ProfessionComparisonRequest{BaseRequest: input}
+	for _, pair := range professionPairs {
+		request.Professions = append(request.Professions, parseProfessionPair(pair))
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.CompareProfessionsAsync(request, reporter)
+
+	var result *proto.ProfessionComparisonResult
+	for v := range reporter {
+		if v.FinalProfessionComparisonResult != nil {
+			result = v.FinalProfessionComparisonResult
+			break
+		}
+		if verbose {
+			fmt.Printf("Sim Progress: %d / %d (completed %d / %d sims)\n", v.CompletedIterations, v.TotalIterations, v.CompletedSims, v.TotalSims)
+		}
+	}
+	if result.ErrorResult != "" {
+		log.Fatalf("profession comparison failed: %s", result.ErrorResult)
+	}
+
+	var output []byte
+	switch professionFormat {
+	case "json":
+		output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			log.Fatalf("failed to marshal final results: %s", err)
+		}
+	case "csv":
+		output = []byte(professionComparisonCSV(input.Raid.Parties[0].Players[0], result))
+	default:
+		log.Fatalf("unknown output format %q", professionFormat)
+	}
+
+	if outfile == "" {
+		fmt.Print(string(output))
+	} else {
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
+
+func professionComparisonCSV(player *proto.Player, result *proto.ProfessionComparisonResult) string {
+	var sb strings.Builder
+	// Status is current for the player's professions, and skipped for pairs
+	// the gear needs other professions for.
+	sb.WriteString("profession1,profession2,status,dps,dps_ci,dps_delta,dps_delta_ci\n")
+
+	for _, entry := range result.Entries {
+		status := ""
+		if entry.Professions.Profession1 == player.Profession1 && entry.Professions.Profession2 == player.Profession2 {
+			status = "current"
+		}
+		sb.WriteString(fmt.Sprintf("%s,%s,%s,%0.2f,%0.2f,%0.2f,%0.2f\n",
+			entry.Professions.Profession1, entry.Professions.Profession2, status,
+			entry.Dps.Avg, entry.Dps.ConfidenceInterval,
+			entry.DpsDelta.Avg, entry.DpsDelta.ConfidenceInterval))
+	}
+	for _, professions := range result.Skipped {
+		sb.WriteString(fmt.Sprintf("%s,%s,skipped,,,,\n", professions.Profession1, professions.Profession2))
+	}
+	return sb.String()
+}
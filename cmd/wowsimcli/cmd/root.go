@@ -17,6 +17,12 @@ func Execute(version string) {
 	rootCmd.AddCommand(newVersionCommand(version))
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(bulkCmd)
+	rootCmd.AddCommand(statCurveCmd)
+	rootCmd.AddCommand(talentsCmd)
+	rootCmd.AddCommand(professionsCmd)
+	rootCmd.AddCommand(buffValuesCmd)
+	rootCmd.AddCommand(prepullCmd)
+	rootCmd.AddCommand(tuneCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
 
 	if err := rootCmd.Execute(); err != nil {
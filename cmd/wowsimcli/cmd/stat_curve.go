@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	curveStats  []string
+	curveStats2 []string
+	curveMin    float64
+	curveMax    float64
+	curveSteps  int32
+	curveMin2   float64
+	curveMax2   float64
+	curveSteps2 int32
+	curveFormat string
+)
+
+var statCurveCmd = &cobra.Command{
+	Use:   "statcurve",
+	Short: "sim DPS over a range of values for one or two stats",
+	Long:  "sim DPS over a range of values for one or two stats, e.g. to find caps and breakpoints",
+	Run:   statCurveMain,
+}
+
+func init() {
+	statCurveCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	statCurveCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	statCurveCmd.Flags().StringSliceVar(&curveStats, "stat", nil, "stat(s) to sweep, e.g. StatMeleeHaste,StatSpellHaste")
+	statCurveCmd.Flags().Float64Var(&curveMin, "min", -200, "lowest stat delta relative to current gear")
+	statCurveCmd.Flags().Float64Var(&curveMax, "max", 200, "highest stat delta relative to current gear")
+	statCurveCmd.Flags().Int32Var(&curveSteps, "steps", 11, "number of points between min and max")
+	statCurveCmd.Flags().StringSliceVar(&curveStats2, "stat2", nil, "optional second stat(s) to sweep, producing a grid")
+	statCurveCmd.Flags().Float64Var(&curveMin2, "min2", -200, "lowest delta for the second stat")
+	statCurveCmd.Flags().Float64Var(&curveMax2, "max2", 200, "highest delta for the second stat")
+	statCurveCmd.Flags().Int32Var(&curveSteps2, "steps2", 11, "number of points for the second stat")
+	statCurveCmd.Flags().StringVar(&curveFormat, "format", "csv", "output format, csv or json")
+	statCurveCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	statCurveCmd.MarkFlagRequired("infile")
+	statCurveCmd.MarkFlagRequired("stat")
+}
+
+func parseStats(names []string) []proto.Stat {
+	var result []proto.Stat
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		value, ok := proto.Stat_value[name]
+		if !ok {
+			value, ok = proto.Stat_value["Stat"+name]
+		}
+		if !ok {
+			log.Fatalf("unknown stat %q", name)
+		}
+		result = append(result, proto.Stat(value))
+	}
+	return result
+}
+
+func statCurveMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	request := &proto.StatCurveRequest{
+		Player:     input.Raid.Parties[0].Players[0],
+		RaidBuffs:  input.Raid.Buffs,
+		PartyBuffs: input.Raid.Parties[0].Buffs,
+		Debuffs:    input.Raid.Debuffs,
+		Encounter:  input.Encounter,
+		SimOptions: input.SimOptions,
+		Tanks:      input.Raid.Tanks,
+		Axes: []*proto.StatCurveAxis{{
+			Stats:    parseStats(curveStats),
+			MinDelta: curveMin,
+			MaxDelta: curveMax,
+			NumSteps: curveSteps,
+		}},
+	}
+	if len(curveStats2) > 0 {
+		request.Axes = append(request.Axes, &proto.StatCurveAxis{
+			Stats:    parseStats(curveStats2),
+			MinDelta: curveMin2,
+			MaxDelta: curveMax2,
+			NumSteps: curveSteps2,
+		})
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.StatCurveAsync(request, reporter)
+
+	var result *proto.StatCurveResult
+	for v := range reporter {
+		if v.FinalStatCurveResult != nil {
+			result = v.FinalStatCurveResult
+			break
+		}
+		if verbose {
+			fmt.Printf("Sim Progress: %d / %d (completed %d / %d sims)\n", v.CompletedIterations, v.TotalIterations, v.CompletedSims, v.TotalSims)
+		}
+	}
+	if result.ErrorResult != "" {
+		log.Fatalf("stat curve failed: %s", result.ErrorResult)
+	}
+
+	var output []byte
+	switch curveFormat {
+	case "json":
+		output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			log.Fatalf("failed to marshal final results: %s", err)
+		}
+	case "csv":
+		output = []byte(statCurveCSV(request, result))
+	default:
+		log.Fatalf("unknown output format %q", curveFormat)
+	}
+
+	if outfile == "" {
+		fmt.Print(string(output))
+	} else {
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
+
+func statCurveCSV(request *proto.StatCurveRequest, result *proto.StatCurveResult) string {
+	var sb strings.Builder
+	for _, axis := range request.Axes {
+		names := make([]string, len(axis.Stats))
+		for i, stat := range axis.Stats {
+			names[i] = strings.TrimPrefix(stat.String(), "Stat")
+		}
+		sb.WriteString(strings.Join(names, "+") + ",")
+	}
+	sb.WriteString("dps,dps_ci,dps_delta,dps_delta_ci,tps,tps_ci,hps,hps_ci\n")
+
+	for _, point := range result.Points {
+		for _, delta := range point.Deltas {
+			sb.WriteString(fmt.Sprintf("%0.1f,", delta))
+		}
+		sb.WriteString(fmt.Sprintf("%0.2f,%0.2f,%0.2f,%0.2f,%0.2f,%0.2f,%0.2f,%0.2f\n",
+			point.Dps.Avg, point.Dps.ConfidenceInterval,
+			point.DpsDelta.Avg, point.DpsDelta.ConfidenceInterval,
+			point.Tps.Avg, point.Tps.ConfidenceInterval,
+			point.Hps.Avg, point.Hps.ConfidenceInterval))
+	}
+	return sb.String()
+}
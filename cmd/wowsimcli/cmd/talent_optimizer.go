@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	talenttrees "github.com/wowsims/cata/ui/core/talents/trees"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	talentTreesFile   string
+	talentTotalPoints int32
+	talentPrimaryTree int32
+	talentPrimaryPts  int32
+	talentRequired    string
+	talentCandidates  []string
+	talentPrimes      []int32
+	talentMajors      []int32
+	talentMaxBuilds   int32
+)
+
+var talentsCmd = &cobra.Command{
+	Use:   "talents",
+	Short: "find the best talent and glyph builds within some constraints",
+	Long:  "bulk simulate every legal talent build and prime/major glyph combination within the given constraints, and rank them against the current build",
+	Run:   talentsMain,
+}
+
+func init() {
+	talentsCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	talentsCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	talentsCmd.Flags().StringVar(&talentTreesFile, "trees", "", "location of the class talent tree definitions, defaults to the UI's trees of the class")
+	talentsCmd.Flags().Int32Var(&talentTotalPoints, "points", 41, "number of talent points to spend")
+	talentsCmd.Flags().Int32Var(&talentPrimaryTree, "primary", 0, "index of the primary talent tree (0-2)")
+	talentsCmd.Flags().Int32Var(&talentPrimaryPts, "primary-points", 31, "minimum number of points in the primary tree")
+	talentsCmd.Flags().StringVar(&talentRequired, "require", "", "talents every build must take, as a talents string. Ranks are minimums")
+	talentsCmd.Flags().StringSliceVar(&talentCandidates, "candidates", nil, "talents (by field name, e.g. titansGrip) the remaining points may go into, defaults to all")
+	talentsCmd.Flags().Int32SliceVar(&talentPrimes, "prime", nil, "prime glyphs to choose 3 of, defaults to the current glyphs")
+	talentsCmd.Flags().Int32SliceVar(&talentMajors, "major", nil, "major glyphs to choose 3 of, defaults to the current glyphs")
+	talentsCmd.Flags().Int32Var(&talentMaxBuilds, "max-builds", 2000, "give up if more builds than this match the constraints")
+	talentsCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	talentsCmd.MarkFlagRequired("infile")
+}
+
+// Reads a talent tree definition file, as used by the UI.
+func loadTalentTrees(path string) []*proto.TalentTreeLayout {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("failed to load talent trees file %q: %v", path, err)
+	}
+	trees, err := talenttrees.Parse(data)
+	if err != nil {
+		log.Fatalf("failed to parse talent trees file: %s", err)
+	}
+	return trees
+}
+
+func talentsMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	player := input.Raid.Parties[0].Players[0]
+	var trees []*proto.TalentTreeLayout
+	if talentTreesFile != "" {
+		trees = loadTalentTrees(talentTreesFile)
+	}
+
+	request := &proto.BulkSimRequest{
+		BaseSettings: input,
+		BulkSettings: &proto.BulkSettings{
+			IterationsPerCombo: input.SimOptions.Iterations,
+			TalentOptimizer: &proto.TalentOptimizerSettings{
+				Trees:             trees,
+				TotalPoints:       talentTotalPoints,
+				PrimaryTree:       talentPrimaryTree,
+				PrimaryTreePoints: talentPrimaryPts,
+				RequiredTalents:   talentRequired,
+				CandidateTalents:  talentCandidates,
+				PrimeGlyphs:       talentPrimes,
+				MajorGlyphs:       talentMajors,
+				MaxBuilds:         talentMaxBuilds,
+			},
+		},
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.RunBulkSimAsync(context.Background(), request, reporter)
+
+	var result *proto.BulkSimResult
+	for v := range reporter {
+		if v.FinalBulkResult != nil {
+			result = v.FinalBulkResult
+			break
+		}
+		if verbose {
+			fmt.Printf("Sim Progress: %d / %d (completed %d / %d sims)\n", v.CompletedIterations, v.TotalIterations, v.CompletedSims, v.TotalSims)
+		}
+	}
+	if result == nil {
+		log.Fatalf("talent optimizer did not return a result")
+	}
+	if result.ErrorResult != "" {
+		log.Fatalf("talent optimizer failed: %s", result.ErrorResult)
+	}
+
+	output := talentBuildsCSV(player, result)
+	if outfile == "" {
+		fmt.Print(output)
+	} else {
+		err = os.WriteFile(outfile, []byte(output), 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
+
+func talentBuildsCSV(player *proto.Player, result *proto.BulkSimResult) string {
+	var sb strings.Builder
+	sb.WriteString("talents,prime_glyphs,major_glyphs,dps,dps_delta\n")
+
+	baseDps := result.EquippedGearResult.UnitMetrics.Dps.Avg
+	writeBuild := func(talents string, glyphs *proto.Glyphs, dps float64) {
+		sb.WriteString(fmt.Sprintf("%s,%d;%d;%d,%d;%d;%d,%0.1f,%0.1f\n", talents,
+			glyphs.GetPrime1(), glyphs.GetPrime2(), glyphs.GetPrime3(),
+			glyphs.GetMajor1(), glyphs.GetMajor2(), glyphs.GetMajor3(),
+			dps, dps-baseDps))
+	}
+
+	writeBuild(player.TalentsString+" (current)", player.Glyphs, baseDps)
+	for _, combo := range result.Results {
+		if combo.TalentLoadout == nil {
+			continue
+		}
+		writeBuild(combo.TalentLoadout.TalentsString, combo.TalentLoadout.Glyphs, combo.UnitMetrics.Dps.Avg)
+	}
+	return sb.String()
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	tuneIterations int32
+	tuneMaxPasses  int32
+	tuneObjective  string
+)
+
+var tuneCmd = &cobra.Command{
+	Use:   "tune",
+	Short: "tune the marked constants in APL rotations",
+	Long:  "search for the best values of the APL constants that have a tuning range, and report how sensitive the rotation is to each of them",
+	Run:   tuneMain,
+}
+
+func init() {
+	tuneCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	tuneCmd.Flags().StringVar(&outfile, "outfile", "", "location to write the tuned RaidSimRequest to")
+	tuneCmd.Flags().Int32Var(&tuneIterations, "iterations", 0, "iterations for each short sim, defaults to the input's iterations")
+	tuneCmd.Flags().Int32Var(&tuneMaxPasses, "passes", 3, "maximum number of passes over all tunable constants")
+	tuneCmd.Flags().StringVar(&tuneObjective, "objective", "dps", "what to maximize, dps, tps or hps")
+	tuneCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	tuneCmd.MarkFlagRequired("infile")
+}
+
+func tuneMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	objective, ok := map[string]proto.RotationTunerObjective{
+		"dps": proto.RotationTunerObjective_ObjectiveDps,
+		"tps": proto.RotationTunerObjective_ObjectiveTps,
+		"hps": proto.RotationTunerObjective_ObjectiveHps,
+	}[strings.ToLower(tuneObjective)]
+	if !ok {
+		log.Fatalf("unknown objective %q", tuneObjective)
+	}
+
+	request := &proto.RotationTunerRequest{
+		BaseRequest: input,
+		Iterations:  tuneIterations,
+		MaxPasses:   tuneMaxPasses,
+		Objective:   objective,
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.TuneRotationAsync(request, reporter)
+
+	var result *proto.RotationTunerResult
+	for v := range reporter {
+		if v.FinalRotationTunerResult != nil {
+			result = v.FinalRotationTunerResult
+			break
+		}
+		if verbose {
+			fmt.Printf("Sim Progress: %d / %d (completed %d / %d sims)\n", v.CompletedIterations, v.TotalIterations, v.CompletedSims, v.TotalSims)
+		}
+	}
+	if result.ErrorResult != "" {
+		log.Fatalf("rotation tuning failed: %s", result.ErrorResult)
+	}
+
+	fmt.Print(rotationTunerReport(result))
+
+	if outfile != "" {
+		output, err := protojson.MarshalOptions{Indent: "  "}.Marshal(result.TunedRequest)
+		if err != nil {
+			log.Fatalf("failed to marshal tuned request: %s", err)
+		}
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
+
+// Formats a tuner result as a human-readable report.
+func rotationTunerReport(result *proto.RotationTunerResult) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Objective: %0.2f -> %0.2f (%+0.2f +/- %0.2f) after %d passes\n",
+		result.OriginalObjective.Avg, result.TunedObjective.Avg,
+		result.Improvement.Avg, result.Improvement.ConfidenceInterval, result.NumPasses))
+	for _, param := range result.Parameters {
+		sb.WriteString(fmt.Sprintf("\n%s: %s -> %s\n", param.Name, param.OriginalVal, param.TunedVal))
+		for _, sample := range param.Samples {
+			sb.WriteString(fmt.Sprintf("  %10s  %10.2f  %+8.2f +/- %0.2f\n",
+				sample.Val, sample.Objective.Avg, sample.Delta.Avg, sample.Delta.ConfidenceInterval))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,16 @@
+package cata
+
+import (
+	_ "embed"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+// Effects defined as data rather than code, see proto.ProcEffect.
+//
+//go:embed proc_effects.json
+var procEffectsJson []byte
+
+func init() {
+	core.NewProcEffectsFromJson(procEffectsJson)
+}
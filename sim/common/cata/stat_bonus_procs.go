@@ -332,8 +332,8 @@ func init() {
 		Outcome:    core.OutcomeLanded,
 		ProcChance: 0.1,
 		ICD:        time.Second * 20,
-	}, func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) bool {
-		return sim.IsExecutePhase35()
+	}, func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) bool {
+		return sim.IsTargetExecutePhase35(result.Target)
 	})
 
 	shared.NewProcStatBonusEffectWithCustomCondition(shared.ProcStatBonusEffect{
@@ -347,8 +347,8 @@ func init() {
 		Outcome:    core.OutcomeLanded,
 		ProcChance: 0.1,
 		ICD:        time.Second * 20,
-	}, func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) bool {
-		return sim.IsExecutePhase35()
+	}, func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) bool {
+		return sim.IsTargetExecutePhase35(result.Target)
 	})
 
 	shared.NewProcStatBonusEffect(shared.ProcStatBonusEffect{
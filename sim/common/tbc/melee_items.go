@@ -55,7 +55,6 @@ func init() {
 			})
 		}
 
-		numHits := min(5, character.Env.GetNumTargets())
 		debuffAuras := make([]*core.Aura, len(character.Env.Encounter.TargetUnits))
 		for i, target := range character.Env.Encounter.TargetUnits {
 			debuffAuras[i] = makeDebuffAura(target)
@@ -70,6 +69,7 @@ func init() {
 			FlatThreatBonus:  63,
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				numHits := min(5, sim.Environment.GetNumTargets())
 				curTarget := target
 				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 					result := spell.CalcDamage(sim, curTarget, 0, spell.OutcomeMagicHit)
@@ -313,6 +313,7 @@ func init() {
 			ThreatMultiplier: 1,
 
 			Shield: core.ShieldConfig{
+				MaxAbsorb: 20000,
 				Aura: core.Aura{
 					Label:    "Val'anyr Shield",
 					Duration: time.Second * 30,
@@ -326,7 +327,6 @@ func init() {
 			Callback: core.CallbackOnHealDealt | core.CallbackOnPeriodicHealDealt,
 			Duration: time.Second * 15,
 			Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
-				// TODO: Shield needs to stack with itself up to 20k.
 				shieldSpell.Shield(result.Target).Apply(sim, result.Damage*0.15)
 			},
 		})
@@ -1064,7 +1064,8 @@ func init() {
 		})
 
 		petrifiedScarabActivation := character.RegisterSpell(core.SpellConfig{
-			ActionID: core.ActionID{ItemID: 21685},
+			ActionID:        core.ActionID{ItemID: 21685},
+			RelatedSelfBuff: mercurialShieldAura,
 			Cast: core.CastConfig{
 				CD: core.Cooldown{
 					Timer:    character.NewTimer(),
@@ -148,6 +148,17 @@ func PlayerProtoToSpec(player *proto.Player) proto.Spec {
 	return configSpecs[typeName]
 }
 
+// Whether players of the spec are measured by their healing rather than their
+// damage.
+func isHealingSpec(spec proto.Spec) bool {
+	switch spec {
+	case proto.Spec_SpecRestorationDruid, proto.Spec_SpecHolyPaladin, proto.Spec_SpecDisciplinePriest,
+		proto.Spec_SpecHolyPriest, proto.Spec_SpecRestorationShaman:
+		return true
+	}
+	return false
+}
+
 func RegisterAgentFactory(emptyOptions interface{}, spec proto.Spec, factory AgentFactory, specSetter SpecSetter) {
 	typeName := reflect.TypeOf(emptyOptions).Name()
 	if _, ok := agentFactories[typeName]; ok {
@@ -42,6 +42,54 @@ func StatWeightsAsync(request *proto.StatWeightsRequest, progress chan *proto.Pr
 	}()
 }
 
+/**
+ * Returns DPS/TPS/HPS over a range of values for one or two stats.
+ */
+func StatCurve(request *proto.StatCurveRequest) *proto.StatCurveResult {
+	return CalcStatCurve(request, nil)
+}
+
+func StatCurveAsync(request *proto.StatCurveRequest, progress chan *proto.ProgressMetrics) {
+	go func() {
+		result := CalcStatCurve(request, progress)
+		progress <- &proto.ProgressMetrics{
+			FinalStatCurveResult: result,
+		}
+	}()
+}
+
+/**
+ * Searches for the best values of the tunable constants in the APL rotations.
+ */
+func TuneRotation(request *proto.RotationTunerRequest) *proto.RotationTunerResult {
+	return CalcRotationTuning(request, nil)
+}
+
+func TuneRotationAsync(request *proto.RotationTunerRequest, progress chan *proto.ProgressMetrics) {
+	go func() {
+		result := CalcRotationTuning(request, progress)
+		progress <- &proto.ProgressMetrics{
+			FinalRotationTunerResult: result,
+		}
+	}()
+}
+
+/**
+ * Reruns single iterations of a sim with debug logs.
+ */
+func ReplayIteration(request *proto.ReplayIterationRequest) *proto.ReplayIterationResult {
+	return CalcReplayIteration(request, nil)
+}
+
+func ReplayIterationAsync(request *proto.ReplayIterationRequest, progress chan *proto.ProgressMetrics) {
+	go func() {
+		result := CalcReplayIteration(request, progress)
+		progress <- &proto.ProgressMetrics{
+			FinalReplayResult: result,
+		}
+	}()
+}
+
 /**
  * Runs multiple iterations of the sim with a full raid.
  */
@@ -60,3 +108,51 @@ func RunBulkSim(request *proto.BulkSimRequest) *proto.BulkSimResult {
 func RunBulkSimAsync(ctx context.Context, request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics) {
 	go BulkSim(ctx, request, progress)
 }
+
+/**
+ * Sims the player with each candidate profession pair and ranks them.
+ */
+func CompareProfessions(request *proto.ProfessionComparisonRequest) *proto.ProfessionComparisonResult {
+	return CalcProfessionComparison(request, nil)
+}
+
+func CompareProfessionsAsync(request *proto.ProfessionComparisonRequest, progress chan *proto.ProgressMetrics) {
+	go func() {
+		result := CalcProfessionComparison(request, progress)
+		progress <- &proto.ProgressMetrics{
+			FinalProfessionComparisonResult: result,
+		}
+	}()
+}
+
+/**
+ * Sims the player as each race the class can be and ranks them.
+ */
+func CompareRaces(request *proto.RaceComparisonRequest) *proto.RaceComparisonResult {
+	return CalcRaceComparison(request, nil)
+}
+
+func CompareRacesAsync(request *proto.RaceComparisonRequest, progress chan *proto.ProgressMetrics) {
+	go func() {
+		result := CalcRaceComparison(request, progress)
+		progress <- &proto.ProgressMetrics{
+			FinalRaceComparisonResult: result,
+		}
+	}()
+}
+
+/**
+ * Returns the DPS/HPS value of each buff, debuff and consumable, for each player.
+ */
+func BuffValues(request *proto.BuffValuesRequest) *proto.BuffValuesResult {
+	return CalcBuffValues(request, nil)
+}
+
+func BuffValuesAsync(request *proto.BuffValuesRequest, progress chan *proto.ProgressMetrics) {
+	go func() {
+		result := CalcBuffValues(request, progress)
+		progress <- &proto.ProgressMetrics{
+			FinalBuffValuesResult: result,
+		}
+	}()
+}
@@ -198,6 +198,9 @@ func (rot *APLRotation) reset(sim *Simulation) {
 	rot.inLoop = false
 	rot.interruptChannelIf = nil
 	rot.allowChannelRecastOnInterrupt = false
+	if rot.unit.playerModel != nil {
+		rot.unit.playerModel.reset()
+	}
 	for _, action := range rot.allAPLActions() {
 		action.impl.Reset(sim)
 	}
@@ -223,6 +226,10 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 		return
 	}
 
+	if pm := apl.unit.playerModel; pm != nil && pm.delayRotation(sim) {
+		return
+	}
+
 	i := 0
 	apl.inLoop = true
 
@@ -239,6 +246,11 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 		apl.unit.Log(sim, "No available actions!")
 	}
 
+	// The player reacts again once they are free to act after this action.
+	if pm := apl.unit.playerModel; pm != nil && i > 0 {
+		pm.reacted = false
+	}
+
 	gcdReady := apl.unit.GCD.IsReady(sim)
 	if gcdReady {
 		apl.unit.WaitUntil(sim, sim.CurrentTime+apl.unit.ReactionTime)
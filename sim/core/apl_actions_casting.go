@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/wowsims/cata/sim/core/proto"
 )
@@ -175,8 +176,8 @@ func (action *APLActionMultidot) IsReady(sim *Simulation) bool {
 			}
 		}
 	} else {
-		for i := int32(0); i < action.maxDots; i++ {
-			target := sim.Encounter.TargetUnits[i]
+		targets := sim.Encounter.ActiveTargetUnits
+		for _, target := range targets[:min(int(action.maxDots), len(targets))] {
 			dot := action.spell.Dot(target)
 			if (!dot.IsActive() || dot.RemainingDuration(sim) < maxOverlap) && action.spell.CanCast(sim, target) {
 				action.nextTarget = target
@@ -254,6 +255,58 @@ func (action *APLActionMultishield) String() string {
 	return fmt.Sprintf("Multishield(%s)", action.spell.ActionID)
 }
 
+type APLActionCycleTargets struct {
+	defaultAPLActionImpl
+	unit        *Unit
+	innerAction *APLAction
+
+	nextTarget *Unit
+}
+
+func (rot *APLRotation) newActionCycleTargets(config *proto.APLActionCycleTargets) APLActionImpl {
+	innerAction := rot.newAPLAction(config.Action)
+	if innerAction == nil {
+		return nil
+	}
+	return &APLActionCycleTargets{
+		unit:        rot.unit,
+		innerAction: innerAction,
+	}
+}
+func (action *APLActionCycleTargets) GetInnerActions() []*APLAction {
+	return []*APLAction{action.innerAction}
+}
+func (action *APLActionCycleTargets) Reset(*Simulation) {
+	action.nextTarget = nil
+}
+
+func (action *APLActionCycleTargets) IsReady(sim *Simulation) bool {
+	curTarget := action.unit.CurrentTarget
+	defer func() { action.unit.CurrentTarget = curTarget }()
+
+	// Try the current target first, then the following targets in encounter order.
+	targets := sim.Encounter.ActiveTargetUnits
+	start := max(0, slices.Index(targets, curTarget))
+	for i := range targets {
+		target := targets[(start+i)%len(targets)]
+		action.unit.CurrentTarget = target
+		if action.innerAction.IsReady(sim) {
+			action.nextTarget = target
+			return true
+		}
+	}
+	return false
+}
+func (action *APLActionCycleTargets) Execute(sim *Simulation) {
+	curTarget := action.unit.CurrentTarget
+	action.unit.CurrentTarget = action.nextTarget
+	action.innerAction.Execute(sim)
+	action.unit.CurrentTarget = curTarget
+}
+func (action *APLActionCycleTargets) String() string {
+	return fmt.Sprintf("Cycle Targets(%s)", action.innerAction)
+}
+
 type APLActionAutocastOtherCooldowns struct {
 	defaultAPLActionImpl
 	character *Character
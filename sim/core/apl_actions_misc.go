@@ -192,3 +192,35 @@ func (action *APLActionCustomRotation) Execute(sim *Simulation) {
 func (action *APLActionCustomRotation) String() string {
 	return "Custom Rotation()"
 }
+
+type APLActionTaunt struct {
+	defaultAPLActionImpl
+	spell  *Spell
+	target UnitReference
+}
+
+func (rot *APLRotation) newActionTaunt(config *proto.APLActionTaunt) APLActionImpl {
+	spell := rot.unit.TauntSpell()
+	if spell == nil {
+		rot.ValidationWarning("Taunt requires a tank listed in the raid's tanks")
+		return nil
+	}
+	target := rot.GetTargetUnit(config.Target)
+	if target.Get() == nil {
+		return nil
+	}
+	return &APLActionTaunt{
+		spell:  spell,
+		target: target,
+	}
+}
+func (action *APLActionTaunt) IsReady(sim *Simulation) bool {
+	target := action.target.Get()
+	return target.CurrentTarget != action.spell.Unit && action.spell.CanCast(sim, target)
+}
+func (action *APLActionTaunt) Execute(sim *Simulation) {
+	action.spell.Cast(sim, action.target.Get())
+}
+func (action *APLActionTaunt) String() string {
+	return fmt.Sprintf("Taunt(%s)", action.target.Get().Label)
+}
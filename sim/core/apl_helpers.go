@@ -180,20 +180,37 @@ func (rot *APLRotation) GetTargetAPLSpell(spellId *proto.ActionID, targetUnit Un
 	return spell
 }
 
-func (rot *APLRotation) GetAPLDot(targetUnit UnitReference, spellId *proto.ActionID) *Dot {
+type DotReference struct {
+	fixedDot *Dot
+
+	spell           *Spell
+	curTargetSource *Unit
+}
+
+func (dr *DotReference) Get() *Dot {
+	if dr.fixedDot != nil {
+		return dr.fixedDot
+	} else if dr.curTargetSource != nil {
+		return dr.spell.Dot(dr.curTargetSource.CurrentTarget)
+	} else {
+		return nil
+	}
+}
+
+func (rot *APLRotation) GetAPLDot(targetUnit UnitReference, spellId *proto.ActionID) DotReference {
 	spell := rot.GetAPLSpell(spellId)
 
 	if spell == nil {
-		return nil
+		return DotReference{}
 	} else if spell.AOEDot() != nil {
-		return spell.AOEDot()
+		return DotReference{fixedDot: spell.AOEDot()}
+	} else if targetUnit.fixedUnit != nil {
+		return DotReference{fixedDot: spell.Dot(targetUnit.fixedUnit)}
+	} else if targetUnit.curTargetSource != nil {
+		// Follows the current target, so values stay correct when cycling targets.
+		return DotReference{spell: spell, curTargetSource: targetUnit.curTargetSource}
 	} else {
-		target := targetUnit.Get()
-		if target != nil {
-			return spell.Dot(target)
-		} else {
-			return spell.CurDot()
-		}
+		return DotReference{fixedDot: spell.CurDot()}
 	}
 }
 
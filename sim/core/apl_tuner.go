@@ -0,0 +1,261 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	defaultTunerMaxPasses   = 3
+	defaultTunerNumSteps    = 10
+	maxTunerValuesPerConst  = 100
+	tunerImprovementEpsilon = 1e-9
+)
+
+// A tunable APLValueConst.
+type tunerParameter struct {
+	name        string
+	originalVal string
+	values      []string
+}
+
+// Returns all tunable consts within msg, in a stable order.
+func findTunableConsts(msg protoreflect.Message) []*proto.APLValueConst {
+	var consts []*proto.APLValueConst
+	if aplConst, ok := msg.Interface().(*proto.APLValueConst); ok {
+		if aplConst.Tuning != nil {
+			consts = append(consts, aplConst)
+		}
+		return consts
+	}
+
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() == nil || fd.IsMap() || !msg.Has(fd) {
+			continue
+		}
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				consts = append(consts, findTunableConsts(list.Get(j).Message())...)
+			}
+		} else {
+			consts = append(consts, findTunableConsts(msg.Get(fd).Message())...)
+		}
+	}
+	return consts
+}
+
+// Formats value in the same units as the original const value.
+func formatTunedConst(originalVal string, value float64) string {
+	// Hide floating point noise from summing up steps.
+	value = math.Round(value*1e6) / 1e6
+	lastChar := originalVal[len(originalVal)-1]
+	if lastChar == '%' {
+		return strconv.FormatFloat(value, 'f', -1, 64) + "%"
+	}
+	if _, err := time.ParseDuration(originalVal); err == nil && (lastChar < '0' || lastChar > '9') {
+		return DurationFromSeconds(value).String()
+	}
+	if _, err := strconv.Atoi(originalVal); err == nil {
+		return strconv.Itoa(int(math.Round(value)))
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func newTunerParameter(aplConst *proto.APLValueConst) (*tunerParameter, error) {
+	tuning := aplConst.Tuning
+	name := tuning.Name
+	if name == "" {
+		name = aplConst.Val
+	}
+	if aplConst.Val == "" || tuning.Max < tuning.Min {
+		return nil, fmt.Errorf("invalid tuning range for %q", name)
+	}
+
+	step := tuning.Step
+	if step <= 0 {
+		step = (tuning.Max - tuning.Min) / defaultTunerNumSteps
+	}
+	param := &tunerParameter{
+		name:        name,
+		originalVal: aplConst.Val,
+	}
+	seen := make(map[string]bool)
+	for i := 0; ; i++ {
+		value := tuning.Min + step*float64(i)
+		if value > tuning.Max+step*1e-6 || step == 0 && i > 0 {
+			break
+		}
+		if i >= maxTunerValuesPerConst {
+			return nil, fmt.Errorf("tuning range for %q has more than %d values", name, maxTunerValuesPerConst)
+		}
+		val := formatTunedConst(aplConst.Val, value)
+		if !seen[val] {
+			seen[val] = true
+			param.values = append(param.values, val)
+		}
+	}
+	return param, nil
+}
+
+// Returns the per-iteration values of the objective, summed over the raid.
+func tunerObjectiveValues(result *proto.RaidSimResult, objective proto.RotationTunerObjective) []float64 {
+	if objective == proto.RotationTunerObjective_ObjectiveDps {
+		return result.RaidMetrics.Dps.AllValues
+	}
+
+	var values []float64
+	for _, party := range result.RaidMetrics.Parties {
+		for _, player := range party.Players {
+			playerValues := player.Threat.AllValues
+			if objective == proto.RotationTunerObjective_ObjectiveHps {
+				playerValues = player.Hps.AllValues
+			}
+			if values == nil {
+				values = make([]float64, len(playerValues))
+			}
+			for i := 0; i < min(len(values), len(playerValues)); i++ {
+				values[i] += playerValues[i]
+			}
+		}
+	}
+	return values
+}
+
+func averageOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// Searches for the best values of the tunable consts in the request's APL
+// rotations, using coordinate descent: each pass sweeps one value at a time
+// while holding the others fixed, and keeps the best one. All sims share random
+// numbers so that small differences between values are still visible with
+// short sims.
+func CalcRotationTuning(request *proto.RotationTunerRequest, progress chan *proto.ProgressMetrics) *proto.RotationTunerResult {
+	if request.BaseRequest == nil {
+		return &proto.RotationTunerResult{ErrorResult: "Rotation tuner needs a base request"}
+	}
+
+	baseRequest := googleProto.Clone(request.BaseRequest).(*proto.RaidSimRequest)
+	baseRequest.SimOptions = commonRandomNumbersOptions(baseRequest.SimOptions)
+	if request.Iterations > 0 {
+		baseRequest.SimOptions.Iterations = request.Iterations
+	}
+
+	var params []*tunerParameter
+	for _, aplConst := range findTunableConsts(baseRequest.ProtoReflect()) {
+		param, err := newTunerParameter(aplConst)
+		if err != nil {
+			return &proto.RotationTunerResult{ErrorResult: err.Error()}
+		}
+		params = append(params, param)
+	}
+	if len(params) == 0 {
+		return &proto.RotationTunerResult{ErrorResult: "No tunable values found in the rotation"}
+	}
+
+	maxPasses := int(request.MaxPasses)
+	if maxPasses <= 0 {
+		maxPasses = defaultTunerMaxPasses
+	}
+
+	// Returns a copy of the base request using the given const values.
+	newRequest := func(vals []string) *proto.RaidSimRequest {
+		simRequest := googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+		for i, aplConst := range findTunableConsts(simRequest.ProtoReflect()) {
+			aplConst.Val = vals[i]
+		}
+		return simRequest
+	}
+
+	originalVals := make([]string, len(params))
+	for i, param := range params {
+		originalVals[i] = param.originalVal
+	}
+	results, errorStr := runSimBatch([]*proto.RaidSimRequest{newRequest(originalVals)}, progress)
+	if errorStr != "" {
+		return &proto.RotationTunerResult{ErrorResult: errorStr}
+	}
+	originalValues := tunerObjectiveValues(results[0], request.Objective)
+
+	tunedVals := append([]string{}, originalVals...)
+	tunedValues := originalValues
+	samples := make([][]*proto.RotationTunerSample, len(params))
+
+	numPasses := 0
+	for changed := true; changed && numPasses < maxPasses; numPasses++ {
+		changed = false
+		for paramIdx, param := range params {
+			requests := make([]*proto.RaidSimRequest, len(param.values))
+			for i, val := range param.values {
+				vals := append([]string{}, tunedVals...)
+				vals[paramIdx] = val
+				requests[i] = newRequest(vals)
+			}
+			results, errorStr := runSimBatch(requests, progress)
+			if errorStr != "" {
+				return &proto.RotationTunerResult{ErrorResult: errorStr}
+			}
+
+			sweepValues := make([][]float64, len(results))
+			bestIdx := -1
+			bestAvg := averageOf(tunedValues)
+			for i, result := range results {
+				sweepValues[i] = tunerObjectiveValues(result, request.Objective)
+				if avg := averageOf(sweepValues[i]); avg > bestAvg+tunerImprovementEpsilon {
+					bestIdx = i
+					bestAvg = avg
+				}
+			}
+			if bestIdx != -1 {
+				tunedVals[paramIdx] = param.values[bestIdx]
+				tunedValues = sweepValues[bestIdx]
+				changed = true
+			}
+
+			samples[paramIdx] = nil
+			for i, values := range sweepValues {
+				samples[paramIdx] = append(samples[paramIdx], &proto.RotationTunerSample{
+					Val:       param.values[i],
+					Objective: newMetricEstimate(values),
+					Delta:     newPairedMetricEstimate(tunedValues, values),
+				})
+			}
+		}
+	}
+
+	tunerResult := &proto.RotationTunerResult{
+		TunedRequest:      newRequest(tunedVals),
+		OriginalObjective: newMetricEstimate(originalValues),
+		TunedObjective:    newMetricEstimate(tunedValues),
+		Improvement:       newPairedMetricEstimate(originalValues, tunedValues),
+		NumPasses:         int32(numPasses),
+	}
+	// Give back the user's sim options rather than the tuner's.
+	tunerResult.TunedRequest.SimOptions = request.BaseRequest.SimOptions
+
+	for i, param := range params {
+		tunerResult.Parameters = append(tunerResult.Parameters, &proto.RotationTunerParameter{
+			Name:        param.name,
+			OriginalVal: param.originalVal,
+			TunedVal:    tunedVals[i],
+			Samples:     samples[i],
+		})
+	}
+	return tunerResult
+}
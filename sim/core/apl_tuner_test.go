@@ -0,0 +1,48 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestTunerParameterValues(t *testing.T) {
+	for _, tc := range []struct {
+		val    string
+		tuning *proto.APLValueConstTuning
+		want   []string
+	}{
+		{val: "50", tuning: &proto.APLValueConstTuning{Min: 40, Max: 60, Step: 10}, want: []string{"40", "50", "60"}},
+		{val: "2s", tuning: &proto.APLValueConstTuning{Min: 0.5, Max: 1.5, Step: 0.5}, want: []string{"500ms", "1s", "1.5s"}},
+		{val: "20%", tuning: &proto.APLValueConstTuning{Min: 10, Max: 30, Step: 10}, want: []string{"10%", "20%", "30%"}},
+		{val: "0.5", tuning: &proto.APLValueConstTuning{Min: 0, Max: 1}, want: []string{"0", "0.1", "0.2", "0.3", "0.4", "0.5", "0.6", "0.7", "0.8", "0.9", "1"}},
+	} {
+		param, err := newTunerParameter(&proto.APLValueConst{Val: tc.val, Tuning: tc.tuning})
+		if err != nil {
+			t.Fatalf("newTunerParameter(%q) failed: %v", tc.val, err)
+		}
+		if diff := cmp.Diff(tc.want, param.values); diff != "" {
+			t.Fatalf("newTunerParameter(%q) returned unexpected values (-want +got):\n%s", tc.val, diff)
+		}
+	}
+}
+
+func TestFindTunableConsts(t *testing.T) {
+	tunable := &proto.APLValueConst{Val: "80", Tuning: &proto.APLValueConstTuning{Min: 50, Max: 100}}
+	rotation := &proto.APLRotation{
+		PriorityList: []*proto.APLListItem{{
+			Action: &proto.APLAction{
+				Condition: &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+					Lhs: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: "1"}}},
+					Rhs: &proto.APLValue{Value: &proto.APLValue_Const{Const: tunable}},
+				}}},
+			},
+		}},
+	}
+
+	consts := findTunableConsts(rotation.ProtoReflect())
+	if len(consts) != 1 || consts[0] != tunable {
+		t.Fatalf("findTunableConsts() = %v, want only the tunable const", consts)
+	}
+}
@@ -99,6 +99,10 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 		return rot.newValueBossSpellIsCasting(config.GetBossSpellIsCasting())
 	case *proto.APLValue_BossSpellTimeToReady:
 		return rot.newValueBossSpellTimeToReady(config.GetBossSpellTimeToReady())
+	case *proto.APLValue_BossTimeToNextDamageSpike:
+		return rot.newValueBossTimeToNextDamageSpike(config.GetBossTimeToNextDamageSpike())
+	case *proto.APLValue_BossNextDamageSpikeDamage:
+		return rot.newValueBossNextDamageSpikeDamage(config.GetBossNextDamageSpikeDamage())
 
 	// Resources
 	case *proto.APLValue_CurrentHealth:
@@ -185,6 +189,8 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 		return rot.newValueDotIsActive(config.GetDotIsActive())
 	case *proto.APLValue_DotRemainingTime:
 		return rot.newValueDotRemainingTime(config.GetDotRemainingTime())
+	case *proto.APLValue_DotMissingTargets:
+		return rot.newValueDotMissingTargets(config.GetDotMissingTargets())
 	case *proto.APLValue_DotTickFrequency:
 		return rot.newValueDotTickFrequency(config.GetDotTickFrequency())
 
@@ -49,8 +49,69 @@ func (value *APLValueBossSpellTimeToReady) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeDuration
 }
 func (value *APLValueBossSpellTimeToReady) GetDuration(sim *Simulation) time.Duration {
+	// Spells published as damage spikes may be used later than their cooldown
+	// allows, e.g. enrages at some boss health.
+	target := sim.Encounter.Targets[value.spell.Unit.Index]
+	for _, spike := range target.DamageSpikes(sim) {
+		if spike.Spell == value.spell {
+			if spike.NextAt == NeverExpires {
+				return NeverExpires
+			}
+			return max(0, spike.NextAt-sim.CurrentTime)
+		}
+	}
 	return value.spell.TimeToReady(sim)
 }
 func (value *APLValueBossSpellTimeToReady) String() string {
 	return fmt.Sprintf("Boss Spell Time to Ready(%s)", value.spell.ActionID)
 }
+
+type APLValueBossTimeToNextDamageSpike struct {
+	DefaultAPLValueImpl
+	unit      *Unit
+	minDamage float64
+}
+
+func (rot *APLRotation) newValueBossTimeToNextDamageSpike(config *proto.APLValueBossTimeToNextDamageSpike) APLValue {
+	return &APLValueBossTimeToNextDamageSpike{
+		unit:      rot.unit,
+		minDamage: config.MinDamage,
+	}
+}
+func (value *APLValueBossTimeToNextDamageSpike) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueBossTimeToNextDamageSpike) GetDuration(sim *Simulation) time.Duration {
+	spike, ok := value.unit.Env.NextDamageSpike(sim, value.unit, value.minDamage)
+	if !ok {
+		return NeverExpires
+	}
+	return max(0, spike.NextAt-sim.CurrentTime)
+}
+func (value *APLValueBossTimeToNextDamageSpike) String() string {
+	return fmt.Sprintf("Time to Next Damage Spike(%0.0f)", value.minDamage)
+}
+
+type APLValueBossNextDamageSpikeDamage struct {
+	DefaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueBossNextDamageSpikeDamage(_ *proto.APLValueBossNextDamageSpikeDamage) APLValue {
+	return &APLValueBossNextDamageSpikeDamage{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueBossNextDamageSpikeDamage) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueBossNextDamageSpikeDamage) GetFloat(sim *Simulation) float64 {
+	spike, ok := value.unit.Env.NextDamageSpike(sim, value.unit, 0)
+	if !ok {
+		return 0
+	}
+	return spike.Damage
+}
+func (value *APLValueBossNextDamageSpikeDamage) String() string {
+	return "Next Damage Spike Damage()"
+}
@@ -9,12 +9,12 @@ import (
 
 type APLValueDotIsActive struct {
 	DefaultAPLValueImpl
-	dot *Dot
+	dot DotReference
 }
 
 func (rot *APLRotation) newValueDotIsActive(config *proto.APLValueDotIsActive) APLValue {
 	dot := rot.GetAPLDot(rot.GetTargetUnit(config.TargetUnit), config.SpellId)
-	if dot == nil {
+	if dot.Get() == nil {
 		return nil
 	}
 	return &APLValueDotIsActive{
@@ -25,20 +25,20 @@ func (value *APLValueDotIsActive) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeBool
 }
 func (value *APLValueDotIsActive) GetBool(sim *Simulation) bool {
-	return value.dot.IsActive()
+	return value.dot.Get().IsActive()
 }
 func (value *APLValueDotIsActive) String() string {
-	return fmt.Sprintf("Dot Is Active(%s)", value.dot.Spell.ActionID)
+	return fmt.Sprintf("Dot Is Active(%s)", value.dot.Get().Spell.ActionID)
 }
 
 type APLValueDotRemainingTime struct {
 	DefaultAPLValueImpl
-	dot *Dot
+	dot DotReference
 }
 
 func (rot *APLRotation) newValueDotRemainingTime(config *proto.APLValueDotRemainingTime) APLValue {
 	dot := rot.GetAPLDot(rot.GetTargetUnit(config.TargetUnit), config.SpellId)
-	if dot == nil {
+	if dot.Get() == nil {
 		return nil
 	}
 	return &APLValueDotRemainingTime{
@@ -49,20 +49,20 @@ func (value *APLValueDotRemainingTime) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeDuration
 }
 func (value *APLValueDotRemainingTime) GetDuration(sim *Simulation) time.Duration {
-	return value.dot.RemainingDuration(sim)
+	return value.dot.Get().RemainingDuration(sim)
 }
 func (value *APLValueDotRemainingTime) String() string {
-	return fmt.Sprintf("Dot Remaining Time(%s)", value.dot.Spell.ActionID)
+	return fmt.Sprintf("Dot Remaining Time(%s)", value.dot.Get().Spell.ActionID)
 }
 
 type APLValueDotTickFrequency struct {
 	DefaultAPLValueImpl
-	dot *Dot
+	dot DotReference
 }
 
 func (rot *APLRotation) newValueDotTickFrequency(config *proto.APLValueDotTickFrequency) APLValue {
 	dot := rot.GetAPLDot(rot.GetTargetUnit(config.TargetUnit), config.SpellId)
-	if dot == nil {
+	if dot.Get() == nil {
 		return nil
 	}
 	return &APLValueDotTickFrequency{
@@ -74,8 +74,57 @@ func (value *APLValueDotTickFrequency) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeDuration
 }
 func (value *APLValueDotTickFrequency) GetDuration(_ *Simulation) time.Duration {
-	return value.dot.tickPeriod
+	return value.dot.Get().tickPeriod
 }
 func (value *APLValueDotTickFrequency) String() string {
-	return fmt.Sprintf("Dot Tick Frequency(%s)", value.dot.tickPeriod)
+	return fmt.Sprintf("Dot Tick Frequency(%s)", value.dot.Get().tickPeriod)
+}
+
+type APLValueDotMissingTargets struct {
+	DefaultAPLValueImpl
+	spell      *Spell
+	targets    *[]*Unit // Read live, since targets can die in health fights.
+	maxOverlap APLValue
+}
+
+func (rot *APLRotation) newValueDotMissingTargets(config *proto.APLValueDotMissingTargets) APLValue {
+	spell := rot.GetAPLMultidotSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+
+	maxOverlap := rot.coerceTo(rot.newAPLValue(config.MaxOverlap), proto.APLValueType_ValueTypeDuration)
+	if maxOverlap == nil {
+		maxOverlap = rot.newValueConst(&proto.APLValueConst{Val: "0ms"})
+	}
+
+	targets := &rot.unit.Env.Encounter.ActiveTargetUnits
+	if spell.Flags.Matches(SpellFlagHelpful) {
+		targets = &rot.unit.Env.Raid.AllPlayerUnits
+	}
+
+	return &APLValueDotMissingTargets{
+		spell:      spell,
+		targets:    targets,
+		maxOverlap: maxOverlap,
+	}
+}
+func (value *APLValueDotMissingTargets) GetInnerValues() []APLValue {
+	return []APLValue{value.maxOverlap}
+}
+func (value *APLValueDotMissingTargets) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueDotMissingTargets) GetInt(sim *Simulation) int32 {
+	maxOverlap := value.maxOverlap.GetDuration(sim)
+	missing := int32(0)
+	for _, target := range *value.targets {
+		if dot := value.spell.Dot(target); !dot.IsActive() || dot.RemainingDuration(sim) < maxOverlap {
+			missing++
+		}
+	}
+	return missing
+}
+func (value *APLValueDotMissingTargets) String() string {
+	return fmt.Sprintf("Dot Missing Targets(%s, %s)", value.spell.ActionID, value.maxOverlap)
 }
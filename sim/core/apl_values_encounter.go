@@ -94,6 +94,7 @@ func (value *APLValueNumberTargets) String() string {
 
 type APLValueIsExecutePhase struct {
 	DefaultAPLValueImpl
+	unit      *Unit
 	threshold proto.APLValueIsExecutePhase_ExecutePhaseThreshold
 }
 
@@ -102,6 +103,7 @@ func (rot *APLRotation) newValueIsExecutePhase(config *proto.APLValueIsExecutePh
 		return nil
 	}
 	return &APLValueIsExecutePhase{
+		unit:      rot.unit,
 		threshold: config.Threshold,
 	}
 }
@@ -109,14 +111,15 @@ func (value *APLValueIsExecutePhase) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeBool
 }
 func (value *APLValueIsExecutePhase) GetBool(sim *Simulation) bool {
+	target := value.unit.CurrentTarget
 	if value.threshold == proto.APLValueIsExecutePhase_E20 {
-		return sim.IsExecutePhase20()
+		return sim.IsTargetExecutePhase20(target)
 	} else if value.threshold == proto.APLValueIsExecutePhase_E25 {
-		return sim.IsExecutePhase25()
+		return sim.IsTargetExecutePhase25(target)
 	} else if value.threshold == proto.APLValueIsExecutePhase_E35 {
-		return sim.IsExecutePhase35()
+		return sim.IsTargetExecutePhase35(target)
 	} else if value.threshold == proto.APLValueIsExecutePhase_E90 {
-		return sim.IsExecutePhase90()
+		return sim.IsTargetExecutePhase90(target)
 	} else {
 		panic("Should never reach here")
 	}
@@ -42,3 +42,90 @@ func TestValueConst(t *testing.T) {
 		t.Fatalf("Unexpected coerced duration value %s", coercedDurVal.GetDuration(sim))
 	}
 }
+
+func TestActionCycleTargets(t *testing.T) {
+	spellID := ActionID{SpellID: 42}.ToProto()
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+			Rotation: &proto.APLRotation{
+				Type: proto.APLRotation_TypeAPL,
+				PriorityList: []*proto.APLListItem{{Action: &proto.APLAction{
+					Action: &proto.APLAction_CycleTargets{CycleTargets: &proto.APLActionCycleTargets{Action: &proto.APLAction{
+						Condition: &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{
+							Val: &proto.APLValue{Value: &proto.APLValue_DotIsActive{DotIsActive: &proto.APLValueDotIsActive{SpellId: spellID}}},
+						}}},
+						Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: spellID}},
+					}}},
+				}}},
+			},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 88}, {Name: "target", Level: 88}, {Name: "target", Level: 88}},
+			Duration: 60,
+		},
+	})
+	sim.Reset()
+	sim.PrePull()
+	for sim.CurrentTime < time.Second {
+		sim.Step()
+	}
+
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	for _, target := range sim.Encounter.TargetUnits {
+		if !fa.Spell.Dot(target).IsActive() {
+			t.Fatalf("Expected the dot to be cycled onto %s", target.Label)
+		}
+	}
+	if fa.CurrentTarget != sim.Encounter.TargetUnits[0] {
+		t.Fatalf("Cycling targets should not change the current target, got %s", fa.CurrentTarget.Label)
+	}
+}
+
+func TestValueDotMissingTargets(t *testing.T) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 88}, {Name: "target", Level: 88}, {Name: "target", Level: 88}},
+			Duration: 60,
+		},
+	})
+	sim.Reset()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	rot := &APLRotation{
+		unit: &fa.Unit,
+	}
+
+	missingTargets := func(maxOverlap string) int32 {
+		return rot.newValueDotMissingTargets(&proto.APLValueDotMissingTargets{
+			SpellId:    ActionID{SpellID: 42}.ToProto(),
+			MaxOverlap: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: maxOverlap}}},
+		}).GetInt(sim)
+	}
+
+	if missing := missingTargets("0s"); missing != 3 {
+		t.Fatalf("Expected 3 targets missing the dot, got %d", missing)
+	}
+	fa.Spell.Dot(sim.Encounter.TargetUnits[1]).Apply(sim)
+	if missing := missingTargets("0s"); missing != 2 {
+		t.Fatalf("Expected 2 targets missing the dot, got %d", missing)
+	}
+	// The dot lasts 18s, so it is about to fall off with a longer overlap.
+	if missing := missingTargets("20s"); missing != 3 {
+		t.Fatalf("Expected 3 targets missing the dot with a 20s overlap, got %d", missing)
+	}
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"sort"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Buff, debuff or consumable messages of a request whose fields are toggled to
+// find their values.
+type buffGroup struct {
+	prefix string
+	// Index of the player in the result's players, or -1 for raid-wide buffs.
+	player int
+	get    func(request *proto.RaidSimRequest) googleProto.Message
+}
+
+// A single field of a buffGroup, turned off or on.
+type buffToggle struct {
+	group *buffGroup
+	field protoreflect.FieldDescriptor
+	added bool
+}
+
+func (toggle *buffToggle) name() string {
+	return toggle.group.prefix + "." + string(toggle.field.Name())
+}
+
+func (toggle *buffToggle) apply(request *proto.RaidSimRequest) {
+	msg := toggle.group.get(request).ProtoReflect()
+	if !toggle.added {
+		msg.Clear(toggle.field)
+	} else if toggle.field.Kind() == protoreflect.BoolKind {
+		msg.Set(toggle.field, protoreflect.ValueOfBool(true))
+	} else {
+		msg.Set(toggle.field, protoreflect.ValueOfInt32(1))
+	}
+}
+
+// Returns a toggle for each field of the message which is set, and if
+// includeAdded, for each bool or count field which isn't. Enum fields, like
+// flasks or food, are never added: there is no single value to add, and adding
+// each would mean a sim per flask, food and potion.
+func buffToggles(group *buffGroup, msg protoreflect.Message, includeAdded bool) []*buffToggle {
+	var toggles []*buffToggle
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsList() || fd.IsMap() {
+			continue
+		}
+		switch fd.Kind() {
+		case protoreflect.BoolKind, protoreflect.Int32Kind:
+		case protoreflect.EnumKind:
+			if !msg.Has(fd) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		if msg.Has(fd) {
+			toggles = append(toggles, &buffToggle{group: group, field: fd})
+		} else if includeAdded {
+			toggles = append(toggles, &buffToggle{group: group, field: fd, added: true})
+		}
+	}
+	return toggles
+}
+
+// Finds what each raid buff, debuff, individual buff and consumable of the
+// request is worth to each player, by simming the request with each of them
+// turned off, and optionally each missing one turned on, with common random
+// numbers.
+func CalcBuffValues(request *proto.BuffValuesRequest, progress chan *proto.ProgressMetrics) *proto.BuffValuesResult {
+	if request.GetBaseRequest().GetRaid() == nil {
+		return &proto.BuffValuesResult{ErrorResult: "Buff values need a base request"}
+	}
+
+	baseRequest := googleProto.Clone(request.BaseRequest).(*proto.RaidSimRequest)
+	raid := baseRequest.Raid
+	if raid.Buffs == nil {
+		raid.Buffs = &proto.RaidBuffs{}
+	}
+	if raid.Debuffs == nil {
+		raid.Debuffs = &proto.Debuffs{}
+	}
+
+	groups := []*buffGroup{
+		{prefix: "raid_buffs", player: -1, get: func(r *proto.RaidSimRequest) googleProto.Message { return r.Raid.Buffs }},
+		{prefix: "debuffs", player: -1, get: func(r *proto.RaidSimRequest) googleProto.Message { return r.Raid.Debuffs }},
+	}
+
+	numParties := int(raid.NumActiveParties)
+	if numParties == 0 {
+		numParties = len(raid.Parties)
+	}
+	result := &proto.BuffValuesResult{}
+	var healers []bool
+	for partyIdx, party := range raid.Parties[:min(numParties, len(raid.Parties))] {
+		for playerIdx, player := range party.GetPlayers() {
+			if player == nil || player.Class == proto.Class_ClassUnknown {
+				continue
+			}
+			if player.Buffs == nil {
+				player.Buffs = &proto.IndividualBuffs{}
+			}
+			if player.Consumes == nil {
+				player.Consumes = &proto.Consumes{}
+			}
+
+			partyIdx, playerIdx := partyIdx, playerIdx
+			groups = append(groups,
+				&buffGroup{prefix: "buffs", player: len(result.Players), get: func(r *proto.RaidSimRequest) googleProto.Message {
+					return r.Raid.Parties[partyIdx].Players[playerIdx].Buffs
+				}},
+				&buffGroup{prefix: "consumes", player: len(result.Players), get: func(r *proto.RaidSimRequest) googleProto.Message {
+					return r.Raid.Parties[partyIdx].Players[playerIdx].Consumes
+				}},
+			)
+			healers = append(healers, player.GetSpec() != nil && isHealingSpec(PlayerProtoToSpec(player)))
+			result.Players = append(result.Players, &proto.PlayerBuffValues{
+				Name:        player.Name,
+				PartyIndex:  int32(partyIdx),
+				PlayerIndex: int32(playerIdx),
+			})
+		}
+	}
+	if len(result.Players) == 0 {
+		return &proto.BuffValuesResult{ErrorResult: "Buff values need a player"}
+	}
+	baseRequest.SimOptions = commonRandomNumbersOptions(baseRequest.SimOptions)
+
+	var toggles []*buffToggle
+	for _, group := range groups {
+		toggles = append(toggles, buffToggles(group, group.get(baseRequest).ProtoReflect(), request.IncludeAdded)...)
+	}
+
+	// The first request is the base request, which the values are measured against.
+	requests := []*proto.RaidSimRequest{baseRequest}
+	for _, toggle := range toggles {
+		simRequest := googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+		toggle.apply(simRequest)
+		requests = append(requests, simRequest)
+	}
+
+	results, errorStr := runSimBatch(requests, progress)
+	if errorStr != "" {
+		return &proto.BuffValuesResult{ErrorResult: errorStr}
+	}
+
+	for i, playerValues := range result.Players {
+		playerMetrics := func(simResult *proto.RaidSimResult) *proto.UnitMetrics {
+			return simResult.RaidMetrics.Parties[playerValues.PartyIndex].Players[playerValues.PlayerIndex]
+		}
+		baseline := playerMetrics(results[0])
+
+		for j, toggle := range toggles {
+			if toggle.group.player != -1 && toggle.group.player != i {
+				continue
+			}
+			toggled := playerMetrics(results[j+1])
+			value := &proto.BuffValue{Name: toggle.name(), Added: toggle.added}
+			if toggle.added {
+				value.DpsValue = newPairedMetricEstimate(baseline.Dps.AllValues, toggled.Dps.AllValues)
+				value.HpsValue = newPairedMetricEstimate(baseline.Hps.AllValues, toggled.Hps.AllValues)
+			} else {
+				value.DpsValue = newPairedMetricEstimate(toggled.Dps.AllValues, baseline.Dps.AllValues)
+				value.HpsValue = newPairedMetricEstimate(toggled.Hps.AllValues, baseline.Hps.AllValues)
+			}
+			playerValues.Values = append(playerValues.Values, value)
+		}
+		// Ranks by the metric of the player's role.
+		roleValue := func(value *proto.BuffValue) float64 {
+			if healers[i] {
+				return value.HpsValue.Avg
+			}
+			return value.DpsValue.Avg
+		}
+		sort.SliceStable(playerValues.Values, func(a, b int) bool {
+			return roleValue(playerValues.Values[a]) > roleValue(playerValues.Values[b])
+		})
+	}
+
+	return result
+}
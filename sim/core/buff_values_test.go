@@ -0,0 +1,128 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wowsims/cata/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestBuffToggles(t *testing.T) {
+	request := &proto.RaidSimRequest{Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{{
+		Buffs: &proto.IndividualBuffs{FocusMagic: true},
+		Consumes: &proto.Consumes{
+			Flask:               proto.Flask_FlaskOfTitanicStrength,
+			PetScrollOfStrength: 2,
+		},
+	}}}}}}
+	group := &buffGroup{prefix: "consumes", get: func(r *proto.RaidSimRequest) googleProto.Message {
+		return r.Raid.Parties[0].Players[0].Consumes
+	}}
+
+	names := func(toggles []*buffToggle) []string {
+		return MapSlice(toggles, func(toggle *buffToggle) string {
+			if toggle.added {
+				return "+" + toggle.name()
+			}
+			return "-" + toggle.name()
+		})
+	}
+
+	toggles := buffToggles(group, group.get(request).ProtoReflect(), false)
+	if diff := cmp.Diff([]string{"-consumes.flask", "-consumes.pet_scroll_of_strength"}, names(toggles)); diff != "" {
+		t.Fatalf("buffToggles() returned diff (-want +got):\n%s", diff)
+	}
+
+	toggles = buffToggles(group, group.get(request).ProtoReflect(), true)
+	if diff := cmp.Diff([]string{
+		"-consumes.flask",
+		"+consumes.pet_scroll_of_agility",
+		"-consumes.pet_scroll_of_strength",
+		"+consumes.thermal_sapper",
+		"+consumes.explosive_decoy",
+	}, names(toggles)); diff != "" {
+		t.Fatalf("buffToggles() with added returned diff (-want +got):\n%s", diff)
+	}
+
+	simRequest := googleProto.Clone(request).(*proto.RaidSimRequest)
+	toggles[0].apply(simRequest)
+	toggles[1].apply(simRequest)
+	want := &proto.Consumes{PetScrollOfAgility: 1, PetScrollOfStrength: 2}
+	if diff := cmp.Diff(want, simRequest.Raid.Parties[0].Players[0].Consumes, protocmp.Transform()); diff != "" {
+		t.Fatalf("apply() returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestCalcBuffValuesNeedsPlayer(t *testing.T) {
+	result := CalcBuffValues(&proto.BuffValuesRequest{BaseRequest: &proto.RaidSimRequest{Raid: &proto.Raid{}}}, nil)
+	if result.ErrorResult == "" {
+		t.Fatalf("Expected an error for a raid without players")
+	}
+}
+
+func TestCalcBuffValues(t *testing.T) {
+	spellID := ActionID{SpellID: 42}.ToProto()
+	request := &proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{Iterations: 20, RandomSeed: 100},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+			Rotation: &proto.APLRotation{
+				Type: proto.APLRotation_TypeAPL,
+				PriorityList: []*proto.APLListItem{{Action: &proto.APLAction{
+					Condition: &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{
+						Val: &proto.APLValue{Value: &proto.APLValue_DotIsActive{DotIsActive: &proto.APLValueDotIsActive{SpellId: spellID}}},
+					}}},
+					Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: spellID}},
+				}}},
+			},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{CurseOfElements: true}),
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 88}},
+			Duration: 60,
+		},
+	}
+
+	result := CalcBuffValues(&proto.BuffValuesRequest{BaseRequest: request, IncludeAdded: true}, make(chan *proto.ProgressMetrics, 1000))
+	if result.ErrorResult != "" {
+		t.Fatalf("Buff values failed: %s", result.ErrorResult)
+	}
+	if len(result.Players) != 1 || result.Players[0].Name != "Caster" {
+		t.Fatalf("Expected values for the one player, got %v", result.Players)
+	}
+
+	values := map[string]*proto.BuffValue{}
+	playerValues := result.Players[0].Values
+	for i, value := range playerValues {
+		values[value.Name] = value
+		if i > 0 && value.DpsValue.Avg > playerValues[i-1].DpsValue.Avg {
+			t.Fatalf("Expected values sorted by DPS value, got %s after %s", value.Name, playerValues[i-1].Name)
+		}
+	}
+
+	curseOfElements := values["debuffs.curse_of_elements"]
+	if curseOfElements == nil || curseOfElements.Added || curseOfElements.DpsValue.Avg <= 0 {
+		t.Fatalf("Expected the 8%% spell damage debuff to be worth DPS, got %v", curseOfElements)
+	}
+	// The same debuff from another class doesn't stack, and with common random
+	// numbers adding it changes nothing at all.
+	ebonPlaguebringer := values["debuffs.ebon_plaguebringer"]
+	if ebonPlaguebringer == nil || !ebonPlaguebringer.Added || ebonPlaguebringer.DpsValue.Avg != 0 || ebonPlaguebringer.DpsValue.Stdev != 0 {
+		t.Fatalf("Expected an overlapping debuff to be worth nothing, got %v", ebonPlaguebringer)
+	}
+	if curseOfElements.HpsValue.Avg != 0 {
+		t.Fatalf("Expected no HPS value for a damage debuff, got %v", curseOfElements.HpsValue)
+	}
+}
+
+func TestIsHealingSpec(t *testing.T) {
+	if !isHealingSpec(proto.Spec_SpecRestorationShaman) || isHealingSpec(proto.Spec_SpecElementalShaman) {
+		t.Fatalf("Expected only healing specs to be ranked by HPS")
+	}
+}
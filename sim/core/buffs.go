@@ -377,12 +377,14 @@ func StoneskinTotem(unit *Unit) *Aura {
 	})
 }
 
+const DevotionAuraArmor = 4075
+
 func DevotionAura(unit *Unit) *Aura {
 	return makeExclusiveBuff(unit, BuffConfig{
 		"Devotion Aura",
 		ActionID{SpellID: 465},
 		[]StatConfig{
-			{stats.Armor, 4075, false},
+			{stats.Armor, DevotionAuraArmor, false},
 		},
 	})
 }
@@ -1074,6 +1076,9 @@ type externalConsecutiveCDApproximation struct {
 
 	// Applies the buff.
 	AddAura CooldownActivation
+
+	// The aura AddAura applies, if it is a single aura on the character.
+	SelfBuff *Aura
 }
 
 // numSources is the number of other players assigned to apply the buff to this player.
@@ -1096,6 +1101,8 @@ func registerExternalConsecutiveCDApproximation(agent Agent, config externalCons
 		ActionID: config.ActionID,
 		Flags:    SpellFlagNoOnCastComplete | SpellFlagNoMetrics | SpellFlagNoLogs,
 
+		RelatedSelfBuff: config.SelfBuff,
+
 		Cast: CastConfig{
 			CD: Cooldown{
 				Timer:    sharedTimer,
@@ -1144,10 +1151,17 @@ const BloodlustAuraTag = "Bloodlust"
 const BloodlustDuration = time.Second * 40
 const BloodlustCD = time.Minute * 10
 
+// Debuff applied by each Bloodlust-style effect. They all share the Sated label,
+// so that any of them blocks all the others.
+var satedSpellIDs = map[int32]int32{
+	2825:  57724, // Bloodlust -> Sated
+	32182: 57723, // Heroism -> Exhaustion
+	80353: 80354, // Time Warp -> Temporal Displacement
+}
+
 func registerBloodlustCD(agent Agent, spellID int32) {
 	character := agent.GetCharacter()
-	BloodlustActionID.SpellID = spellID
-	bloodlustAura := BloodlustAura(character, -1)
+	bloodlustAura := bloodlustAuraForSpell(character, spellID, -1)
 
 	spell := character.RegisterSpell(SpellConfig{
 		ActionID: bloodlustAura.ActionID,
@@ -1159,9 +1173,15 @@ func registerBloodlustCD(agent Agent, spellID int32) {
 				Duration: BloodlustCD,
 			},
 		},
+		ExtraCastCondition: func(sim *Simulation, target *Unit) bool {
+			// Don't waste the external on a player that can't receive it.
+			return !character.HasActiveAura(SatedAuraLabel)
+		},
 
-		ApplyEffects: func(sim *Simulation, target *Unit, _ *Spell) {
-			if !target.HasActiveAura(SatedAuraLabel) {
+		ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
+			// The spell may also be cast from the APL at an enemy target, so check the
+			// receiving player directly.
+			if !character.HasActiveAura(SatedAuraLabel) {
 				bloodlustAura.Activate(sim)
 			}
 		},
@@ -1179,11 +1199,15 @@ func registerBloodlustCD(agent Agent, spellID int32) {
 }
 
 func BloodlustAura(character *Character, actionTag int32) *Aura {
-	actionID := BloodlustActionID.WithTag(actionTag)
+	return bloodlustAuraForSpell(character, BloodlustActionID.SpellID, actionTag)
+}
+
+func bloodlustAuraForSpell(character *Character, spellID int32, actionTag int32) *Aura {
+	actionID := ActionID{SpellID: spellID, Tag: actionTag}
 
 	sated := character.GetOrRegisterAura(Aura{
 		Label:    SatedAuraLabel,
-		ActionID: ActionID{SpellID: 57724},
+		ActionID: ActionID{SpellID: satedSpellIDs[spellID]},
 		Duration: time.Minute * 10,
 	})
 
@@ -1197,7 +1221,7 @@ func BloodlustAura(character *Character, actionTag int32) *Aura {
 			aura.Unit.MultiplyResourceRegenSpeed(sim, 1.3)
 			for _, pet := range character.Pets {
 				if pet.IsEnabled() && !pet.IsGuardian() {
-					BloodlustAura(&pet.Character, actionTag).Activate(sim)
+					bloodlustAuraForSpell(&pet.Character, spellID, actionTag).Activate(sim)
 				}
 			}
 
@@ -1408,7 +1432,8 @@ func registerDivineGuardianCD(agent Agent, numDivineGuardians int32) {
 			ShouldActivate: func(sim *Simulation, character *Character) bool {
 				return true
 			},
-			AddAura: func(sim *Simulation, character *Character) { dgAura.Activate(sim) },
+			SelfBuff: dgAura,
+			AddAura:  func(sim *Simulation, character *Character) { dgAura.Activate(sim) },
 		},
 		numDivineGuardians)
 }
@@ -1455,6 +1480,7 @@ func registerHandOfSacrificeCD(agent Agent, numSacs int32) {
 			ShouldActivate: func(sim *Simulation, character *Character) bool {
 				return true
 			},
+			SelfBuff: hosAura,
 			AddAura: func(sim *Simulation, character *Character) {
 				hosAura.Activate(sim)
 			},
@@ -1504,7 +1530,8 @@ func registerPainSuppressionCD(agent Agent, numPainSuppressions int32) {
 			ShouldActivate: func(sim *Simulation, character *Character) bool {
 				return true
 			},
-			AddAura: func(sim *Simulation, character *Character) { psAura.Activate(sim) },
+			SelfBuff: psAura,
+			AddAura:  func(sim *Simulation, character *Character) { psAura.Activate(sim) },
 		},
 		numPainSuppressions)
 }
@@ -1561,6 +1588,7 @@ func registerGuardianSpiritCD(agent Agent, numGuardianSpirits int32) {
 			ShouldActivate: func(sim *Simulation, character *Character) bool {
 				return true
 			},
+			SelfBuff: gsAura,
 			AddAura: func(sim *Simulation, character *Character) {
 				gsAura.Activate(sim)
 			},
@@ -1697,7 +1725,7 @@ func registerManaTideTotemCD(agent Agent, numManaTideTotems int32) {
 	var mttAura *Aura
 
 	character := agent.GetCharacter()
-	mttAura = ManaTideTotemAura(character, -1)
+	mttAura = ManaTideTotemAura(&character.Unit, -1)
 
 	character.Env.RegisterPostFinalizeEffect(func() {
 		// Use first MTT at 60s, or halfway through the fight, whichever comes first.
@@ -1724,11 +1752,10 @@ func registerManaTideTotemCD(agent Agent, numManaTideTotems int32) {
 		numManaTideTotems)
 }
 
-// TODO: Should this be a raid aura on every character available?
-func ManaTideTotemAura(character *Character, actionTag int32) *Aura {
+func ManaTideTotemAura(unit *Unit, actionTag int32) *Aura {
 	actionID := ManaTideTotemActionID.WithTag(actionTag)
-	dep := character.NewDynamicMultiplyStat(stats.Spirit, 2)
-	return character.GetOrRegisterAura(Aura{
+	dep := unit.NewDynamicMultiplyStat(stats.Spirit, 2)
+	return unit.GetOrRegisterAura(Aura{
 		Label:    "ManaTideTotem-" + actionID.String(),
 		Tag:      ManaTideTotemAuraTag,
 		ActionID: actionID,
@@ -7,6 +7,7 @@ import (
 	"math"
 	"runtime"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"strings"
 	"sync/atomic"
@@ -15,6 +16,7 @@ import (
 	goproto "google.golang.org/protobuf/proto"
 
 	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
 )
 
 const (
@@ -93,8 +95,23 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 	}
 	// reduce to just base party.
 	b.Request.BaseSettings.Raid.Parties = []*proto.Party{b.Request.BaseSettings.Raid.Parties[0]}
-	// clean to reduce memory
+	// clean to reduce memory, but keep the proc effects only the request's sims use
+	procEffects := player.GetDatabase().GetProcEffects()
 	player.Database = nil
+	if len(procEffects) > 0 {
+		player.Database = &proto.SimDatabase{ProcEffects: procEffects}
+	}
+
+	if optimizerSettings := b.Request.BulkSettings.GetTalentOptimizer(); optimizerSettings != nil {
+		loadouts, err := talentOptimizerLoadouts(player, optimizerSettings)
+		if err != nil {
+			return nil, err
+		}
+		// Fast mode weeds out the weak builds with short sims, and refines the rest with more iterations.
+		b.Request.BulkSettings.SimTalents = true
+		b.Request.BulkSettings.FastMode = true
+		b.Request.BulkSettings.TalentsToSim = append(b.Request.BulkSettings.TalentsToSim, loadouts...)
+	}
 
 	// Gemming for now can happen before slots are decided.
 	// We might have to add logic after slot decisions if we want to enforce keeping meta gem active.
@@ -143,6 +160,14 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 		}
 	}
 
+	if b.Request.BulkSettings.ExpandRandomSuffixes {
+		items, err := expandRandomSuffixes(b.Request.BulkSettings.Items, b.Request.BulkSettings.RandomSuffixFilters)
+		if err != nil {
+			return nil, err
+		}
+		b.Request.BulkSettings.Items = items
+	}
+
 	iterations := b.Request.GetBulkSettings().GetIterationsPerCombo()
 	if iterations <= 0 {
 		iterations = defaultIterationsPerCombo
@@ -208,6 +233,24 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 					}
 				}
 			}
+
+			for _, professions := range b.Request.BulkSettings.GetProfessionsToSim() {
+				sr := goproto.Clone(substitutedRequest).(*proto.RaidSimRequest)
+				cl := *changeLog
+				simPlayer := sr.Raid.Parties[0].Players[0]
+				if sameProfessionPair(professions, &proto.ProfessionPair{Profession1: simPlayer.Profession1, Profession2: simPlayer.Profession2}) {
+					continue
+				}
+
+				professionsArr := [2]proto.Profession{professions.Profession1, professions.Profession2}
+				ApplyProfessions(simPlayer, professionsArr)
+				// Crafted items needing the old professions can't be swapped out.
+				if len(ValidateProfessionGear(simPlayer.Equipment, professionsArr)) > 0 {
+					continue
+				}
+				cl.Professions = professions
+				validCombos = append(validCombos, singleBulkSim{req: sr, cl: &cl, eq: sub})
+			}
 		}
 	}
 
@@ -302,6 +345,7 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 			ItemsAdded:    r.ChangeLog.AddedItems,
 			UnitMetrics:   um,
 			TalentLoadout: r.ChangeLog.TalentLoadout,
+			Professions:   r.ChangeLog.Professions,
 		})
 	}
 
@@ -399,7 +443,7 @@ func (b *bulkSimRunner) getRankedResults(pctx context.Context, validCombos []sin
 			cancel() // cancel reporter
 			return nil, nil, errors.New("simulation failed: " + result.Result.ErrorResult)
 		}
-		if !result.Substitution.HasItemReplacements() && result.ChangeLog.TalentLoadout == nil {
+		if !result.Substitution.HasItemReplacements() && result.ChangeLog.TalentLoadout == nil && result.ChangeLog.Professions == nil {
 			baseResult = result
 		}
 		rankedResults[i] = result
@@ -441,9 +485,9 @@ func (es *equipmentSubstitution) HasItemReplacements() bool {
 }
 
 func (es *equipmentSubstitution) CanonicalHash() string {
-	slotToID := map[proto.ItemSlot]int32{}
+	slotToID := map[proto.ItemSlot]int64{}
 	for _, repl := range es.Items {
-		slotToID[repl.Slot] = repl.Item.Id
+		slotToID[repl.Slot] = itemComboID(repl.Item)
 	}
 
 	// Canonical representation always has the ring or trinket with smaller item ID in slot1
@@ -479,6 +523,58 @@ func (es *equipmentSubstitution) CanonicalHash() string {
 	return strings.Join(parts, ":")
 }
 
+// expandRandomSuffixes replaces each item which can have a random suffix but
+// doesn't specify one with a copy per suffix. Suffixes which give the item the
+// same stats as an earlier one are skipped, as are suffixes not matching any of
+// the name filters, if given.
+func expandRandomSuffixes(items []*proto.ItemSpec, nameFilters []string) ([]*proto.ItemSpec, error) {
+	var expanded []*proto.ItemSpec
+	for _, is := range items {
+		item, ok := ItemsByID[is.Id]
+		if !ok || is.RandomSuffix != 0 || len(item.RandomSuffixOptions) == 0 {
+			expanded = append(expanded, is)
+			continue
+		}
+
+		var seenStats []stats.Stats
+		for _, suffixID := range item.RandomSuffixOptions {
+			suffix, ok := RandomSuffixesByID[suffixID]
+			if !ok || !matchesRandomSuffixFilters(suffix.Name, nameFilters) {
+				continue
+			}
+
+			suffixStats := suffix.Stats.Multiply(float64(item.RandomPropPoints) / 10000.)
+			if slices.ContainsFunc(seenStats, suffixStats.Equals) {
+				continue
+			}
+			seenStats = append(seenStats, suffixStats)
+
+			suffixItem := goproto.Clone(is).(*proto.ItemSpec)
+			suffixItem.RandomSuffix = suffixID
+			// The reforge may not be possible with the stats of this suffix.
+			item.RandomSuffix = suffix
+			if reforge, ok := ReforgeStatsByID[is.Reforging]; ok && !validateReforging(&item, reforge) {
+				suffixItem.Reforging = 0
+			}
+			expanded = append(expanded, suffixItem)
+		}
+
+		if len(seenStats) == 0 {
+			return nil, fmt.Errorf("no random suffix of item %d matches the suffix filters", is.Id)
+		}
+	}
+	return expanded, nil
+}
+
+func matchesRandomSuffixFilters(suffixName string, nameFilters []string) bool {
+	if len(nameFilters) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(nameFilters, func(filter string) bool {
+		return strings.Contains(strings.ToLower(suffixName), strings.ToLower(filter))
+	})
+}
+
 // isValidEquipment returns true if the specified equipment spec is valid. An equipment spec
 // is valid if it does not reference a two-hander and off-hand weapon combo.
 func isValidEquipment(equipment *proto.EquipmentSpec) bool {
@@ -541,8 +637,8 @@ func generateAllEquipmentSubstitutions(_ context.Context, baseItems []*proto.Ite
 			comboChecker := ItemComboChecker{}
 
 			// Pre-seed the existing item combos
-			comboChecker.HasCombo(baseItems[proto.ItemSlot_ItemSlotFinger1].Id, baseItems[proto.ItemSlot_ItemSlotFinger2].Id)
-			comboChecker.HasCombo(baseItems[proto.ItemSlot_ItemSlotTrinket1].Id, baseItems[proto.ItemSlot_ItemSlotTrinket2].Id)
+			comboChecker.HasCombo(baseItems[proto.ItemSlot_ItemSlotFinger1], baseItems[proto.ItemSlot_ItemSlotFinger2])
+			comboChecker.HasCombo(baseItems[proto.ItemSlot_ItemSlotTrinket1], baseItems[proto.ItemSlot_ItemSlotTrinket2])
 
 			for slotid, slot := range itemsBySlot {
 				for _, item := range slot {
@@ -552,7 +648,7 @@ func generateAllEquipmentSubstitutions(_ context.Context, baseItems []*proto.Ite
 					// Handle finger/trinket specially to generate combos
 					switch slotid {
 					case int(proto.ItemSlot_ItemSlotFinger1), int(proto.ItemSlot_ItemSlotTrinket1):
-						if !comboChecker.HasCombo(item.Id, baseItems[slotid+1].Id) {
+						if !comboChecker.HasCombo(item, baseItems[slotid+1]) {
 							results <- &sub
 						}
 						// Generate extra combos
@@ -566,7 +662,7 @@ func generateAllEquipmentSubstitutions(_ context.Context, baseItems []*proto.Ite
 						}
 					case int(proto.ItemSlot_ItemSlotFinger2), int(proto.ItemSlot_ItemSlotTrinket2):
 						// Ensure we don't have this combo with the base equipment.
-						if !comboChecker.HasCombo(item.Id, baseItems[slotid-1].Id) {
+						if !comboChecker.HasCombo(item, baseItems[slotid-1]) {
 							results <- &sub
 						}
 					default:
@@ -599,17 +695,17 @@ func createReplacement(repl equipmentSubstitution, item *itemWithSlot) equipment
 func shouldSkipCombo(baseItems []*proto.ItemSpec, item *proto.ItemSpec, slot proto.ItemSlot, comboChecker ItemComboChecker, replacements equipmentSubstitution) bool {
 	switch slot {
 	case proto.ItemSlot_ItemSlotFinger1, proto.ItemSlot_ItemSlotTrinket1:
-		return comboChecker.HasCombo(item.Id, baseItems[slot+1].Id)
+		return comboChecker.HasCombo(item, baseItems[slot+1])
 	case proto.ItemSlot_ItemSlotFinger2, proto.ItemSlot_ItemSlotTrinket2:
 
 		for _, repl := range replacements.Items {
 			if slot == proto.ItemSlot_ItemSlotFinger2 && repl.Slot == proto.ItemSlot_ItemSlotFinger1 ||
 				slot == proto.ItemSlot_ItemSlotTrinket2 && repl.Slot == proto.ItemSlot_ItemSlotTrinket1 {
-				return comboChecker.HasCombo(repl.Item.Id, item.Id)
+				return comboChecker.HasCombo(repl.Item, item)
 			}
 		}
 		// Since we didn't find an item in the opposite slot, check against base items.
-		return comboChecker.HasCombo(item.Id, baseItems[slot-1].Id)
+		return comboChecker.HasCombo(item, baseItems[slot-1])
 	}
 	return false
 }
@@ -647,6 +743,7 @@ type itemWithSlot struct {
 type raidSimRequestChangeLog struct {
 	AddedItems    []*proto.ItemSpecWithSlot
 	TalentLoadout *proto.TalentLoadout
+	Professions   *proto.ProfessionPair
 }
 
 // createNewRequestWithSubstitution creates a copy of the input RaidSimRequest and applis the given
@@ -681,10 +778,10 @@ func createNewRequestWithSubstitution(readonlyInputRequest *proto.RaidSimRequest
 	return request, changeLog
 }
 
-type ItemComboChecker map[int64]struct{}
+type ItemComboChecker map[[2]int64]struct{}
 
-func (ic *ItemComboChecker) HasCombo(itema int32, itemb int32) bool {
-	if itema == itemb {
+func (ic *ItemComboChecker) HasCombo(itema *proto.ItemSpec, itemb *proto.ItemSpec) bool {
+	if itemComboID(itema) == itemComboID(itemb) {
 		return true
 	}
 	key := ic.generateComboKey(itema, itemb)
@@ -697,11 +794,17 @@ func (ic *ItemComboChecker) HasCombo(itema int32, itemb int32) bool {
 }
 
 // put this function on ic just so it isn't in global namespace
-func (ic *ItemComboChecker) generateComboKey(itemA int32, itemB int32) int64 {
-	if itemA > itemB {
-		return int64(itemA) + int64(itemB)<<4
+func (ic *ItemComboChecker) generateComboKey(itemA *proto.ItemSpec, itemB *proto.ItemSpec) [2]int64 {
+	idA, idB := itemComboID(itemA), itemComboID(itemB)
+	if idA > idB {
+		return [2]int64{idB, idA}
 	}
-	return int64(itemB) + int64(itemA)<<4
+	return [2]int64{idA, idB}
+}
+
+// itemComboID distinguishes the random suffixes of an item, so each is simmed.
+func itemComboID(item *proto.ItemSpec) int64 {
+	return int64(item.GetId()) | int64(item.GetRandomSuffix())<<32
 }
 
 type SubstitutionComboChecker map[string]struct{}
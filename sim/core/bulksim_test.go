@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -380,3 +381,44 @@ func TestGenerateAllEquipmentSubstitutions(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandRandomSuffixes(t *testing.T) {
+	const itemBandedRing = 55400
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: itemBandedRing, Type: proto.ItemType_ItemTypeFinger, RandPropPoints: 10000, RandomSuffixOptions: []int32{-1, -2, -3, -4}},
+		},
+		RandomSuffixes: []*proto.ItemRandomSuffix{
+			{Id: -1, Name: "of the Bandit", Stats: stats.Stats{stats.Agility: 1, stats.Stamina: 1}.ToFloatArray()},
+			{Id: -2, Name: "of the Feverflare", Stats: stats.Stats{stats.Intellect: 1, stats.Stamina: 1}.ToFloatArray()},
+			// Same stats as "of the Bandit".
+			{Id: -3, Name: "of the Thief", Stats: stats.Stats{stats.Agility: 1, stats.Stamina: 1}.ToFloatArray()},
+			{Id: -4, Name: "of the Bear", Stats: stats.Stats{stats.Strength: 1, stats.Stamina: 1}.ToFloatArray()},
+		},
+	})
+
+	suffixIDs := func(items []*proto.ItemSpec) []int32 {
+		return MapSlice(items, func(is *proto.ItemSpec) int32 { return is.RandomSuffix })
+	}
+
+	items := []*proto.ItemSpec{{Id: itemBandedRing}, {Id: itemBandedRing, RandomSuffix: -4}, {Id: itemStarshardEdge}}
+	expanded, err := expandRandomSuffixes(items, nil)
+	if err != nil {
+		t.Fatalf("expandRandomSuffixes() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]int32{-1, -2, -4, -4, 0}, suffixIDs(expanded)); diff != "" {
+		t.Fatalf("expandRandomSuffixes() returned diff (-want +got):\n%s", diff)
+	}
+
+	expanded, err = expandRandomSuffixes(items[:1], []string{"bandit", "of the Feverflare"})
+	if err != nil {
+		t.Fatalf("expandRandomSuffixes() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]int32{-1, -2}, suffixIDs(expanded)); diff != "" {
+		t.Fatalf("expandRandomSuffixes() with filters returned diff (-want +got):\n%s", diff)
+	}
+
+	if _, err := expandRandomSuffixes(items[:1], []string{"of the Wind"}); err == nil {
+		t.Fatalf("expandRandomSuffixes() with no matching suffixes should return an error")
+	}
+}
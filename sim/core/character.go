@@ -86,6 +86,18 @@ type Character struct {
 	conjuredCD         *Timer
 
 	Pets []*Pet // cached in AddPet, for advance()
+
+	// Problems with the character's settings, which are returned to the user
+	// with its stats.
+	warnings []string
+
+	// Item and enchant effects defined by the player's request.
+	requestItemEffects    map[int32]ApplyEffect
+	requestEnchantEffects map[int32]ApplyEffect
+}
+
+func (character *Character) ValidationWarning(message string, vals ...interface{}) {
+	character.warnings = append(character.warnings, fmt.Sprintf(message, vals...))
 }
 
 func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character {
@@ -129,9 +141,15 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 
 	character.GCD = character.NewTimer()
 	character.RotationTimer = character.NewTimer()
+	character.playerModel = newPlayerModel(&character.Unit, player.PlayerModel)
+	character.requestItemEffects, character.requestEnchantEffects = compileRequestProcEffects(player.GetDatabase().GetProcEffects())
 
 	character.Label = fmt.Sprintf("%s (#%d)", character.Name, character.Index+1)
 
+	for _, violation := range ValidateProfessionGear(player.Equipment, character.professions) {
+		character.ValidationWarning("%s", violation)
+	}
+
 	if player.Glyphs != nil {
 		character.glyphs = [9]int32{
 			player.Glyphs.Prime1,
@@ -318,20 +336,38 @@ func (character *Character) CalculateMasteryPoints() float64 {
 	return character.GetStat(stats.Mastery) / MasteryRatingPerMasteryPoint
 }
 
+// Returns the effect of an item or gem, preferring the one defined by the request.
+func (character *Character) itemEffect(id int32) (ApplyEffect, bool) {
+	if applyEffect, ok := character.requestItemEffects[id]; ok {
+		return applyEffect, true
+	}
+	applyEffect, ok := itemEffects[id]
+	return applyEffect, ok
+}
+
+// Returns the effect of an enchant, preferring the one defined by the request.
+func (character *Character) enchantEffect(effectID int32) (ApplyEffect, bool) {
+	if applyEffect, ok := character.requestEnchantEffects[effectID]; ok {
+		return applyEffect, true
+	}
+	applyEffect, ok := enchantEffects[effectID]
+	return applyEffect, ok
+}
+
 // Apply effects from all equipped core.
 func (character *Character) applyItemEffects(agent Agent) {
 	for slot, eq := range character.Equipment {
-		if applyItemEffect, ok := itemEffects[eq.ID]; ok {
+		if applyItemEffect, ok := character.itemEffect(eq.ID); ok {
 			applyItemEffect(agent)
 		}
 
 		for _, g := range eq.Gems {
-			if applyGemEffect, ok := itemEffects[g.ID]; ok {
+			if applyGemEffect, ok := character.itemEffect(g.ID); ok {
 				applyGemEffect(agent)
 			}
 		}
 
-		if applyEnchantEffect, ok := enchantEffects[eq.Enchant.EffectID]; ok {
+		if applyEnchantEffect, ok := character.enchantEffect(eq.Enchant.EffectID); ok {
 			applyEnchantEffect(agent)
 		}
 
@@ -343,7 +379,7 @@ func (character *Character) applyItemEffects(agent Agent) {
 	if character.ItemSwap.IsEnabled() {
 		offset := int(proto.ItemSlot_ItemSlotMainHand)
 		for i, item := range character.ItemSwap.unEquippedItems {
-			if applyEnchantEffect, ok := enchantEffects[item.Enchant.EffectID]; ok {
+			if applyEnchantEffect, ok := character.enchantEffect(item.Enchant.EffectID); ok {
 				applyEnchantEffect(agent)
 			}
 
@@ -438,8 +474,9 @@ func (character *Character) initialize(agent Agent) {
 				return
 			}
 
-			if sim.Options.Interactive {
-				if character.GCD.IsReady(sim) {
+			if sim.IsInteractive(&character.Unit) {
+				// Interactive players only act once their channel ends.
+				if character.GCD.IsReady(sim) && character.ChanneledDot == nil {
 					sim.NeedsInput = true
 				}
 				return
@@ -495,6 +532,7 @@ func (character *Character) FillPlayerStats(playerStats *proto.PlayerStats) {
 	if character.Rotation != nil {
 		playerStats.RotationStats = character.Rotation.getStats()
 	}
+	playerStats.Warnings = character.warnings
 }
 
 func (character *Character) reset(sim *Simulation, agent Agent) {
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// How long before a damage spike defensive cooldowns are used for it.
+const damageSpikeLeadTime = time.Second * 2
+
+// A predictable burst of boss damage, e.g. a big hit on a timer or an enrage
+// below some health. Encounters publish these so tanks can plan defensive
+// cooldowns around them.
+type DamageSpike struct {
+	// Boss spell causing the spike.
+	Spell *Spell
+
+	// Unit the spike will hit. Defaults to the boss's current target.
+	Target *Unit
+
+	// Rough damage of each hit of the spike, to tell big spikes from small ones.
+	Damage float64
+
+	// When the spike is next expected, or NeverExpires if it isn't coming.
+	NextAt time.Duration
+
+	// How long the spike lasts once it starts, 0 for a single hit.
+	Duration time.Duration
+
+	// For lasting spikes like enrages, the boss aura during which all of its
+	// hits count towards the spike.
+	Aura *Aura
+}
+
+// Optional interface for TargetAIs that publish their upcoming damage spikes.
+type DamageSpikeAI interface {
+	// Returns the encounter's damage spikes. Called often, so should be cheap;
+	// the returned slice may be reused by the next call.
+	DamageSpikes(sim *Simulation) []DamageSpike
+}
+
+func (spike *DamageSpike) hits(unit *Unit) bool {
+	if spike.Target != nil {
+		return spike.Target == unit
+	}
+	return spike.Spell.Unit.CurrentTarget == unit
+}
+
+// Whether the spike is happening now or within the given time.
+func (spike *DamageSpike) within(sim *Simulation, dur time.Duration) bool {
+	if spike.NextAt == NeverExpires {
+		return false
+	}
+	return spike.NextAt <= sim.CurrentTime+dur && sim.CurrentTime <= spike.NextAt+spike.Duration
+}
+
+// Returns the damage spikes published by this target's AI, if any.
+func (target *Target) DamageSpikes(sim *Simulation) []DamageSpike {
+	if spikeAI, ok := target.AI.(DamageSpikeAI); ok {
+		return spikeAI.DamageSpikes(sim)
+	}
+	return nil
+}
+
+// Remembers the published damage spikes, so hits from them are tracked.
+func (target *Target) resetDamageSpikes(sim *Simulation) {
+	target.damageSpikes = slices.Clone(target.DamageSpikes(sim))
+}
+
+// Returns the earliest upcoming damage spike that will hit unit with at least
+// minDamage per hit, across all targets.
+func (env *Environment) NextDamageSpike(sim *Simulation, unit *Unit, minDamage float64) (DamageSpike, bool) {
+	var next DamageSpike
+	found := false
+	for _, target := range env.Encounter.Targets {
+		for _, spike := range target.DamageSpikes(sim) {
+			if spike.NextAt == NeverExpires || spike.Damage < minDamage || !spike.hits(unit) {
+				continue
+			}
+			// Spikes that are already happening count as starting now.
+			if spike.NextAt+spike.Duration < sim.CurrentTime {
+				continue
+			}
+			if !found || spike.NextAt < next.NextAt {
+				next = spike
+				found = true
+			}
+		}
+	}
+	return next, found
+}
+
+// Estimates when the remaining fight will drop to the given fraction (0-1), for
+// effects that trigger at some boss health.
+func (sim *Simulation) EstimatedTimeAtRemainingPercent(percent float64) time.Duration {
+	remainingPercent := sim.GetRemainingDurationPercent()
+	if remainingPercent <= percent {
+		return sim.CurrentTime
+	}
+	return sim.CurrentTime + time.Duration(float64(sim.GetRemainingDuration())*(1-percent/remainingPercent))
+}
+
+// Whether a defensive cooldown of this character is currently active.
+func (character *Character) DefensiveCooldownActive() bool {
+	for _, mcd := range character.majorCooldowns {
+		for _, aura := range mcd.survivalAuras {
+			if aura.IsActive() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// When planning defensives against damage spikes, returns whether a defensive
+// cooldown should be saved for (false) or used on (true) the next spike. The
+// second return value is false if there are no spikes to plan against.
+func (character *Character) shouldUseDefensiveForSpike(sim *Simulation) (bool, bool) {
+	spike, ok := character.Env.NextDamageSpike(sim, &character.Unit, 0)
+	if !ok {
+		return false, false
+	}
+	// Chain defensives across spikes rather than stacking them on one.
+	return spike.within(sim, damageSpikeLeadTime) && !character.DefensiveCooldownActive(), true
+}
+
+type damageSpikeMetrics struct {
+	hits                 int32
+	coveredHits          int32
+	damageTaken          float64
+	uncoveredDamageTaken float64
+}
+
+// Records a hit on a player that is part of one of this target's damage
+// spikes, and whether a defensive cooldown covered it.
+func (target *Target) recordDamageSpikeHit(spell *Spell, result *SpellResult) {
+	if !result.Landed() {
+		return
+	}
+	for _, spike := range target.damageSpikes {
+		if spike.Spell != spell && (spike.Aura == nil || !spike.Aura.IsActive()) {
+			continue
+		}
+
+		unit := result.Target
+		covered := false
+		if agent := unit.Env.Raid.GetPlayerFromUnit(unit); agent != nil {
+			covered = agent.GetCharacter().DefensiveCooldownActive()
+		}
+
+		if unit.Metrics.damageSpikes == nil {
+			unit.Metrics.damageSpikes = make(map[ActionID]*damageSpikeMetrics)
+		}
+		metrics, ok := unit.Metrics.damageSpikes[spike.Spell.ActionID]
+		if !ok {
+			metrics = &damageSpikeMetrics{}
+			unit.Metrics.damageSpikes[spike.Spell.ActionID] = metrics
+		}
+
+		metrics.hits++
+		metrics.damageTaken += result.Damage
+		if covered {
+			metrics.coveredHits++
+		} else {
+			metrics.uncoveredDamageTaken += result.Damage
+		}
+		return
+	}
+}
+
+func (metrics *damageSpikeMetrics) ToProto(actionID ActionID, numIterations float64) *proto.DamageSpikeMetrics {
+	return &proto.DamageSpikeMetrics{
+		Id:                   actionID.ToProto(),
+		Hits:                 float64(metrics.hits) / numIterations,
+		CoveredHits:          float64(metrics.coveredHits) / numIterations,
+		DamageTaken:          metrics.damageTaken / numIterations,
+		UncoveredDamageTaken: metrics.uncoveredDamageTaken / numIterations,
+	}
+}
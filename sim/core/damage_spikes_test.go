@@ -0,0 +1,106 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestDamageSpikeWithin(t *testing.T) {
+	sim := &Simulation{CurrentTime: time.Second * 10}
+
+	spike := DamageSpike{NextAt: time.Second * 11}
+	if !spike.within(sim, damageSpikeLeadTime) {
+		t.Fatalf("spike 1s away should be within the lead time")
+	}
+	spike.NextAt = time.Second * 13
+	if spike.within(sim, damageSpikeLeadTime) {
+		t.Fatalf("spike 3s away should not be within the lead time")
+	}
+
+	// Lasting spikes count until they are over.
+	spike = DamageSpike{NextAt: time.Second * 5, Duration: time.Second * 10}
+	if !spike.within(sim, 0) {
+		t.Fatalf("ongoing spike should be within")
+	}
+	spike.Duration = time.Second * 2
+	if spike.within(sim, 0) {
+		t.Fatalf("finished spike should not be within")
+	}
+
+	spike.NextAt = NeverExpires
+	if spike.within(sim, time.Hour) {
+		t.Fatalf("spike that isn't coming should never be within")
+	}
+}
+
+// Hits the player for a known amount at a fixed time.
+type fakeSpikeAI struct {
+	spike DamageSpike
+}
+
+func (ai *fakeSpikeAI) Initialize(*Target, *proto.Target)      {}
+func (ai *fakeSpikeAI) Reset(*Simulation)                      {}
+func (ai *fakeSpikeAI) ExecuteCustomRotation(*Simulation)      {}
+func (ai *fakeSpikeAI) DamageSpikes(*Simulation) []DamageSpike { return []DamageSpike{ai.spike} }
+
+func TestPlanDefensiveForDamageSpike(t *testing.T) {
+	sim := SetupFakeSim()
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	target := sim.Encounter.Targets[0]
+	target.AI = &fakeSpikeAI{spike: DamageSpike{
+		Spell:  target.RegisterSpell(SpellConfig{ActionID: ActionID{SpellID: 1003}}),
+		Target: &character.Unit,
+		Damage: 50000,
+		NextAt: time.Second * 10,
+	}}
+
+	actionID := ActionID{SpellID: 1001}
+	// The buff has its own ActionID, and an unrelated proc shares the cooldown's.
+	defensiveAura := character.RegisterAura(Aura{Label: "Fake Defensive", ActionID: ActionID{SpellID: 1002}, Duration: time.Second * 5})
+	procAura := character.RegisterAura(Aura{Label: "Fake Proc", ActionID: actionID, Duration: time.Second * 5})
+	defensive := character.RegisterSpell(SpellConfig{
+		ActionID:        actionID,
+		RelatedSelfBuff: defensiveAura,
+		Flags:           SpellFlagNoOnCastComplete | SpellFlagHelpful,
+		Cast: CastConfig{
+			CD: Cooldown{
+				Timer:    character.NewTimer(),
+				Duration: time.Minute,
+			},
+		},
+		ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
+			defensiveAura.Activate(sim)
+			// An unrelated proc from using the cooldown.
+			procAura.Activate(sim)
+		},
+	})
+	character.initialMajorCooldowns = append(character.initialMajorCooldowns, MajorCooldown{
+		Spell:          defensive,
+		Type:           CooldownTypeSurvival,
+		ShouldActivate: func(*Simulation, *Character) bool { return true },
+	})
+	character.cooldownConfigs.PlanDefensivesForDamageSpikes = true
+	character.majorCooldownManager.finalize()
+	character.majorCooldownManager.reset(sim)
+	mcd := &character.initialMajorCooldowns[len(character.initialMajorCooldowns)-1]
+
+	sim.CurrentTime = time.Second * 5
+	if mcd.tryActivateHelper(sim, character) {
+		t.Fatalf("Expected the defensive to be saved for the spike at 10s")
+	}
+	sim.CurrentTime = time.Second * 9
+	if !mcd.tryActivateHelper(sim, character) {
+		t.Fatalf("Expected the defensive to be used right before the spike")
+	}
+	if !character.DefensiveCooldownActive() {
+		t.Fatalf("Expected the defensive to cover the spike")
+	}
+
+	// Only the buff the cooldown applies counts, not procs that happen alongside it.
+	defensiveAura.Deactivate(sim)
+	if !procAura.IsActive() || character.DefensiveCooldownActive() {
+		t.Fatalf("Expected only the cooldown's own aura to count as a defensive, got %v", mcd.survivalAuras)
+	}
+}
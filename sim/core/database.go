@@ -17,6 +17,101 @@ var RandomSuffixesByID = map[int32]RandomSuffix{}
 var EnchantsByEffectID = map[int32]Enchant{}
 var ReforgeStatsByID = map[int32]ReforgeStat{}
 
+// Converts a database the UI uses to the parts the sim needs.
+func NewSimDatabase(db *proto.UIDatabase) *proto.SimDatabase {
+	simDB := &proto.SimDatabase{
+		Items:          make([]*proto.SimItem, len(db.Items)),
+		Enchants:       make([]*proto.SimEnchant, len(db.Enchants)),
+		Gems:           make([]*proto.SimGem, len(db.Gems)),
+		ReforgeStats:   make([]*proto.ReforgeStat, len(db.ReforgeStats)),
+		RandomSuffixes: make([]*proto.ItemRandomSuffix, len(db.RandomSuffixes)),
+	}
+
+	for i, item := range db.Items {
+		simDB.Items[i] = &proto.SimItem{
+			Id:               item.Id,
+			Name:             item.Name,
+			Type:             item.Type,
+			ArmorType:        item.ArmorType,
+			WeaponType:       item.WeaponType,
+			HandType:         item.HandType,
+			RangedWeaponType: item.RangedWeaponType,
+			Stats:            item.Stats,
+			GemSockets:       item.GemSockets,
+			SocketBonus:      item.SocketBonus,
+			WeaponDamageMin:  item.WeaponDamageMin,
+			WeaponDamageMax:  item.WeaponDamageMax,
+			WeaponSpeed:      item.WeaponSpeed,
+			SetName:          item.SetName,
+			RandPropPoints:   item.RandPropPoints,
+
+			RandomSuffixOptions: item.RandomSuffixOptions,
+			RequiredProfession:  item.RequiredProfession,
+			Faction:             factionRestrictionToFaction(item.FactionRestriction),
+		}
+	}
+
+	for i, suffix := range db.RandomSuffixes {
+		simDB.RandomSuffixes[i] = &proto.ItemRandomSuffix{
+			Id:    suffix.Id,
+			Name:  suffix.Name,
+			Stats: suffix.Stats,
+		}
+	}
+
+	for i, enchant := range db.Enchants {
+		simDB.Enchants[i] = &proto.SimEnchant{
+			EffectId:           enchant.EffectId,
+			Stats:              enchant.Stats,
+			Type:               enchant.Type,
+			RequiredProfession: enchant.RequiredProfession,
+		}
+	}
+
+	for i, gem := range db.Gems {
+		simDB.Gems[i] = &proto.SimGem{
+			Id:                 gem.Id,
+			Name:               gem.Name,
+			Color:              gem.Color,
+			Stats:              gem.Stats,
+			RequiredProfession: gem.RequiredProfession,
+		}
+	}
+
+	for i, reforgeStat := range db.ReforgeStats {
+		simDB.ReforgeStats[i] = &proto.ReforgeStat{
+			Id:         reforgeStat.Id,
+			FromStat:   reforgeStat.FromStat,
+			ToStat:     reforgeStat.ToStat,
+			Multiplier: reforgeStat.Multiplier,
+		}
+	}
+
+	return simDB
+}
+
+func factionRestrictionToFaction(restriction proto.UIItem_FactionRestriction) proto.Faction {
+	switch restriction {
+	case proto.UIItem_FACTION_RESTRICTION_ALLIANCE_ONLY:
+		return proto.Faction_Alliance
+	case proto.UIItem_FACTION_RESTRICTION_HORDE_ONLY:
+		return proto.Faction_Horde
+	default:
+		return proto.Faction_Unknown
+	}
+}
+
+// Replaces everything added to the database so far, e.g. to compare sims
+// between two versions of it. Must not be called while sims are running.
+func ReplaceDatabase(newDB *proto.SimDatabase) {
+	ItemsByID = map[int32]Item{}
+	GemsByID = map[int32]Gem{}
+	RandomSuffixesByID = map[int32]RandomSuffix{}
+	EnchantsByEffectID = map[int32]Enchant{}
+	ReforgeStatsByID = map[int32]ReforgeStat{}
+	addToDatabase(newDB)
+}
+
 func addToDatabase(newDB *proto.SimDatabase) {
 	for _, v := range newDB.Items {
 		if _, ok := ItemsByID[v.Id]; !ok {
@@ -47,6 +142,7 @@ func addToDatabase(newDB *proto.SimDatabase) {
 			ReforgeStatsByID[v.Id] = ReforgeStatFromProto(v)
 		}
 	}
+
 }
 
 type ReforgeStat struct {
@@ -94,6 +190,12 @@ type Item struct {
 	SetName          string // Empty string if not part of a set.
 	RandomPropPoints int32  // Used to rescale random suffix stats
 
+	// IDs of the random suffixes this item can have.
+	RandomSuffixOptions []int32
+
+	RequiredProfession proto.Profession
+	Faction            proto.Faction // Set if only one faction can use the item.
+
 	GemSockets  []proto.GemColor
 	SocketBonus stats.Stats
 
@@ -124,6 +226,10 @@ func ItemFromProto(pData *proto.SimItem) Item {
 		SocketBonus:      stats.FromFloatArray(pData.SocketBonus),
 		SetName:          pData.SetName,
 		RandomPropPoints: pData.RandPropPoints,
+
+		RandomSuffixOptions: pData.RandomSuffixOptions,
+		RequiredProfession:  pData.RequiredProfession,
+		Faction:             pData.Faction,
 	}
 }
 
@@ -163,12 +269,18 @@ func RandomSuffixFromProto(pData *proto.ItemRandomSuffix) RandomSuffix {
 type Enchant struct {
 	EffectID int32 // Used by UI to apply effect to tooltip
 	Stats    stats.Stats
+	Type     proto.ItemType // Which type of item this enchant can be applied to.
+
+	RequiredProfession proto.Profession
 }
 
 func EnchantFromProto(pData *proto.SimEnchant) Enchant {
 	return Enchant{
 		EffectID: pData.EffectId,
 		Stats:    stats.FromFloatArray(pData.Stats),
+		Type:     pData.Type,
+
+		RequiredProfession: pData.RequiredProfession,
 	}
 }
 
@@ -177,6 +289,8 @@ type Gem struct {
 	Name  string
 	Stats stats.Stats
 	Color proto.GemColor
+
+	RequiredProfession proto.Profession
 }
 
 func GemFromProto(pData *proto.SimGem) Gem {
@@ -185,6 +299,8 @@ func GemFromProto(pData *proto.SimGem) Gem {
 		Name:  pData.Name,
 		Stats: stats.FromFloatArray(pData.Stats),
 		Color: pData.Color,
+
+		RequiredProfession: pData.RequiredProfession,
 	}
 }
 
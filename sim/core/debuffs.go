@@ -105,22 +105,6 @@ func applyDebuffEffects(target *Unit, targetIdx int, debuffs *proto.Debuffs, rai
 		}, raid)
 	}
 
-	if debuffs.AcidSpit && targetIdx == 0 {
-		aura := AcidSpitAura(target)
-		ScheduledMajorArmorAura(aura, PeriodicActionOptions{
-			Period:          time.Second * 10,
-			NumTicks:        3,
-			TickImmediately: true,
-			Priority:        ActionPriorityDOT,
-			OnAction: func(sim *Simulation) {
-				aura.Activate(sim)
-				if aura.IsActive() {
-					aura.AddStack(sim)
-				}
-			},
-		}, raid)
-	}
-
 	if debuffs.FaerieFire && targetIdx == 0 {
 		aura := FaerieFireAura(target)
 		ScheduledMajorArmorAura(aura, PeriodicActionOptions{
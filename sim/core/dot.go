@@ -196,6 +196,46 @@ func (dot *Dot) ApplyOrReset(sim *Simulation) {
 	sim.AddPendingAction(dot.tickAction)
 }
 
+// Applies an exact copy of this Dot as the other Dot, usually the same spell on
+// another target. The snapshot, stacks, remaining duration and tick timer carry
+// over, as with effects that spread DoTs like Impact.
+func (dot *Dot) CopyTo(sim *Simulation, other *Dot) {
+	remainingDuration := dot.RemainingDuration(sim)
+	if other == nil || !dot.IsActive() || remainingDuration <= 0 {
+		return
+	}
+
+	other.SnapshotBaseDamage = dot.SnapshotBaseDamage
+	other.SnapshotCritChance = dot.SnapshotCritChance
+	other.SnapshotAttackerMultiplier = dot.SnapshotAttackerMultiplier
+
+	// The other Dot may have a different number of ticks, e.g. Living Bomb spread
+	// by Impact, so count the ticks left from the remaining duration instead.
+	ticksRemaining := int32(0)
+	if untilNextTick := dot.TimeUntilNextTick(sim); untilNextTick <= remainingDuration {
+		ticksRemaining = 1 + int32((remainingDuration-untilNextTick)/dot.tickPeriod)
+	}
+	other.tickPeriod = dot.tickPeriod
+	other.TickCount = other.NumberOfTicks - ticksRemaining
+	other.Aura.Duration = remainingDuration
+	other.Aura.Activate(sim)
+	if other.MaxStacks > 0 {
+		other.SetStacks(sim, dot.GetStacks())
+	}
+
+	// Tick in sync with the original.
+	other.lastTickTime = dot.lastTickTime
+	oldTickAction := other.tickAction
+	other.tickAction = nil // prevent tickAction.CleanUp() from adding an extra tick
+	oldTickAction.Cancel(sim)
+
+	periodicOptions := other.basePeriodicOptions()
+	periodicOptions.Period = other.tickPeriod
+	other.tickAction = NewPeriodicAction(sim, periodicOptions)
+	other.tickAction.NextActionAt = dot.tickAction.NextActionAt
+	sim.AddPendingAction(other.tickAction)
+}
+
 func (dot *Dot) Cancel(sim *Simulation) {
 	if dot.Aura.IsActive() {
 		dot.Aura.Deactivate(sim)
@@ -254,7 +294,7 @@ func (dot *Dot) TickOnce(sim *Simulation) {
 			if dot.Spell.Unit.GCD.IsReady(sim) {
 				dot.Spell.Unit.WaitUntil(sim, sim.CurrentTime+dot.Spell.Unit.ChannelClipDelay)
 			}
-		} else if dot.Spell.Unit.Rotation.shouldInterruptChannel(sim) {
+		} else if !sim.IsInteractive(dot.Spell.Unit) && dot.Spell.Unit.Rotation.shouldInterruptChannel(sim) {
 			dot.Cancel(sim)
 			if dot.Spell.Unit.GCD.IsReady(sim) {
 				dot.Spell.Unit.WaitUntil(sim, sim.CurrentTime+dot.Spell.Unit.ChannelClipDelay)
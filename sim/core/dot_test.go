@@ -176,3 +176,56 @@ func TestDotSnapshotSpellMultiplier(t *testing.T) {
 	fa.Dot.Rollover(sim)
 	expectDotTickDamage(t, sim, fa.Dot, 300) // (100) * 1.5 * 2
 }
+
+func TestDotCopyTo(t *testing.T) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 88}, {Name: "target", Level: 88}},
+			Duration: 180,
+		},
+	})
+	sim.Reset()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	fa.Dot.Apply(sim)
+	for sim.CurrentTime < time.Second*7 {
+		sim.Step()
+	}
+
+	// The copy keeps the original snapshot, so spell power gained since doesn't apply.
+	fa.GetCharacter().AddStatDynamic(sim, stats.SpellPower, 100)
+
+	// The copy may have fewer ticks in total, like Living Bomb spread by Impact, but
+	// still runs for the rest of the original.
+	other := fa.Spell.Dot(sim.Encounter.TargetUnits[1])
+	other.NumberOfTicks = 2
+	fa.Dot.CopyTo(sim, other)
+
+	if !other.IsActive() {
+		t.Fatalf("Copied dot should be active")
+	}
+	if other.ExpiresAt() != fa.Dot.ExpiresAt() || other.NextTickAt() != fa.Dot.NextTickAt() {
+		t.Fatalf("Copied dot should tick and expire with the original")
+	}
+	ticksRemaining := fa.Dot.MaxTicksRemaining()
+	if ticksRemaining != 3 || other.MaxTicksRemaining() != ticksRemaining {
+		t.Fatalf("Copied dot has %d ticks remaining, expected %d", other.MaxTicksRemaining(), ticksRemaining)
+	}
+
+	for other.IsActive() {
+		sim.Step()
+	}
+	damage := fa.Spell.SpellMetrics[other.Unit.UnitIndex].TotalDamage
+	if !WithinToleranceFloat64(3*150, damage, 0.01) {
+		t.Fatalf("Incorrect copied dot damage: Expected: 450, Actual: %0.3f", damage)
+	}
+}
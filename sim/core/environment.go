@@ -92,21 +92,7 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 		}
 	}
 
-	// Assign target or target using Tanks field.
-	for _, target := range env.Encounter.Targets {
-		if target.Index < int32(len(encounterProto.Targets)) {
-			targetProto := encounterProto.Targets[target.Index]
-			if targetProto.TankIndex >= 0 && targetProto.TankIndex < int32(len(raidProto.Tanks)) {
-				raidTargetProto := raidProto.Tanks[targetProto.TankIndex]
-				if raidTargetProto != nil {
-					raidTarget := env.GetUnit(raidTargetProto, nil)
-					if raidTarget != nil {
-						target.CurrentTarget = raidTarget
-					}
-				}
-			}
-		}
-	}
+	env.assignTanks(raidProto, encounterProto)
 
 	env.State = Constructed
 }
@@ -135,6 +121,8 @@ func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.
 		}
 	}
 
+	env.registerTankSpells()
+
 	env.State = Initialized
 	return raidStats
 }
@@ -228,8 +216,7 @@ func (env *Environment) IsFinalized() bool {
 }
 
 func (env *Environment) reset(sim *Simulation) {
-	// Reset primary targets damage taken for tracking health fights.
-	env.Encounter.DamageTaken = 0
+	env.Encounter.reset()
 
 	// Targets need to be reset before the raid, so that players can check for
 	// the presence of permanent target auras in their Reset handlers.
@@ -245,8 +232,9 @@ func (env *Environment) GetMaxDuration() time.Duration {
 	return env.BaseDuration + env.DurationVariation
 }
 
+// Returns the number of living targets.
 func (env *Environment) GetNumTargets() int32 {
-	return int32(len(env.Encounter.Targets))
+	return int32(len(env.Encounter.ActiveTargets))
 }
 
 func (env *Environment) GetTarget(index int32) *Target {
@@ -102,8 +102,8 @@ var ChanceOfDeathAuraLabel = "Chance of Death"
 
 func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel) {
 	character.Unit.Metrics.isTanking = false
-	for _, target := range character.Env.Encounter.TargetUnits {
-		if target.CurrentTarget == &character.Unit {
+	for _, target := range character.Env.Encounter.Targets {
+		if target.CurrentTarget == &character.Unit || target.tankIndex(&character.Unit) != -1 {
 			character.Unit.Metrics.isTanking = true
 		}
 	}
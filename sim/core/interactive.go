@@ -0,0 +1,358 @@
+package core
+
+import (
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Whether the unit is controlled from outside the sim, i.e. waits for input
+// instead of running its rotation. Without a controlled unit, every character
+// is controlled, as in the C library.
+func (sim *Simulation) IsInteractive(unit *Unit) bool {
+	return sim.Options.Interactive && (sim.interactiveUnit == nil || sim.interactiveUnit == unit)
+}
+
+// A single iteration stepped through by an external agent, which picks the
+// actions of one player each time they are free to act.
+type interactiveSession struct {
+	mu sync.Mutex
+
+	id     string
+	sim    *Simulation
+	player *Unit
+	done   bool
+
+	lastUsed time.Time // Guarded by sessionsMu.
+}
+
+// Agents don't always end their sessions, so sessions which go unused for a
+// while are dropped, as are the least recently used ones past a limit.
+const (
+	interactiveSessionTTL  = time.Minute * 30
+	maxInteractiveSessions = 64
+)
+
+var (
+	sessionsMu    sync.Mutex
+	sessions      = map[string]*interactiveSession{}
+	nextSessionID int64
+)
+
+func getInteractiveSession(id string) *interactiveSession {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+	session := sessions[id]
+	if session != nil {
+		session.lastUsed = time.Now()
+	}
+	return session
+}
+
+func addInteractiveSession(session *interactiveSession) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+	session.lastUsed = time.Now()
+	evictInteractiveSessions(session.lastUsed, maxInteractiveSessions-1)
+	sessions[session.id] = session
+}
+
+// Drops sessions unused since the TTL, then the least recently used ones until
+// at most maxSessions are left. Callers must hold sessionsMu.
+func evictInteractiveSessions(now time.Time, maxSessions int) {
+	for id, session := range sessions {
+		if now.Sub(session.lastUsed) > interactiveSessionTTL {
+			delete(sessions, id)
+		}
+	}
+	for len(sessions) > maxSessions {
+		var oldest *interactiveSession
+		for _, session := range sessions {
+			if oldest == nil || session.lastUsed.Before(oldest.lastUsed) {
+				oldest = session
+			}
+		}
+		delete(sessions, oldest.id)
+	}
+}
+
+func StartInteractiveSession(request *proto.StartSessionRequest) (result *proto.SessionObservation) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.SessionObservation{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	if request.Request == nil || request.Request.SimOptions == nil {
+		return &proto.SessionObservation{ErrorResult: "Session needs a sim request"}
+	}
+	simRequest := googleProto.Clone(request.Request).(*proto.RaidSimRequest)
+	simRequest.SimOptions.Iterations = 1
+	simRequest.SimOptions.Interactive = true
+
+	sim := NewSim(simRequest)
+	player := sim.GetUnit(&proto.UnitReference{Type: proto.UnitReference_Player, Index: request.RaidIndex}, nil)
+	if player == nil {
+		return &proto.SessionObservation{ErrorResult: "No player with raid index " + strconv.Itoa(int(request.RaidIndex))}
+	}
+	sim.interactiveUnit = player
+
+	sessionsMu.Lock()
+	nextSessionID++
+	session := &interactiveSession{
+		id:     strconv.FormatInt(nextSessionID, 10),
+		sim:    sim,
+		player: player,
+	}
+	sessionsMu.Unlock()
+
+	sim.Reset()
+	sim.PrePull()
+	session.advance()
+	observation := session.observe(true)
+
+	// Only sessions which started cleanly can be stepped.
+	addInteractiveSession(session)
+	return observation
+}
+
+func StepInteractiveSession(request *proto.SessionStepRequest) (result *proto.SessionObservation) {
+	session := getInteractiveSession(request.SessionId)
+	if session == nil {
+		return &proto.SessionObservation{SessionId: request.SessionId, ErrorResult: "No session with id " + request.SessionId}
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	defer func() {
+		if err := recover(); err != nil {
+			session.done = true
+			result = &proto.SessionObservation{
+				SessionId:   session.id,
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	if session.done {
+		return session.observe(false)
+	}
+
+	succeeded := session.act(request)
+	session.advance()
+	return session.observe(succeeded)
+}
+
+func EndInteractiveSession(request *proto.EndSessionRequest) (result *proto.EndSessionResult) {
+	sessionsMu.Lock()
+	session := sessions[request.SessionId]
+	delete(sessions, request.SessionId)
+	sessionsMu.Unlock()
+
+	if session == nil {
+		return &proto.EndSessionResult{ErrorResult: "No session with id " + request.SessionId}
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.EndSessionResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	sim := session.sim
+	if !session.done {
+		// Hands the player back to their rotation for the rest of the iteration.
+		sim.Options.Interactive = false
+		if sim.NeedsInput {
+			sim.NeedsInput = false
+			session.player.SetRotationTimer(sim, sim.CurrentTime)
+		}
+		sim.runPendingActions()
+	}
+	sim.Cleanup()
+
+	return &proto.EndSessionResult{
+		Result: &proto.RaidSimResult{
+			RaidMetrics:            sim.Raid.GetMetrics(),
+			EncounterMetrics:       sim.Encounter.GetMetricsProto(),
+			FirstIterationDuration: sim.Duration.Seconds(),
+			AvgIterationDuration:   sim.Duration.Seconds(),
+		},
+	}
+}
+
+// Casts the requested spell, or idles. Returns whether the action happened.
+func (session *interactiveSession) act(request *proto.SessionStepRequest) bool {
+	sim, player := session.sim, session.player
+
+	if request.SpellId == nil {
+		if request.WaitSeconds <= 0 {
+			return false
+		}
+		// Waking up before the GCD ends would leave the player without input.
+		player.WaitUntil(sim, max(sim.CurrentTime+DurationFromSeconds(request.WaitSeconds), player.GCD.ReadyAt()))
+		sim.NeedsInput = false
+		return true
+	}
+
+	actionID := ProtoToActionID(request.SpellId)
+	spells := session.spells()
+	spellIdx := slices.IndexFunc(spells, func(spell *Spell) bool {
+		return spell.ActionID.SameAction(actionID)
+	})
+	if spellIdx == -1 {
+		return false
+	}
+	spell := spells[spellIdx]
+
+	target := player.CurrentTarget
+	if spell.Flags.Matches(SpellFlagHelpful) {
+		target = player
+	}
+	if request.Target != nil {
+		if target = player.GetUnit(request.Target); target == nil {
+			return false
+		}
+	}
+
+	if !spell.CanCast(sim, target) || !spell.Cast(sim, target) {
+		return false
+	}
+
+	// Off-GCD spells leave the player free to act again right away.
+	if player.NextRotationActionAt() > sim.CurrentTime {
+		sim.NeedsInput = false
+	}
+	return true
+}
+
+// The action space of the session: spells of the player the rotation could cast
+// during the encounter.
+func (session *interactiveSession) spells() []*Spell {
+	return FilterSlice(session.player.Spellbook, func(spell *Spell) bool {
+		return spell.Flags.Matches(SpellFlagAPL) && !spell.Flags.Matches(SpellFlagPrepullOnly|SpellFlagPrepullPotion)
+	})
+}
+
+// Runs the sim until the controlled player needs input or the iteration ends.
+func (session *interactiveSession) advance() {
+	sim := session.sim
+	for !sim.NeedsInput {
+		if sim.Step() {
+			session.done = true
+			return
+		}
+	}
+}
+
+func (session *interactiveSession) observe(lastActionSucceeded bool) *proto.SessionObservation {
+	sim, player := session.sim, session.player
+
+	observation := &proto.SessionObservation{
+		SessionId:           session.id,
+		Done:                session.done,
+		CurrentTime:         sim.CurrentTime.Seconds(),
+		RemainingTime:       sim.GetRemainingDuration().Seconds(),
+		LastActionSucceeded: lastActionSucceeded,
+		DamageDone:          damageDoneSoFar(player),
+		Player:              observeUnit(sim, player),
+	}
+
+	for _, target := range sim.Encounter.Targets {
+		observedTarget := observeUnit(sim, &target.Unit)
+		observedTarget.IsDead = target.IsDead()
+		observation.Targets = append(observation.Targets, observedTarget)
+	}
+
+	for _, spell := range session.spells() {
+		observation.Spells = append(observation.Spells, &proto.ObservedSpell{
+			Id:          spell.ActionID.ToProto(),
+			TimeToReady: spell.TimeToReady(sim).Seconds(),
+			CanCast:     !session.done && spell.CanCast(sim, player.CurrentTarget),
+		})
+	}
+
+	return observation
+}
+
+func observeUnit(sim *Simulation, unit *Unit) *proto.ObservedUnit {
+	observed := &proto.ObservedUnit{
+		UnitIndex: unit.UnitIndex,
+	}
+
+	addResource := func(resourceType proto.ResourceType, value float64) {
+		observed.Resources = append(observed.Resources, &proto.ObservedResource{Type: resourceType, Value: value})
+	}
+	if unit.HasManaBar() {
+		addResource(proto.ResourceType_ResourceTypeMana, unit.CurrentMana())
+	}
+	if unit.HasRageBar() {
+		addResource(proto.ResourceType_ResourceTypeRage, unit.CurrentRage())
+	}
+	if unit.HasEnergyBar() {
+		addResource(proto.ResourceType_ResourceTypeEnergy, unit.CurrentEnergy())
+		addResource(proto.ResourceType_ResourceTypeComboPoints, float64(unit.ComboPoints()))
+	}
+	if unit.HasFocusBar() {
+		addResource(proto.ResourceType_ResourceTypeFocus, unit.CurrentFocus())
+	}
+	if unit.HasRunicPowerBar() {
+		addResource(proto.ResourceType_ResourceTypeRunicPower, unit.CurrentRunicPower())
+		addResource(proto.ResourceType_ResourceTypeBloodRune, float64(unit.CurrentBloodRunes()))
+		addResource(proto.ResourceType_ResourceTypeFrostRune, float64(unit.CurrentFrostRunes()))
+		addResource(proto.ResourceType_ResourceTypeUnholyRune, float64(unit.CurrentUnholyRunes()))
+		addResource(proto.ResourceType_ResourceTypeDeathRune, float64(unit.CurrentDeathRunes()))
+	}
+	if unit.HasHealthBar() {
+		addResource(proto.ResourceType_ResourceTypeHealth, unit.CurrentHealth())
+		observed.HealthPercent = unit.CurrentHealthPercent()
+	}
+
+	for _, aura := range unit.auras {
+		if !aura.IsActive() || aura.ActionID.IsEmptyAction() {
+			continue
+		}
+		remainingTime := -1.0
+		if remaining := aura.RemainingDuration(sim); remaining != NeverExpires {
+			remainingTime = remaining.Seconds()
+		}
+		observed.Auras = append(observed.Auras, &proto.ObservedAura{
+			Id:            aura.ActionID.ToProto(),
+			Label:         aura.Label,
+			RemainingTime: remainingTime,
+			Stacks:        aura.GetStacks(),
+		})
+	}
+
+	return observed
+}
+
+// Damage of the unit's spells so far, before it is folded into its metrics at
+// the end of the iteration.
+func damageDoneSoFar(unit *Unit) float64 {
+	var damage float64
+	for _, spell := range unit.Spellbook {
+		for _, spellMetrics := range spell.splitSpellMetrics {
+			for i, spellTargetMetrics := range spellMetrics {
+				if unit.IsOpponent(unit.AttackTables[i].Defender) {
+					damage += spellTargetMetrics.TotalDamage
+				}
+			}
+		}
+	}
+	return damage
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestInteractiveSession(t *testing.T) {
+	request := &proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 88}},
+			Duration: 10,
+		},
+	}
+
+	observation := StartInteractiveSession(&proto.StartSessionRequest{Request: request})
+	if observation.ErrorResult != "" {
+		t.Fatalf("Failed to start session: %s", observation.ErrorResult)
+	}
+	if observation.Done || observation.CurrentTime != 0 {
+		t.Fatalf("Expected the player to need input at the start of the fight, got %v", observation)
+	}
+	if len(observation.Targets) != 1 {
+		t.Fatalf("Expected 1 observed target, got %d", len(observation.Targets))
+	}
+
+	step := StepInteractiveSession(&proto.SessionStepRequest{SessionId: observation.SessionId, SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 42}}})
+	if step.LastActionSucceeded || step.CurrentTime != 0 {
+		t.Fatalf("Expected spells without the APL flag not to be castable")
+	}
+
+	for steps := 0; !step.Done; steps++ {
+		if steps > 10 {
+			t.Fatalf("Expected the fight to end after 10 waits of 1s")
+		}
+		step = StepInteractiveSession(&proto.SessionStepRequest{SessionId: observation.SessionId, WaitSeconds: 1})
+		if step.ErrorResult != "" {
+			t.Fatalf("Failed to step session: %s", step.ErrorResult)
+		}
+	}
+
+	result := EndInteractiveSession(&proto.EndSessionRequest{SessionId: observation.SessionId})
+	if result.ErrorResult != "" || result.Result.FirstIterationDuration != 10 {
+		t.Fatalf("Expected a 10s iteration, got %v", result)
+	}
+	if StepInteractiveSession(&proto.SessionStepRequest{SessionId: observation.SessionId}).ErrorResult == "" {
+		t.Fatalf("Expected ended sessions to be removed")
+	}
+}
+
+func TestInteractiveSessionEviction(t *testing.T) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+	oldSessions := sessions
+	defer func() { sessions = oldSessions }()
+
+	now := time.Now()
+	sessions = map[string]*interactiveSession{
+		"stale":  {id: "stale", lastUsed: now.Add(-interactiveSessionTTL - time.Second)},
+		"oldest": {id: "oldest", lastUsed: now.Add(-time.Minute * 2)},
+		"older":  {id: "older", lastUsed: now.Add(-time.Minute)},
+		"newest": {id: "newest", lastUsed: now},
+	}
+
+	evictInteractiveSessions(now, 2)
+	if len(sessions) != 2 || sessions["older"] == nil || sessions["newest"] == nil {
+		t.Fatalf("Expected only the 2 most recently used sessions to be kept, got %v", sessions)
+	}
+}
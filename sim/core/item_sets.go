@@ -63,6 +63,16 @@ func NewItemSet(set ItemSet) *ItemSet {
 	return &set
 }
 
+// Returns the registered set with the given name or alternative name, or nil.
+func GetItemSetByName(name string) *ItemSet {
+	for _, set := range sets {
+		if set.Name == name || (set.AlternativeName != "" && set.AlternativeName == name) {
+			return set
+		}
+	}
+	return nil
+}
+
 func (character *Character) HasSetBonus(set *ItemSet, numItems int32) bool {
 	if character.Env != nil && character.Env.IsFinalized() {
 		panic("HasSetBonus is very slow and should never be called after finalization. Try caching the value during construction instead!")
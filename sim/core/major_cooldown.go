@@ -60,11 +60,19 @@ type MajorCooldown struct {
 	// are used instead of ShouldActivate.
 	timings []time.Duration
 
+	// Cooldown that this MCD is synced to. When set, this MCD is only used
+	// alongside the referenced cooldown.
+	syncWith ActionID
+
 	// Number of times this MCD was used so far in the current iteration.
 	numUsages int
 
 	// Whether this MCD is currently disabled.
 	disabled bool
+
+	// For survival cooldowns, the temporary auras of the same spell, whose uptime
+	// tells whether the cooldown is currently protecting the character.
+	survivalAuras []*Aura
 }
 
 func (mcd *MajorCooldown) ReadyAt() time.Duration {
@@ -108,6 +116,10 @@ func (mcd *MajorCooldown) GetTimings() []time.Duration {
 	return mcd.timings
 }
 
+func (mcd *MajorCooldown) IsSynced() bool {
+	return !mcd.syncWith.IsEmptyAction()
+}
+
 // Public version of TryActivate for manual activation by Agent code.
 // Note that this version will work even if the MCD is disabled.
 func (mcd *MajorCooldown) TryActivate(sim *Simulation, character *Character) bool {
@@ -127,10 +139,22 @@ func (mcd *MajorCooldown) shouldActivateHelper(sim *Simulation, character *Chara
 		return false
 	}
 
+	// Synced cooldowns are activated by the cooldown they are synced to.
+	if mcd.IsSynced() {
+		return false
+	}
+
 	if mcd.numUsages < len(mcd.timings) {
 		return sim.CurrentTime >= mcd.timings[mcd.numUsages]
 	}
 
+	if mcd.Type.Matches(CooldownTypeSurvival) && character.cooldownConfigs.PlanDefensivesForDamageSpikes {
+		// Spikes decide the timing instead of the usual health based conditions.
+		if useForSpike, hasSpikes := character.shouldUseDefensiveForSpike(sim); hasSpikes {
+			return useForSpike
+		}
+	}
+
 	if mcd.Type.Matches(CooldownTypeSurvival) && character.cooldownConfigs.HpPercentForDefensives != 0 {
 		if character.CurrentHealthPercent() > character.cooldownConfigs.HpPercentForDefensives {
 			return false
@@ -146,24 +170,59 @@ func (mcd *MajorCooldown) tryActivateHelper(sim *Simulation, character *Characte
 	shouldActivate := mcd.shouldActivateHelper(sim, character)
 
 	if shouldActivate {
-		if mcd.Spell.Flags.Matches(SpellFlagHelpful) {
-			mcd.Spell.Cast(sim, &character.Unit)
-		} else {
-			mcd.Spell.Cast(sim, character.CurrentTarget)
-		}
+		mcd.activate(sim, character)
+	}
 
-		mcd.numUsages++
-		if sim.Log != nil {
-			character.Log(sim, "Major cooldown used: %s", mcd.Spell.ActionID)
+	return shouldActivate
+}
+
+func (mcd *MajorCooldown) activate(sim *Simulation, character *Character) {
+	if mcd.Spell.Flags.Matches(SpellFlagHelpful) {
+		mcd.Spell.Cast(sim, &character.Unit)
+	} else {
+		mcd.Spell.Cast(sim, character.CurrentTarget)
+	}
+
+	mcd.numUsages++
+	if sim.Log != nil {
+		character.Log(sim, "Major cooldown used: %s", mcd.Spell.ActionID)
+	}
+}
+
+// Finds the temporary auras a survival cooldown applies to the character. This
+// is the self buff of its spell, or for spells that don't declare one, the
+// auras that share its ActionID.
+func (mcd *MajorCooldown) findSurvivalAuras(character *Character) {
+	mcd.survivalAuras = nil
+	if !mcd.Type.Matches(CooldownTypeSurvival) {
+		return
+	}
+	if aura := mcd.Spell.RelatedSelfBuff; aura != nil {
+		mcd.survivalAuras = []*Aura{aura}
+		return
+	}
+	for _, aura := range character.GetAuras() {
+		if aura.Duration != NeverExpires && aura.ActionID.SameAction(mcd.Spell.ActionID) {
+			mcd.survivalAuras = append(mcd.survivalAuras, aura)
 		}
 	}
+}
 
-	return shouldActivate
+// Activates a synced MCD alongside the cooldown it is synced to. Only the spell
+// conditions are checked, ShouldActivate and timings are ignored.
+func (mcd *MajorCooldown) activateSynced(sim *Simulation, character *Character) {
+	if mcd.disabled || !mcd.Spell.CanCast(sim, character.CurrentTarget) {
+		return
+	}
+
+	mcd.activate(sim, character)
+	character.UpdateMajorCooldowns()
 }
 
 type cooldownConfigs struct {
-	Cooldowns              []*proto.Cooldown
-	HpPercentForDefensives float64
+	Cooldowns                     []*proto.Cooldown
+	HpPercentForDefensives        float64
+	PlanDefensivesForDamageSpikes bool
 }
 
 type majorCooldownManager struct {
@@ -189,7 +248,8 @@ func newMajorCooldownManager(cooldowns *proto.Cooldowns) majorCooldownManager {
 	}
 
 	cooldownConfigs := cooldownConfigs{
-		HpPercentForDefensives: cooldowns.HpPercentForDefensives,
+		HpPercentForDefensives:        cooldowns.HpPercentForDefensives,
+		PlanDefensivesForDamageSpikes: cooldowns.PlanDefensivesForDamageSpikes,
 	}
 	for _, cooldownConfig := range cooldowns.Cooldowns {
 		if cooldownConfig.Id != nil {
@@ -211,13 +271,18 @@ func (mcdm *majorCooldownManager) finalize() {
 	for i := range mcdm.initialMajorCooldowns {
 		mcd := &mcdm.initialMajorCooldowns[i]
 		mcd.timings = []time.Duration{}
+		mcd.findSurvivalAuras(mcdm.character)
 
 		for _, cooldownConfig := range mcdm.cooldownConfigs.Cooldowns {
 			configID := ProtoToActionID(cooldownConfig.Id)
 			if configID.SameAction(mcd.Spell.ActionID) {
-				mcd.timings = make([]time.Duration, len(cooldownConfig.Timings))
-				for t, timing := range cooldownConfig.Timings {
-					mcd.timings[t] = DurationFromSeconds(timing)
+				if cooldownConfig.SyncWith != nil {
+					mcd.syncWith = ProtoToActionID(cooldownConfig.SyncWith)
+				} else {
+					mcd.timings = make([]time.Duration, len(cooldownConfig.Timings))
+					for t, timing := range cooldownConfig.Timings {
+						mcd.timings[t] = DurationFromSeconds(timing)
+					}
 				}
 				break
 			}
@@ -225,6 +290,40 @@ func (mcdm *majorCooldownManager) finalize() {
 	}
 
 	mcdm.majorCooldowns = make([]*MajorCooldown, len(mcdm.initialMajorCooldowns))
+
+	mcdm.setupSyncedCooldowns()
+}
+
+// Hooks each synced MCD into the spell it is synced to, so that it is used
+// regardless of whether that spell is cast by the MCD manager or the APL.
+func (mcdm *majorCooldownManager) setupSyncedCooldowns() {
+	for i := range mcdm.initialMajorCooldowns {
+		mcd := &mcdm.initialMajorCooldowns[i]
+		if !mcd.IsSynced() {
+			continue
+		}
+
+		syncedID := mcd.Spell.ActionID
+		leader := mcdm.character.GetSpell(mcd.syncWith)
+		if leader == nil || leader.SameAction(syncedID) {
+			// Fall back to using the cooldown on its own, rather than never.
+			mcdm.character.ValidationWarning("%s can't be synced with %s, using it unsynced", syncedID, mcd.syncWith)
+			mcd.syncWith = ActionID{}
+			continue
+		}
+
+		character := mcdm.character
+		leaderEffects := leader.ApplyEffects
+		leader.ApplyEffects = func(sim *Simulation, target *Unit, spell *Spell) {
+			leaderEffects(sim, target, spell)
+
+			// Look up the current iteration's copy, which is missing if the APL
+			// took control of this cooldown.
+			if synced := character.GetMajorCooldown(syncedID); synced != nil {
+				synced.activateSynced(sim, character)
+			}
+		}
+	}
 }
 
 func (mcdm *majorCooldownManager) reset(_ *Simulation) {
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func registerFakeCooldownSpell(character *Character, spellID int32, casts *int) *Spell {
+	return character.RegisterSpell(SpellConfig{
+		ActionID: ActionID{SpellID: spellID},
+		Flags:    SpellFlagNoOnCastComplete,
+		Cast: CastConfig{
+			CD: Cooldown{
+				Timer:    character.NewTimer(),
+				Duration: time.Minute,
+			},
+		},
+		ApplyEffects: func(_ *Simulation, _ *Unit, _ *Spell) {
+			*casts++
+		},
+	})
+}
+
+func TestSyncedCooldown(t *testing.T) {
+	sim := SetupFakeSim()
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+
+	var leaderCasts, syncedCasts int
+	leader := registerFakeCooldownSpell(character, 1001, &leaderCasts)
+	synced := registerFakeCooldownSpell(character, 1002, &syncedCasts)
+	character.initialMajorCooldowns = append(character.initialMajorCooldowns, MajorCooldown{Spell: synced, syncWith: leader.ActionID})
+	character.majorCooldowns = make([]*MajorCooldown, len(character.initialMajorCooldowns))
+	character.setupSyncedCooldowns()
+	character.majorCooldownManager.reset(sim)
+
+	leader.Cast(sim, character.CurrentTarget)
+	if leaderCasts != 1 || syncedCasts != 1 {
+		t.Fatalf("Expected the synced cooldown to be used with its leader, got %d leader and %d synced casts", leaderCasts, syncedCasts)
+	}
+	if len(character.warnings) != 0 {
+		t.Fatalf("Expected no warnings, got %v", character.warnings)
+	}
+}
+
+func TestSyncedCooldownUnknownLeader(t *testing.T) {
+	sim := SetupFakeSim()
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+
+	var syncedCasts int
+	synced := registerFakeCooldownSpell(character, 1002, &syncedCasts)
+	character.initialMajorCooldowns = append(character.initialMajorCooldowns, MajorCooldown{Spell: synced, syncWith: ActionID{SpellID: 9999}})
+	character.setupSyncedCooldowns()
+
+	if character.initialMajorCooldowns[len(character.initialMajorCooldowns)-1].IsSynced() {
+		t.Fatalf("Expected a cooldown synced with an unknown spell to fall back to unsynced")
+	}
+	if len(character.warnings) != 1 {
+		t.Fatalf("Expected a warning for the unknown spell, got %v", character.warnings)
+	}
+}
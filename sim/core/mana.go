@@ -131,7 +131,9 @@ func (mb *manaBar) doneIteration(sim *Simulation) {
 
 	manaGainSpell := mb.unit.GetSpell(ActionID{OtherID: proto.OtherAction_OtherActionManaGain})
 
-	for _, resourceMetrics := range mb.unit.Metrics.resources {
+	// Mana restored by pets, like Shadowfiend, still generates threat for the owner.
+	resources := append(mb.unit.Metrics.resources[:len(mb.unit.Metrics.resources):len(mb.unit.Metrics.resources)], mb.unit.Metrics.petResources...)
+	for _, resourceMetrics := range resources {
 		if resourceMetrics.Type != proto.ResourceType_ResourceTypeMana {
 			continue
 		}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"math"
+	"slices"
 	"time"
 
 	"github.com/wowsims/cata/sim/core/proto"
@@ -97,6 +98,11 @@ type UnitMetrics struct {
 	oomTimeSum   float64
 	actions      map[ActionID]*ActionMetrics
 	resources    []*ResourceMetrics
+	damageSpikes map[ActionID]*damageSpikeMetrics
+
+	// Resources this unit gained from its pets, which are reported with the
+	// pet that restored them.
+	petResources []*ResourceMetrics
 }
 
 // Metrics for the current iteration, for 1 agent. Keep this as a separate
@@ -154,6 +160,7 @@ type SpellMetrics struct {
 	TotalThreat    float64 // Threat generated by all casts of this spell.
 	TotalHealing   float64 // Healing done by all casts of this spell.
 	TotalShielding float64 // Shielding done by all casts of this spell.
+	TotalAbsorbed  float64 // Damage absorbed by shields from this spell.
 	TotalCastTime  time.Duration
 }
 
@@ -173,6 +180,7 @@ type TargetedActionMetrics struct {
 	Threat    float64
 	Healing   float64
 	Shielding float64
+	Absorbed  float64
 	CastTime  time.Duration
 }
 
@@ -192,6 +200,7 @@ func (tam *TargetedActionMetrics) ToProto() *proto.TargetedActionMetrics {
 		Threat:     tam.Threat,
 		Healing:    tam.Healing,
 		Shielding:  tam.Shielding,
+		Absorbed:   tam.Absorbed,
 		CastTimeMs: float64(tam.CastTime.Milliseconds()),
 	}
 }
@@ -324,6 +333,7 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 		tam.Threat += spellTargetMetrics.TotalThreat
 		tam.Healing += spellTargetMetrics.TotalHealing
 		tam.Shielding += spellTargetMetrics.TotalShielding
+		tam.Absorbed += spellTargetMetrics.TotalAbsorbed
 		tam.CastTime += spellTargetMetrics.TotalCastTime
 
 		target := spell.Unit.AttackTables[i].Defender
@@ -514,6 +524,12 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		protoMetrics.Actions = append(protoMetrics.Actions, action.ToProto(actionID))
 	}
 
+	for actionID, spike := range unitMetrics.damageSpikes {
+		protoMetrics.DamageSpikes = append(protoMetrics.DamageSpikes, spike.ToProto(actionID, n))
+	}
+
+	protoMetrics.TargetDamage = targetDamageToProto(protoMetrics.Actions, n)
+
 	protoMetrics.Resources = make([]*proto.ResourceMetrics, 0, len(unitMetrics.resources))
 	for _, resource := range unitMetrics.resources {
 		if resource.Events > 0 {
@@ -524,6 +540,28 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 	return protoMetrics
 }
 
+// Sums the damage of all actions per target, in unit index order.
+func targetDamageToProto(actions []*proto.ActionMetrics, n float64) []*proto.TargetDamageMetrics {
+	var targetDamage []*proto.TargetDamageMetrics
+	for _, action := range actions {
+		for _, tam := range action.Targets {
+			if tam.Damage == 0 {
+				continue
+			}
+			idx := slices.IndexFunc(targetDamage, func(td *proto.TargetDamageMetrics) bool { return td.UnitIndex == tam.UnitIndex })
+			if idx == -1 {
+				targetDamage = append(targetDamage, &proto.TargetDamageMetrics{UnitIndex: tam.UnitIndex})
+				idx = len(targetDamage) - 1
+			}
+			targetDamage[idx].Damage += tam.Damage / n
+		}
+	}
+	slices.SortFunc(targetDamage, func(a, b *proto.TargetDamageMetrics) int {
+		return int(a.UnitIndex - b.UnitIndex)
+	})
+	return targetDamage
+}
+
 type AuraMetrics struct {
 	ID ActionID
 
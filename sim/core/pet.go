@@ -33,6 +33,10 @@ type Pet struct {
 	isGuardian     bool
 	enabledOnStart bool
 
+	// Whether inherited stats follow the owner's stats while the pet is active,
+	// instead of being snapshot on summon.
+	liveStatInheritance bool
+
 	OnPetEnable  OnPetEnable
 	OnPetDisable OnPetDisable
 
@@ -75,10 +79,11 @@ func NewPet(name string, owner *Character, baseStats stats.Stats, statInheritanc
 			PartyIndex: owner.PartyIndex,
 			baseStats:  baseStats,
 		},
-		Owner:           owner,
-		statInheritance: statInheritance,
-		enabledOnStart:  enabledOnStart,
-		isGuardian:      isGuardian,
+		Owner:               owner,
+		statInheritance:     statInheritance,
+		enabledOnStart:      enabledOnStart,
+		isGuardian:          isGuardian,
+		liveStatInheritance: !isGuardian,
 	}
 	pet.GCD = pet.NewTimer()
 	pet.RotationTimer = pet.NewTimer()
@@ -94,7 +99,10 @@ func NewPet(name string, owner *Character, baseStats stats.Stats, statInheritanc
 // addedStats is the amount of stats added to the owner (will be negative if the
 // owner lost stats).
 func (pet *Pet) addOwnerStats(sim *Simulation, addedStats stats.Stats) {
-	inheritedChange := pet.dynamicStatInheritance(addedStats)
+	// Inheritance isn't always linear (offsets, floored hit), so compare the
+	// inherited stats before and after the change.
+	ownerStats := pet.Owner.GetStats()
+	inheritedChange := pet.dynamicStatInheritance(ownerStats).Subtract(pet.dynamicStatInheritance(ownerStats.Subtract(addedStats)))
 
 	pet.inheritedStats.AddInplace(&inheritedChange)
 	pet.AddStatsDynamic(sim, inheritedChange)
@@ -144,18 +152,13 @@ func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 		sim.AddPendingAction(&PendingAction{
 			NextActionAt: sim.CurrentTime + pet.inheritanceDelay,
 			OnAction: func(sim *Simulation) {
-				pet.inheritedStats = pet.statInheritance(pet.Owner.GetStats())
-				pet.AddStatsDynamic(sim, pet.inheritedStats)
+				if pet.enabled {
+					pet.inheritOwnerStats(sim)
+				}
 			},
 		})
 	} else {
-		pet.inheritedStats = pet.statInheritance(pet.Owner.GetStats())
-		pet.AddStatsDynamic(sim, pet.inheritedStats)
-	}
-
-	if !pet.isGuardian {
-		pet.Owner.DynamicStatsPets = append(pet.Owner.DynamicStatsPets, pet)
-		pet.dynamicStatInheritance = pet.statInheritance
+		pet.inheritOwnerStats(sim)
 	}
 
 	//reset current mana after applying stats
@@ -192,6 +195,18 @@ func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 	}
 }
 
+// Applies the owner's current stats to the pet, and starts following the
+// owner's stat changes if the pet uses live inheritance.
+func (pet *Pet) inheritOwnerStats(sim *Simulation) {
+	pet.inheritedStats = pet.statInheritance(pet.Owner.GetStats())
+	pet.AddStatsDynamic(sim, pet.inheritedStats)
+
+	if pet.liveStatInheritance {
+		pet.Owner.DynamicStatsPets = append(pet.Owner.DynamicStatsPets, pet)
+		pet.dynamicStatInheritance = pet.statInheritance
+	}
+}
+
 // Helper for enabling a pet that will expire after a certain duration.
 func (pet *Pet) EnableWithTimeout(sim *Simulation, petAgent PetAgent, petDuration time.Duration) {
 	pet.Enable(sim, petAgent)
@@ -205,6 +220,36 @@ func (pet *Pet) EnableWithTimeout(sim *Simulation, petAgent PetAgent, petDuratio
 	sim.AddPendingAction(pet.timeoutAction)
 }
 
+// Makes the pet's inherited stats follow its owner's stats while it is active.
+// This is the default for non-guardian pets.
+func (pet *Pet) EnableLiveStatInheritance() {
+	pet.liveStatInheritance = true
+}
+
+// Makes the pet snapshot its owner's stats on summon. This is the default for
+// guardians.
+func (pet *Pet) EnableSnapshotStatInheritance() {
+	pet.liveStatInheritance = false
+}
+
+func (pet *Pet) HasLiveStatInheritance() bool {
+	return pet.liveStatInheritance
+}
+
+// Creates metrics for a resource the pet restores to its owner, like
+// Shadowfiend's mana. They are reported with the pet, so each pet's share of the
+// owner's resource gains shows separately.
+func (pet *Pet) NewOwnerResourceMetrics(actionID ActionID, resourceType proto.ResourceType) *ResourceMetrics {
+	metrics := pet.Metrics.NewResourceMetrics(actionID, resourceType)
+	pet.Owner.Metrics.petResources = append(pet.Owner.Metrics.petResources, metrics)
+	return metrics
+}
+
+// Returns the stats the pet currently inherits from its owner.
+func (pet *Pet) InheritedStats() stats.Stats {
+	return pet.inheritedStats
+}
+
 // Enables and possibly updates how the pet inherits its owner's stats. DK use only.
 func (pet *Pet) EnableDynamicStats(inheritance PetStatInheritance) {
 	if !slices.Contains(pet.Owner.DynamicStatsPets, pet) {
@@ -290,3 +335,50 @@ func (pet *Pet) AddRaidBuffs(_ *proto.RaidBuffs)   {}
 func (pet *Pet) AddPartyBuffs(_ *proto.PartyBuffs) {}
 func (pet *Pet) ApplyTalents()                     {}
 func (pet *Pet) OnGCDReady(_ *Simulation)          {}
+
+// Hit caps pets are normalized to, in percent (expertise in expertise points).
+const (
+	petMeleeHitCap  = 8.0
+	petSpellHitCap  = 17.0
+	petExpertiseCap = 26.0
+)
+
+// Pets inherit their owner's hit as a fraction of the owner's hit cap, so a hit
+// capped owner has a pet that is melee hit, spell hit and expertise capped.
+func petHitFromCapFraction(capFraction float64) stats.Stats {
+	return stats.Stats{
+		stats.MeleeHit:  capFraction * petMeleeHitCap * MeleeHitRatingPerHitChance,
+		stats.SpellHit:  capFraction * petSpellHitCap * SpellHitRatingPerHitChance,
+		stats.Expertise: capFraction * petExpertiseCap * ExpertisePerQuarterPercentReduction,
+	}
+}
+
+// Pet hit, spell hit and expertise inherited from a physical owner's hit.
+func InheritPhysicalHit(ownerStats stats.Stats) stats.Stats {
+	return petHitFromCapFraction(ownerStats[stats.MeleeHit] / (petMeleeHitCap * MeleeHitRatingPerHitChance))
+}
+
+// Pet hit, spell hit and expertise inherited from a caster owner's spell hit.
+func InheritSpellHit(ownerStats stats.Stats) stats.Stats {
+	return petHitFromCapFraction(ownerStats[stats.SpellHit] / (petSpellHitCap * SpellHitRatingPerHitChance))
+}
+
+// Pet crit and haste inherited from a physical owner's melee/ranged crit and haste.
+func InheritPhysicalCritAndHaste(ownerStats stats.Stats) stats.Stats {
+	return stats.Stats{
+		stats.MeleeCrit:  ownerStats[stats.MeleeCrit],
+		stats.SpellCrit:  ownerStats[stats.MeleeCrit],
+		stats.MeleeHaste: ownerStats[stats.MeleeHaste],
+		stats.SpellHaste: ownerStats[stats.MeleeHaste],
+	}
+}
+
+// Pet crit and haste inherited from a caster owner's spell crit and haste.
+func InheritSpellCritAndHaste(ownerStats stats.Stats) stats.Stats {
+	return stats.Stats{
+		stats.MeleeCrit:  ownerStats[stats.SpellCrit],
+		stats.SpellCrit:  ownerStats[stats.SpellCrit],
+		stats.MeleeHaste: ownerStats[stats.SpellHaste],
+		stats.SpellHaste: ownerStats[stats.SpellHaste],
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func expectPetStat(t *testing.T, petStats stats.Stats, stat stats.Stat, expected float64) {
+	t.Helper()
+	if math.Abs(petStats[stat]-expected) > 1e-6 {
+		t.Fatalf("pet %s: expected %0.3f, got %0.3f", stat.StatName(), expected, petStats[stat])
+	}
+}
+
+func TestInheritPhysicalHit(t *testing.T) {
+	// A hit capped physical owner has a fully capped pet.
+	petStats := InheritPhysicalHit(stats.Stats{stats.MeleeHit: 8 * MeleeHitRatingPerHitChance})
+	expectPetStat(t, petStats, stats.MeleeHit, 8*MeleeHitRatingPerHitChance)
+	expectPetStat(t, petStats, stats.SpellHit, 17*SpellHitRatingPerHitChance)
+	expectPetStat(t, petStats, stats.Expertise, 26*ExpertisePerQuarterPercentReduction)
+
+	// Half the owner's cap gives half of each pet cap.
+	petStats = InheritPhysicalHit(stats.Stats{stats.MeleeHit: 4 * MeleeHitRatingPerHitChance})
+	expectPetStat(t, petStats, stats.MeleeHit, 4*MeleeHitRatingPerHitChance)
+	expectPetStat(t, petStats, stats.SpellHit, 8.5*SpellHitRatingPerHitChance)
+	expectPetStat(t, petStats, stats.Expertise, 13*ExpertisePerQuarterPercentReduction)
+}
+
+func TestInheritSpellHit(t *testing.T) {
+	petStats := InheritSpellHit(stats.Stats{stats.SpellHit: 17 * SpellHitRatingPerHitChance})
+	expectPetStat(t, petStats, stats.MeleeHit, 8*MeleeHitRatingPerHitChance)
+	expectPetStat(t, petStats, stats.SpellHit, 17*SpellHitRatingPerHitChance)
+	expectPetStat(t, petStats, stats.Expertise, 26*ExpertisePerQuarterPercentReduction)
+}
+
+func TestInheritCritAndHaste(t *testing.T) {
+	ownerStats := stats.Stats{
+		stats.MeleeCrit:  1000,
+		stats.MeleeHaste: 500,
+		stats.SpellCrit:  2000,
+		stats.SpellHaste: 800,
+	}
+
+	petStats := InheritPhysicalCritAndHaste(ownerStats)
+	expectPetStat(t, petStats, stats.SpellCrit, 1000)
+	expectPetStat(t, petStats, stats.SpellHaste, 500)
+
+	petStats = InheritSpellCritAndHaste(ownerStats)
+	expectPetStat(t, petStats, stats.MeleeCrit, 2000)
+	expectPetStat(t, petStats, stats.MeleeHaste, 800)
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Models a human player executing the rotation. Whenever the player becomes
+// free to act, e.g. when the GCD or a cast ends, they press their next action
+// after a random delay, which with a spread can fall before they are free.
+// Presses within the spell queue window before the player is free are queued,
+// so they start on time; otherwise network latency is added on top.
+type playerModel struct {
+	unit *Unit
+
+	latency      time.Duration
+	queueWindow  time.Duration
+	distribution proto.PlayerModel_DelayDistribution
+	delay        time.Duration
+	spread       time.Duration
+
+	missedGCDChance float64
+
+	reacted bool // Whether the player already reacted since their last action.
+}
+
+func newPlayerModel(unit *Unit, config *proto.PlayerModel) *playerModel {
+	if config == nil || (config.LatencyMs <= 0 && config.GcdDelayMs <= 0 && config.GcdDelaySpreadMs <= 0 && config.MissedGcdChance <= 0) {
+		return nil
+	}
+
+	return &playerModel{
+		unit:            unit,
+		latency:         time.Duration(max(config.LatencyMs, 0)) * time.Millisecond,
+		queueWindow:     time.Duration(max(config.SpellQueueWindowMs, 0)) * time.Millisecond,
+		distribution:    config.GcdDelayDistribution,
+		delay:           time.Duration(max(config.GcdDelayMs, 0)) * time.Millisecond,
+		spread:          time.Duration(max(config.GcdDelaySpreadMs, 0)) * time.Millisecond,
+		missedGCDChance: config.MissedGcdChance,
+	}
+}
+
+func (pm *playerModel) reset() {
+	pm.reacted = false
+}
+
+// Samples how long after the player is free to act their next action starts.
+func (pm *playerModel) actionDelay(sim *Simulation) time.Duration {
+	var pressDelay float64
+	switch pm.distribution {
+	case proto.PlayerModel_Uniform:
+		pressDelay = float64(pm.delay) + (2*sim.RandomFloat("Player Model Delay")-1)*float64(pm.spread)
+	case proto.PlayerModel_Normal:
+		pressDelay = float64(pm.delay) + sim.RandomNormFloat("Player Model Delay")*float64(pm.spread)
+	case proto.PlayerModel_Exponential:
+		pressDelay = float64(pm.delay) * sim.RandomExpFloat("Player Model Delay")
+	default:
+		pressDelay = float64(pm.delay)
+	}
+
+	// Presses within the spell queue window before the player is free start as
+	// soon as they are free. Earlier presses aren't queued, so the player has to
+	// press again once free, and later ones wait for the server.
+	var delay time.Duration
+	if press := time.Duration(pressDelay); press < 0 && press >= -pm.queueWindow {
+		delay = 0
+	} else {
+		delay = max(press, 0) + pm.latency
+	}
+
+	if pm.missedGCDChance > 0 && sim.Proc(pm.missedGCDChance, "Player Model Missed GCD") {
+		delay += GCDDefault
+	}
+	return delay
+}
+
+// Delays the rotation the first time it runs after the player's last action.
+// Returns whether the rotation was delayed.
+func (pm *playerModel) delayRotation(sim *Simulation) bool {
+	if pm.reacted {
+		return false
+	}
+	pm.reacted = true
+
+	delay := pm.actionDelay(sim)
+	if delay <= 0 {
+		return false
+	}
+
+	if sim.Log != nil {
+		pm.unit.Log(sim, "Player model delays next action by %s.", delay)
+	}
+	pm.unit.SetRotationTimer(sim, sim.CurrentTime+delay)
+	return true
+}
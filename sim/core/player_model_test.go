@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestPlayerModelActionDelay(t *testing.T) {
+	sim := SetupFakeSim()
+	unit := sim.Raid.AllPlayerUnits[0]
+
+	if newPlayerModel(unit, &proto.PlayerModel{SpellQueueWindowMs: 400}) != nil {
+		t.Fatalf("Expected no player model without any delays")
+	}
+
+	late := newPlayerModel(unit, &proto.PlayerModel{LatencyMs: 50, SpellQueueWindowMs: 400, GcdDelayMs: 300})
+	if delay := late.actionDelay(sim); delay != time.Millisecond*350 {
+		t.Fatalf("Expected actions pressed after the player is free to start after 300ms plus 50ms latency, got %s", delay)
+	}
+
+	// Presses between 100ms before and the moment the player is free are queued.
+	early := newPlayerModel(unit, &proto.PlayerModel{
+		LatencyMs:            50,
+		SpellQueueWindowMs:   100,
+		GcdDelayDistribution: proto.PlayerModel_Uniform,
+		GcdDelaySpreadMs:     300,
+	})
+	var numQueued, numTooEarly int
+	for i := 0; i < 100; i++ {
+		switch delay := early.actionDelay(sim); {
+		case delay == 0:
+			numQueued++
+		case delay == time.Millisecond*50:
+			numTooEarly++
+		case delay < time.Millisecond*50 || delay > time.Millisecond*350:
+			t.Fatalf("Expected late presses within 300ms plus 50ms latency, got %s", delay)
+		}
+	}
+	if numQueued == 0 || numTooEarly == 0 {
+		t.Fatalf("Expected both queued presses and presses too early to be queued, got %d and %d", numQueued, numTooEarly)
+	}
+
+	missed := newPlayerModel(unit, &proto.PlayerModel{MissedGcdChance: 1})
+	if delay := missed.actionDelay(sim); delay != GCDDefault {
+		t.Fatalf("Expected a missed GCD to idle for a full GCD, got %s", delay)
+	}
+
+	uniform := newPlayerModel(unit, &proto.PlayerModel{
+		GcdDelayDistribution: proto.PlayerModel_Uniform,
+		GcdDelayMs:           200,
+		GcdDelaySpreadMs:     100,
+	})
+	for i := 0; i < 100; i++ {
+		if delay := uniform.actionDelay(sim); delay < time.Millisecond*100 || delay > time.Millisecond*300 {
+			t.Fatalf("Expected uniform delays within 200 +/- 100ms, got %s", delay)
+		}
+	}
+}
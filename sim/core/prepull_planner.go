@@ -0,0 +1,210 @@
+package core
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// An opener step of the prepull planner, with the timings of its spell.
+type prepullStep struct {
+	action *proto.APLAction
+	// Nil for actions which don't cast a spell, which are planned as instant and off the GCD.
+	spell *Spell
+
+	castTime   time.Duration
+	gcd        time.Duration
+	travelTime time.Duration
+
+	doAt      time.Duration
+	conflicts []string
+}
+
+// Time the step keeps the player from starting the next one.
+func (step *prepullStep) busyTime() time.Duration {
+	return max(step.gcd, step.castTime)
+}
+
+func prepullActionSpellID(action *proto.APLAction) *proto.ActionID {
+	switch {
+	case action.GetCastSpell() != nil:
+		return action.GetCastSpell().SpellId
+	case action.GetCastFriendlySpell() != nil:
+		return action.GetCastFriendlySpell().SpellId
+	case action.GetChannelSpell() != nil:
+		return action.GetChannelSpell().SpellId
+	}
+	return nil
+}
+
+func newPrepullStep(unit *Unit, action *proto.APLAction) *prepullStep {
+	step := &prepullStep{action: action}
+	spellID := prepullActionSpellID(action)
+	if spellID == nil {
+		return step
+	}
+
+	// Resolve the spell the same way prepull actions do, so the potion placeholder
+	// is the prepull potion.
+	rot := &APLRotation{unit: unit}
+	rot.doAndRecordWarnings(&step.conflicts, true, func() {
+		step.spell = rot.GetAPLSpell(spellID)
+	})
+	if step.spell == nil {
+		return step
+	}
+
+	spell := step.spell
+	if spell.Flags.Matches(SpellFlagEncounterOnly) && !spell.Flags.Matches(SpellFlagPrepullPotion) {
+		step.conflicts = append(step.conflicts, fmt.Sprintf("%s may only be used during the encounter", spell.ActionID))
+	}
+	step.castTime = spell.CastTime()
+	step.gcd = spell.DefaultGCD()
+	step.travelTime = spell.TravelTime()
+	return step
+}
+
+// Returns the cooldown the earlier step puts on a timer shared with the later
+// step, or 0 if they share none.
+func sharedCooldown(earlier *prepullStep, later *prepullStep) time.Duration {
+	if earlier.spell == nil || later.spell == nil {
+		return 0
+	}
+	sharesTimer := func(timer *Timer) bool {
+		return timer != nil && (timer == later.spell.CD.Timer || timer == later.spell.SharedCD.Timer)
+	}
+
+	var cd time.Duration
+	if sharesTimer(earlier.spell.CD.Timer) {
+		cd = max(cd, earlier.spell.CD.Duration)
+	}
+	if sharesTimer(earlier.spell.SharedCD.Timer) {
+		cd = max(cd, earlier.spell.SharedCD.Duration)
+	}
+	return cd
+}
+
+// Plans the steps backwards from the pull, so that each is used as late as
+// possible: the last spell lands on the target by the pull with the GCD ready
+// for the rotation, each step finishes its cast and GCD before the next one
+// starts, and steps sharing a cooldown are far enough apart for it to be ready.
+func planPrepullSteps(steps []*prepullStep) {
+	nextStart := time.Duration(0)
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+		step.doAt = min(nextStart-step.busyTime(), -(step.castTime + step.travelTime))
+
+		for _, later := range steps[i+1:] {
+			if cd := sharedCooldown(step, later); cd > 0 && step.doAt > later.doAt-cd {
+				step.doAt = later.doAt - cd
+				step.conflicts = append(step.conflicts, fmt.Sprintf("Shares a %s cooldown with %s, so it is used at %s",
+					cd, later.spell.ActionID, step.doAt))
+			}
+		}
+
+		// Round down to whole milliseconds, so the plan reads well and stays valid.
+		if rem := step.doAt % time.Millisecond; rem != 0 {
+			step.doAt -= time.Millisecond + rem
+		}
+		nextStart = step.doAt
+	}
+}
+
+// Runs the prepull actions of a single iteration up to the pull, so that
+// failed prepull casts are recorded as warnings of their APL actions.
+func runPrepullToPull(env *Environment) {
+	sim := newSimWithEnv(env, &proto.SimOptions{Iterations: 1})
+	sim.reset()
+	sim.PrePull()
+	for len(sim.pendingActions) > 0 {
+		pa := sim.pendingActions[len(sim.pendingActions)-1]
+		if pa.NextActionAt > 0 || (pa.NextActionAt == 0 && pa.Priority <= ActionPriorityPrePull) {
+			return
+		}
+		sim.Step()
+	}
+}
+
+// Computes do_at times for a player's opener from the cast times, GCDs, travel
+// times and shared cooldowns of its spells, and validates them by running the
+// planned prepull.
+func PlanPrepull(request *proto.PrepullPlanRequest) (result *proto.PrepullPlanResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.PrepullPlanResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	raidProto := request.GetBaseRequest().GetRaid()
+	partyIdx, playerIdx := int(request.PartyIndex), int(request.PlayerIndex)
+	if partyIdx < 0 || partyIdx >= len(raidProto.GetParties()) ||
+		playerIdx < 0 || playerIdx >= len(raidProto.Parties[partyIdx].GetPlayers()) ||
+		raidProto.Parties[partyIdx].Players[playerIdx].GetClass() == proto.Class_ClassUnknown {
+		return &proto.PrepullPlanResult{ErrorResult: "Prepull planner needs a player"}
+	}
+	encounter := request.BaseRequest.Encounter
+	if encounter == nil {
+		encounter = &proto.Encounter{}
+	}
+
+	opener := request.Opener
+	if len(opener) == 0 {
+		for _, prepullAction := range raidProto.Parties[partyIdx].Players[playerIdx].GetRotation().GetPrepullActions() {
+			if !prepullAction.Hide {
+				opener = append(opener, prepullAction.Action)
+			}
+		}
+	}
+	if len(opener) == 0 {
+		return &proto.PrepullPlanResult{ErrorResult: "Prepull planner needs an opener"}
+	}
+
+	env, _, _ := NewEnvironment(raidProto, encounter, false)
+	unit := &env.Raid.Parties[partyIdx].Players[playerIdx].GetCharacter().Unit
+	steps := MapSlice(opener, func(action *proto.APLAction) *prepullStep { return newPrepullStep(unit, action) })
+	planPrepullSteps(steps)
+
+	result = &proto.PrepullPlanResult{}
+	for _, step := range steps {
+		result.PrepullActions = append(result.PrepullActions, &proto.APLPrepullAction{
+			Action: googleProto.Clone(step.action).(*proto.APLAction),
+			DoAtValue: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{
+				Val: step.doAt.String(),
+			}}},
+		})
+	}
+
+	// Validate the plan by running it.
+	planRaid := googleProto.Clone(raidProto).(*proto.Raid)
+	planPlayer := planRaid.Parties[partyIdx].Players[playerIdx]
+	if planPlayer.Rotation == nil {
+		planPlayer.Rotation = &proto.APLRotation{Type: proto.APLRotation_TypeAPL}
+	}
+	planPlayer.Rotation.PrepullActions = result.PrepullActions
+	planEnv, _, _ := NewEnvironment(planRaid, encounter, false)
+	runPrepullToPull(planEnv)
+	planRotation := planEnv.Raid.Parties[partyIdx].Players[playerIdx].GetCharacter().Rotation
+	if planRotation != nil {
+		for i, warnings := range planRotation.prepullWarnings {
+			steps[i].conflicts = append(steps[i].conflicts, warnings...)
+		}
+	}
+
+	for _, step := range steps {
+		result.Steps = append(result.Steps, &proto.PrepullPlanStep{
+			Action:            step.action,
+			DoAtSeconds:       step.doAt.Seconds(),
+			CastTimeSeconds:   step.castTime.Seconds(),
+			GcdSeconds:        step.gcd.Seconds(),
+			TravelTimeSeconds: step.travelTime.Seconds(),
+			Conflicts:         step.conflicts,
+		})
+	}
+	result.Rotation = planPlayer.Rotation
+	return result
+}
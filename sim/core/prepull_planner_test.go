@@ -0,0 +1,144 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestPlanPrepullSteps(t *testing.T) {
+	timer := new(Timer)
+	potion := &Spell{ActionID: ActionID{ItemID: 58145}, CD: Cooldown{Timer: timer, Duration: time.Minute}}
+	otherPotion := &Spell{ActionID: ActionID{ItemID: 58146}, CD: Cooldown{Timer: timer, Duration: time.Minute}}
+
+	steps := []*prepullStep{
+		// Mirror Image: instant with a GCD.
+		{gcd: time.Millisecond * 1500},
+		// Prepot: off the GCD.
+		{spell: potion},
+		// Precast Frostbolt: hasted cast time plus travel time.
+		{castTime: time.Millisecond * 1723, gcd: time.Second, travelTime: time.Millisecond * 571},
+	}
+	planPrepullSteps(steps)
+	doAts := MapSlice(steps, func(step *prepullStep) time.Duration { return step.doAt })
+	want := []time.Duration{-time.Millisecond * 3794, -time.Millisecond * 2294, -time.Millisecond * 2294}
+	if diff := cmp.Diff(want, doAts); diff != "" {
+		t.Fatalf("planPrepullSteps() returned diff (-want +got):\n%s", diff)
+	}
+
+	steps = []*prepullStep{{spell: otherPotion}, {spell: potion}}
+	planPrepullSteps(steps)
+	if steps[0].doAt != -time.Minute || len(steps[0].conflicts) != 1 {
+		t.Fatalf("Expected potions sharing a cooldown to be a minute apart with a conflict, got %s %v", steps[0].doAt, steps[0].conflicts)
+	}
+}
+
+func TestPlanPrepullNeedsPlayer(t *testing.T) {
+	result := PlanPrepull(&proto.PrepullPlanRequest{BaseRequest: &proto.RaidSimRequest{Raid: &proto.Raid{}}})
+	if result.ErrorResult == "" {
+		t.Fatalf("Expected an error for a raid without players")
+	}
+}
+
+// A caster with 25% haste, a hasted instant and a precast bolt taking 1s to travel.
+func NewFakePrepullCaster(char *Character, _ *proto.Player) Agent {
+	fa := &FakeAgent{
+		Character: *char,
+	}
+
+	fa.Init = func() {
+		fa.MultiplyCastSpeed(1.25)
+		fa.RegisterSpell(SpellConfig{
+			ActionID: ActionID{SpellID: 43},
+			Flags:    SpellFlagAPL,
+			Cast: CastConfig{
+				DefaultCast: Cast{GCD: GCDDefault},
+				IgnoreHaste: true,
+			},
+			ApplyEffects: func(_ *Simulation, _ *Unit, _ *Spell) {},
+		})
+		fa.RegisterSpell(SpellConfig{
+			ActionID:     ActionID{SpellID: 44},
+			Flags:        SpellFlagAPL,
+			MissileSpeed: 20,
+			Cast: CastConfig{
+				DefaultCast: Cast{GCD: GCDDefault, CastTime: time.Second * 2},
+			},
+			ApplyEffects: func(_ *Simulation, _ *Unit, _ *Spell) {},
+		})
+	}
+
+	return fa
+}
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_FrostMage{},
+		proto.Spec_SpecFrostMage,
+		NewFakePrepullCaster,
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_FrostMage)
+			if !ok {
+				panic("Invalid spec value for Frost Mage!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+func TestPlanPrepull(t *testing.T) {
+	request := &proto.RaidSimRequest{
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:               "Caster",
+			Class:              proto.Class_ClassMage,
+			Consumes:           &proto.Consumes{},
+			Buffs:              &proto.IndividualBuffs{},
+			Spec:               &proto.Player_FrostMage{},
+			Equipment:          &proto.EquipmentSpec{},
+			DistanceFromTarget: 20,
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{{Name: "target", Level: 88}},
+		},
+	}
+
+	castSpell := func(spellID int32) *proto.APLAction {
+		return &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+			SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: spellID}},
+		}}}
+	}
+	result := PlanPrepull(&proto.PrepullPlanRequest{
+		BaseRequest: request,
+		Opener:      []*proto.APLAction{castSpell(43), castSpell(44)},
+	})
+	if result.ErrorResult != "" {
+		t.Fatalf("PlanPrepull() failed: %s", result.ErrorResult)
+	}
+
+	type stepTimings struct{ DoAt, CastTime, GCD, TravelTime float64 }
+	timings := MapSlice(result.Steps, func(step *proto.PrepullPlanStep) stepTimings {
+		if len(step.Conflicts) > 0 {
+			t.Errorf("Expected the planned prepull to run without conflicts, got %v", step.Conflicts)
+		}
+		return stepTimings{step.DoAtSeconds, step.CastTimeSeconds, step.GcdSeconds, step.TravelTimeSeconds}
+	})
+	want := []stepTimings{
+		// The unhasted GCD has to pass before the bolt starts.
+		{DoAt: -4.1, GCD: 1.5},
+		// The hasted cast lands its bolt 1s after it finishes, at the pull.
+		{DoAt: -2.6, CastTime: 1.6, GCD: 1.2, TravelTime: 1},
+	}
+	if diff := cmp.Diff(want, timings, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Fatalf("PlanPrepull() returned diff (-want +got):\n%s", diff)
+	}
+
+	doAts := MapSlice(result.Rotation.GetPrepullActions(), func(action *proto.APLPrepullAction) string {
+		return action.DoAtValue.GetConst().Val
+	})
+	if diff := cmp.Diff([]string{"-4.1s", "-2.6s"}, doAts); diff != "" {
+		t.Fatalf("Expected the planned prepull actions in the rotation (-want +got):\n%s", diff)
+	}
+}
@@ -74,7 +74,7 @@ func (sim *Simulation) runPresims(request *proto.RaidSimRequest) *proto.RaidSimR
 
 	var lastResult *proto.RaidSimResult
 
-	doOne := sim.Encounter.EndFightAtHealth > 0
+	doOne := sim.Encounter.UseHealth
 	for doOne || remainingAgents > 0 {
 		// ** Run a presim round. **
 
@@ -0,0 +1,282 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Registers the effects of a SimDatabase in JSON format, e.g. an embedded data
+// file of a common effects package.
+func NewProcEffectsFromJson(data []byte) {
+	db := &proto.SimDatabase{}
+	if err := protojson.Unmarshal(data, db); err != nil {
+		panic(fmt.Sprintf("Invalid proc effects data: %s", err))
+	}
+	for _, config := range db.ProcEffects {
+		NewProcEffect(config)
+	}
+}
+
+func NewProcEffect(config *proto.ProcEffect) {
+	applyEffect := compileProcEffect(config)
+	if config.ItemId != 0 {
+		NewItemEffect(config.ItemId, applyEffect)
+	} else {
+		NewEnchantEffect(config.EnchantEffectId, applyEffect)
+	}
+}
+
+// Compiles the effects defined by a player's request, by item / enchant. These
+// only apply to the request's own characters, where they replace the registered
+// effects so theorycrafters can tweak them between sims.
+func compileRequestProcEffects(configs []*proto.ProcEffect) (itemProcEffects map[int32]ApplyEffect, enchantProcEffects map[int32]ApplyEffect) {
+	itemProcEffects = map[int32]ApplyEffect{}
+	enchantProcEffects = map[int32]ApplyEffect{}
+	for _, config := range configs {
+		if config.ItemId != 0 {
+			itemProcEffects[config.ItemId] = compileProcEffect(config)
+		} else {
+			enchantProcEffects[config.EnchantEffectId] = compileProcEffect(config)
+		}
+	}
+	return itemProcEffects, enchantProcEffects
+}
+
+// Turns an effect definition into the same triggers and auras as the Go
+// helpers, like shared.NewProcStatBonusEffect, would make.
+func compileProcEffect(config *proto.ProcEffect) ApplyEffect {
+	if (config.ItemId == 0) == (config.EnchantEffectId == 0) {
+		panic(fmt.Sprintf("Proc effect %s needs exactly one of an item or enchant ID", config.Name))
+	}
+	if config.Name == "" {
+		panic(fmt.Sprintf("Proc effect of item %d / enchant %d needs a name", config.ItemId, config.EnchantEffectId))
+	}
+
+	// Trinkets use the item as action, enchants their spell.
+	actionID := ActionID{ItemID: config.ItemId}
+	auraID := ActionID{SpellID: config.SpellId}
+	if config.ItemId == 0 {
+		actionID = auraID
+	} else if auraID.IsEmptyAction() {
+		auraID = actionID
+	}
+
+	bonus := stats.Stats{}
+	for _, statBonus := range config.Stats {
+		bonus[stats.Stat(statBonus.Stat)] += statBonus.Value
+	}
+	duration := DurationFromSeconds(config.DurationSeconds)
+
+	if config.Type == proto.ProcEffectType_ProcEffectOnUseStats {
+		if bonus.Equals(stats.Stats{}) || duration == 0 || config.CooldownSeconds <= 0 {
+			panic(fmt.Sprintf("On-use effect %s needs stats, a duration and a cooldown", config.Name))
+		}
+		cooldown := DurationFromSeconds(config.CooldownSeconds)
+		return MakeTemporaryStatsOnUseCDRegistration(
+			config.Name,
+			bonus,
+			duration,
+			SpellConfig{
+				ActionID: actionID,
+			},
+			func(character *Character) Cooldown {
+				return Cooldown{
+					Timer:    character.NewTimer(),
+					Duration: cooldown,
+				}
+			},
+			func(character *Character) Cooldown {
+				sharedTimer := character.GetOffensiveTrinketCD()
+				if config.Defensive {
+					sharedTimer = character.GetDefensiveTrinketCD()
+				}
+				return Cooldown{
+					Timer:    sharedTimer,
+					Duration: duration,
+				}
+			},
+		)
+	}
+
+	trigger := ProcTrigger{
+		Name:       config.Name,
+		ActionID:   actionID,
+		Outcome:    procOutcomeFromProto(config.Outcome),
+		Harmful:    config.Harmful,
+		ProcChance: config.ProcChance,
+		PPM:        config.Ppm,
+		ICD:        DurationFromSeconds(config.IcdSeconds),
+	}
+	for _, callback := range config.Callbacks {
+		trigger.Callback |= procCallbackFromProto(callback)
+	}
+	for _, procMask := range config.ProcMasks {
+		trigger.ProcMask |= procMaskFromProto(procMask)
+	}
+	if trigger.Callback == CallbackEmpty {
+		panic(fmt.Sprintf("Proc effect %s needs a callback", config.Name))
+	}
+
+	switch config.Type {
+	case proto.ProcEffectType_ProcEffectStats:
+		if bonus.Equals(stats.Stats{}) || duration == 0 {
+			panic(fmt.Sprintf("Stat proc %s needs stats and a duration", config.Name))
+		}
+		return func(agent Agent) {
+			character := agent.GetCharacter()
+			procAura := character.NewTemporaryStatsAura(config.Name+" Proc", auraID, bonus, duration)
+
+			localTrigger := trigger
+			localTrigger.Handler = func(sim *Simulation, _ *Spell, _ *SpellResult) {
+				procAura.Activate(sim)
+			}
+			procAura.Icd = MakeProcTriggerAura(&character.Unit, localTrigger).Icd
+		}
+
+	case proto.ProcEffectType_ProcEffectStackingStats:
+		if bonus.Equals(stats.Stats{}) || duration == 0 || config.MaxStacks <= 0 {
+			panic(fmt.Sprintf("Stacking proc %s needs stats, a duration and max stacks", config.Name))
+		}
+		return func(agent Agent) {
+			character := agent.GetCharacter()
+			procAura := MakeStackingAura(character, StackingStatAura{
+				Aura: Aura{
+					Label:     config.Name + " Proc",
+					ActionID:  auraID,
+					Duration:  duration,
+					MaxStacks: config.MaxStacks,
+				},
+				BonusPerStack: bonus,
+			})
+
+			localTrigger := trigger
+			localTrigger.Handler = func(sim *Simulation, _ *Spell, _ *SpellResult) {
+				procAura.Activate(sim)
+				procAura.AddStack(sim)
+			}
+			MakeProcTriggerAura(&character.Unit, localTrigger)
+		}
+
+	case proto.ProcEffectType_ProcEffectDamage:
+		if config.SpellId == 0 || config.MaxDamage < config.MinDamage || config.MaxDamage <= 0 {
+			panic(fmt.Sprintf("Damage proc %s needs a spell and a damage range", config.Name))
+		}
+		var flags SpellFlag
+		if config.IgnoreModifiers {
+			flags |= SpellFlagNoSpellMods | SpellFlagIgnoreModifiers
+		}
+		return func(agent Agent) {
+			character := agent.GetCharacter()
+			damageSpell := character.RegisterSpell(SpellConfig{
+				ActionID:    ActionID{SpellID: config.SpellId},
+				SpellSchool: SpellSchoolFromProto(config.School),
+				ProcMask:    ProcMaskEmpty,
+				Flags:       flags,
+
+				DamageMultiplier: 1,
+				CritMultiplier:   character.DefaultSpellCritMultiplier(),
+				ThreatMultiplier: 1,
+				BonusCoefficient: config.BonusCoefficient,
+
+				ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+					baseDamage := sim.Roll(config.MinDamage, config.MaxDamage)
+					spell.CalcAndDealDamage(sim, target, baseDamage, procDamageOutcome(spell, config.DamageOutcome))
+				},
+			})
+
+			localTrigger := trigger
+			localTrigger.Handler = func(sim *Simulation, spell *Spell, _ *SpellResult) {
+				// Triggers without a proc mask would otherwise proc off their own damage.
+				if spell == damageSpell {
+					return
+				}
+				damageSpell.Cast(sim, character.CurrentTarget)
+			}
+			MakeProcTriggerAura(&character.Unit, localTrigger)
+		}
+	}
+
+	panic(fmt.Sprintf("Unknown type of proc effect %s: %s", config.Name, config.Type))
+}
+
+func procCallbackFromProto(callback proto.ProcCallback) AuraCallback {
+	switch callback {
+	case proto.ProcCallback_ProcCallbackOnSpellHitDealt:
+		return CallbackOnSpellHitDealt
+	case proto.ProcCallback_ProcCallbackOnSpellHitTaken:
+		return CallbackOnSpellHitTaken
+	case proto.ProcCallback_ProcCallbackOnPeriodicDamageDealt:
+		return CallbackOnPeriodicDamageDealt
+	case proto.ProcCallback_ProcCallbackOnHealDealt:
+		return CallbackOnHealDealt
+	case proto.ProcCallback_ProcCallbackOnPeriodicHealDealt:
+		return CallbackOnPeriodicHealDealt
+	case proto.ProcCallback_ProcCallbackOnCastComplete:
+		return CallbackOnCastComplete
+	}
+	panic(fmt.Sprintf("Unknown proc callback: %s", callback))
+}
+
+func procMaskFromProto(procMask proto.ProcMask) ProcMask {
+	switch procMask {
+	case proto.ProcMask_ProcMaskAny:
+		return ProcMaskUnknown
+	case proto.ProcMask_ProcMaskMelee:
+		return ProcMaskMelee
+	case proto.ProcMask_ProcMaskMeleeMH:
+		return ProcMaskMeleeMH
+	case proto.ProcMask_ProcMaskMeleeOH:
+		return ProcMaskMeleeOH
+	case proto.ProcMask_ProcMaskMeleeWhiteHit:
+		return ProcMaskMeleeWhiteHit
+	case proto.ProcMask_ProcMaskMeleeSpecial:
+		return ProcMaskMeleeSpecial
+	case proto.ProcMask_ProcMaskRanged:
+		return ProcMaskRanged
+	case proto.ProcMask_ProcMaskMeleeOrRanged:
+		return ProcMaskMeleeOrRanged
+	case proto.ProcMask_ProcMaskSpellDamage:
+		return ProcMaskSpellDamage
+	case proto.ProcMask_ProcMaskSpellHealing:
+		return ProcMaskSpellHealing
+	case proto.ProcMask_ProcMaskDirect:
+		return ProcMaskDirect
+	case proto.ProcMask_ProcMaskProc:
+		return ProcMaskProc
+	}
+	panic(fmt.Sprintf("Unknown proc mask: %s", procMask))
+}
+
+func procOutcomeFromProto(outcome proto.ProcOutcome) HitOutcome {
+	switch outcome {
+	case proto.ProcOutcome_ProcOutcomeAny:
+		return OutcomeEmpty
+	case proto.ProcOutcome_ProcOutcomeLanded:
+		return OutcomeLanded
+	case proto.ProcOutcome_ProcOutcomeHit:
+		return OutcomeHit
+	case proto.ProcOutcome_ProcOutcomeCrit:
+		return OutcomeCrit
+	}
+	panic(fmt.Sprintf("Unknown proc outcome: %s", outcome))
+}
+
+func procDamageOutcome(spell *Spell, outcome proto.ProcDamageOutcome) OutcomeApplier {
+	switch outcome {
+	case proto.ProcDamageOutcome_ProcDamageSpellHit:
+		return spell.OutcomeMagicHit
+	case proto.ProcDamageOutcome_ProcDamageSpellCrit:
+		return spell.OutcomeMagicCrit
+	case proto.ProcDamageOutcome_ProcDamageMeleeHitAndCrit:
+		return spell.OutcomeMeleeSpecialHitAndCrit
+	case proto.ProcDamageOutcome_ProcDamageMeleeHit:
+		return spell.OutcomeMeleeSpecialHit
+	case proto.ProcDamageOutcome_ProcDamageMeleeNoBlockDodgeParryNoCrit:
+		return spell.OutcomeMeleeSpecialNoBlockDodgeParryNoCrit
+	default:
+		return spell.OutcomeMagicHitAndCrit
+	}
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func setupFakeSimWithProcEffect(procEffect *proto.ProcEffect) *Simulation {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{Items: []*proto.ItemSpec{{Id: 99001}}},
+			Database: &proto.SimDatabase{
+				Items:       []*proto.SimItem{{Id: 99001, Name: "Prototype Trinket", Type: proto.ItemType_ItemTypeTrinket}},
+				ProcEffects: []*proto.ProcEffect{procEffect},
+			},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 88}},
+			Duration: 10,
+		},
+	})
+	sim.Reset()
+	return sim
+}
+
+func TestRequestProcEffects(t *testing.T) {
+	procEffect := &proto.ProcEffect{
+		ItemId:          99001,
+		Name:            "Prototype Trinket",
+		SpellId:         99002,
+		Type:            proto.ProcEffectType_ProcEffectStackingStats,
+		Callbacks:       []proto.ProcCallback{proto.ProcCallback_ProcCallbackOnSpellHitDealt},
+		ProcMasks:       []proto.ProcMask{proto.ProcMask_ProcMaskSpellDamage},
+		Stats:           []*proto.StatBonus{{Stat: proto.Stat_StatSpellPower, Value: 100}},
+		DurationSeconds: 15,
+		MaxStacks:       5,
+	}
+
+	character := setupFakeSimWithProcEffect(procEffect).Raid.Parties[0].Players[0].GetCharacter()
+	if character.GetAura("Prototype Trinket") == nil {
+		t.Fatalf("Expected the proc trigger of the request's effect")
+	}
+	if aura := character.GetAura("Prototype Trinket Proc"); aura == nil || aura.MaxStacks != 5 || aura.ActionID.SpellID != 99002 {
+		t.Fatalf("Expected a stacking aura with 5 stacks for spell 99002, got %v", aura)
+	}
+	if HasItemEffect(99001) {
+		t.Fatalf("Expected the request's effect not to be registered for other requests")
+	}
+
+	// Later requests may redefine their effects.
+	procEffect.MaxStacks = 10
+	character = setupFakeSimWithProcEffect(procEffect).Raid.Parties[0].Players[0].GetCharacter()
+	if aura := character.GetAura("Prototype Trinket Proc"); aura == nil || aura.MaxStacks != 10 {
+		t.Fatalf("Expected the redefined effect to have 10 stacks, got %v", aura)
+	}
+}
+
+func TestRequestProcEffectStats(t *testing.T) {
+	sim := setupFakeSimWithProcEffect(&proto.ProcEffect{
+		ItemId:          99001,
+		Name:            "Prototype Trinket",
+		SpellId:         99002,
+		Type:            proto.ProcEffectType_ProcEffectStats,
+		Callbacks:       []proto.ProcCallback{proto.ProcCallback_ProcCallbackOnCastComplete},
+		ProcChance:      1,
+		IcdSeconds:      45,
+		Stats:           []*proto.StatBonus{{Stat: proto.Stat_StatSpellPower, Value: 100}},
+		DurationSeconds: 15,
+	})
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	spellPower := fa.GetStat(stats.SpellPower)
+
+	fa.Spell.Cast(sim, fa.CurrentTarget)
+	aura := fa.GetAura("Prototype Trinket Proc")
+	if !aura.IsActive() || aura.Duration != time.Second*15 || fa.GetStat(stats.SpellPower) != spellPower+100 {
+		t.Fatalf("Expected casting to give 100 spell power for 15s, got %0.0f", fa.GetStat(stats.SpellPower)-spellPower)
+	}
+	if icd := aura.Icd; icd == nil || icd.IsReady(sim) || icd.Duration != time.Second*45 {
+		t.Fatalf("Expected the proc to start its 45s ICD")
+	}
+}
+
+func TestRequestProcEffectDamage(t *testing.T) {
+	sim := setupFakeSimWithProcEffect(&proto.ProcEffect{
+		ItemId:          99001,
+		Name:            "Prototype Trinket",
+		SpellId:         99003,
+		Type:            proto.ProcEffectType_ProcEffectDamage,
+		Callbacks:       []proto.ProcCallback{proto.ProcCallback_ProcCallbackOnCastComplete},
+		ProcChance:      1,
+		School:          proto.SpellSchool_SpellSchoolFire,
+		MinDamage:       1000,
+		MaxDamage:       1000,
+		DamageOutcome:   proto.ProcDamageOutcome_ProcDamageSpellCrit,
+		IgnoreModifiers: true,
+	})
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	fa.Spell.Cast(sim, fa.CurrentTarget)
+	damageSpell := fa.GetSpell(ActionID{SpellID: 99003})
+	if damageSpell == nil {
+		t.Fatalf("Expected a damage spell for spell 99003")
+	}
+	metrics := damageSpell.SpellMetrics[fa.CurrentTarget.UnitIndex]
+	if metrics.Hits+metrics.Crits != 1 || metrics.TotalDamage <= 0 {
+		t.Fatalf("Expected casting to deal the proc's damage once, got %v", metrics)
+	}
+}
+
+func TestRequestProcEffectOnUse(t *testing.T) {
+	sim := setupFakeSimWithProcEffect(&proto.ProcEffect{
+		ItemId:          99001,
+		Name:            "Prototype Trinket",
+		Type:            proto.ProcEffectType_ProcEffectOnUseStats,
+		Stats:           []*proto.StatBonus{{Stat: proto.Stat_StatSpellPower, Value: 500}},
+		DurationSeconds: 20,
+		CooldownSeconds: 120,
+	})
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	spellPower := fa.GetStat(stats.SpellPower)
+
+	mcd := fa.GetMajorCooldown(ActionID{ItemID: 99001})
+	if mcd == nil {
+		t.Fatalf("Expected a major cooldown for the trinket")
+	}
+	spell := mcd.Spell
+	if spell.CD.Duration != time.Minute*2 || spell.SharedCD.Timer != fa.GetOffensiveTrinketCD() || spell.SharedCD.Duration != time.Second*20 {
+		t.Fatalf("Expected a 2 minute cooldown sharing the offensive trinket cooldown for 20s")
+	}
+
+	spell.Cast(sim, fa.CurrentTarget)
+	if fa.GetStat(stats.SpellPower) != spellPower+500 {
+		t.Fatalf("Expected using the trinket to give 500 spell power, got %0.0f", fa.GetStat(stats.SpellPower)-spellPower)
+	}
+}
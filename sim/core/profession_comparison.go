@@ -0,0 +1,85 @@
+package core
+
+import (
+	"sort"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Every pair of two different professions, in enum order.
+func allProfessionPairs() []*proto.ProfessionPair {
+	var pairs []*proto.ProfessionPair
+	for prof1 := proto.Profession_Alchemy; int(prof1) < len(proto.Profession_name); prof1++ {
+		for prof2 := prof1 + 1; int(prof2) < len(proto.Profession_name); prof2++ {
+			pairs = append(pairs, &proto.ProfessionPair{Profession1: prof1, Profession2: prof2})
+		}
+	}
+	return pairs
+}
+
+// Whether both pairs have the same professions, in either order.
+func sameProfessionPair(a *proto.ProfessionPair, b *proto.ProfessionPair) bool {
+	return (a.Profession1 == b.Profession1 && a.Profession2 == b.Profession2) ||
+		(a.Profession1 == b.Profession2 && a.Profession2 == b.Profession1)
+}
+
+// Sims the player with each of the requested profession pairs, with the gear
+// adjusted to each pair and common random numbers, and ranks them.
+func CalcProfessionComparison(request *proto.ProfessionComparisonRequest, progress chan *proto.ProgressMetrics) *proto.ProfessionComparisonResult {
+	variants := newPlayerVariants(request.GetBaseRequest())
+	if variants == nil {
+		return &proto.ProfessionComparisonResult{ErrorResult: "Profession comparison needs a player"}
+	}
+	basePlayer := variants.basePlayer()
+
+	currentProfessions := &proto.ProfessionPair{Profession1: basePlayer.Profession1, Profession2: basePlayer.Profession2}
+	candidates := request.Professions
+	if len(candidates) == 0 {
+		candidates = allProfessionPairs()
+	}
+
+	// The first entry is the current professions, which the deltas are measured against.
+	comparisonResult := &proto.ProfessionComparisonResult{
+		Entries: []*proto.ProfessionComparisonEntry{{
+			Professions: currentProfessions,
+			Equipment:   basePlayer.Equipment,
+		}},
+	}
+	for _, professions := range candidates {
+		alreadyCompared := false
+		for _, entry := range comparisonResult.Entries {
+			alreadyCompared = alreadyCompared || sameProfessionPair(entry.Professions, professions)
+		}
+		if alreadyCompared {
+			continue
+		}
+
+		var equipment *proto.EquipmentSpec
+		professionsArr := [2]proto.Profession{professions.Profession1, professions.Profession2}
+		if !variants.add(func(player *proto.Player) bool {
+			ApplyProfessions(player, professionsArr)
+			equipment = player.Equipment
+			return len(ValidateProfessionGear(equipment, professionsArr)) == 0
+		}) {
+			comparisonResult.Skipped = append(comparisonResult.Skipped, professions)
+			continue
+		}
+		comparisonResult.Entries = append(comparisonResult.Entries, &proto.ProfessionComparisonEntry{
+			Professions: professions,
+			Equipment:   equipment,
+		})
+	}
+
+	dps, dpsDeltas, errorStr := variants.run(progress)
+	if errorStr != "" {
+		return &proto.ProfessionComparisonResult{ErrorResult: errorStr}
+	}
+	for i, entry := range comparisonResult.Entries {
+		entry.Dps, entry.DpsDelta = dps[i], dpsDeltas[i]
+	}
+	sort.SliceStable(comparisonResult.Entries, func(i, j int) bool {
+		return comparisonResult.Entries[i].Dps.Avg > comparisonResult.Entries[j].Dps.Avg
+	})
+
+	return comparisonResult
+}
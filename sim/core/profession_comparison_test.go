@@ -0,0 +1,131 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestAllProfessionPairs(t *testing.T) {
+	pairs := allProfessionPairs()
+	if len(pairs) != 55 {
+		t.Fatalf("Expected 55 profession pairs, got %d", len(pairs))
+	}
+	for i, a := range pairs {
+		if a.Profession1 == proto.Profession_ProfessionUnknown || a.Profession1 == a.Profession2 {
+			t.Fatalf("Invalid profession pair %v", a)
+		}
+		for _, b := range pairs[i+1:] {
+			if sameProfessionPair(a, b) {
+				t.Fatalf("Profession pair %v is duplicated", a)
+			}
+		}
+	}
+}
+
+func TestSameProfessionPair(t *testing.T) {
+	bsJc := &proto.ProfessionPair{Profession1: proto.Profession_Blacksmithing, Profession2: proto.Profession_Jewelcrafting}
+	jcBs := &proto.ProfessionPair{Profession1: proto.Profession_Jewelcrafting, Profession2: proto.Profession_Blacksmithing}
+	bsEnch := &proto.ProfessionPair{Profession1: proto.Profession_Blacksmithing, Profession2: proto.Profession_Enchanting}
+
+	if !sameProfessionPair(bsJc, jcBs) {
+		t.Fatalf("Expected %v and %v to be the same pair", bsJc, jcBs)
+	}
+	if sameProfessionPair(bsJc, bsEnch) {
+		t.Fatalf("Expected %v and %v to be different pairs", bsJc, bsEnch)
+	}
+}
+
+const (
+	itemProfComparisonTailoredChest = 900131
+
+	enchantProfComparisonWrist = 900132
+	enchantProfComparisonRing  = 900133
+)
+
+func TestCalcProfessionComparison(t *testing.T) {
+	addProfessionRulesDatabase()
+	spellPower := func(amount float64) []float64 { return stats.Stats{stats.SpellPower: amount}.ToFloatArray() }
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: itemProfComparisonTailoredChest, Name: "Tailored Chest", Type: proto.ItemType_ItemTypeChest, RequiredProfession: proto.Profession_Tailoring},
+		},
+		Enchants: []*proto.SimEnchant{
+			{EffectId: enchantProfComparisonWrist, Type: proto.ItemType_ItemTypeWrist, Stats: spellPower(50)},
+			{EffectId: enchantProfComparisonRing, Type: proto.ItemType_ItemTypeFinger, Stats: spellPower(100), RequiredProfession: proto.Profession_Enchanting},
+		},
+	})
+
+	spellID := ActionID{SpellID: 42}.ToProto()
+	request := &proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{Iterations: 20, RandomSeed: 100},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+			Rotation: &proto.APLRotation{
+				Type: proto.APLRotation_TypeAPL,
+				PriorityList: []*proto.APLListItem{{Action: &proto.APLAction{
+					Condition: &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{
+						Val: &proto.APLValue{Value: &proto.APLValue_DotIsActive{DotIsActive: &proto.APLValueDotIsActive{SpellId: spellID}}},
+					}}},
+					Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: spellID}},
+				}}},
+			},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 88}},
+			Duration: 60,
+		},
+	}
+	player := request.Raid.Parties[0].Players[0]
+	player.Profession1 = proto.Profession_Mining
+	player.Profession2 = proto.Profession_Tailoring
+	player.Equipment = profRulesEquipment(
+		&proto.ItemSpec{Id: itemProfRulesWrist, Enchant: enchantProfComparisonWrist},
+		&proto.ItemSpec{Id: itemProfRulesRing},
+		&proto.ItemSpec{Id: itemProfComparisonTailoredChest},
+	)
+
+	enchantingTailoring := &proto.ProfessionPair{Profession1: proto.Profession_Enchanting, Profession2: proto.Profession_Tailoring}
+	miningSkinning := &proto.ProfessionPair{Profession1: proto.Profession_Mining, Profession2: proto.Profession_Skinning}
+	result := CalcProfessionComparison(&proto.ProfessionComparisonRequest{
+		BaseRequest: request,
+		Professions: []*proto.ProfessionPair{
+			enchantingTailoring,
+			// The current professions are always compared, once.
+			{Profession1: proto.Profession_Tailoring, Profession2: proto.Profession_Mining},
+			// Can't take off the Tailoring chest.
+			miningSkinning,
+		},
+	}, make(chan *proto.ProgressMetrics, 100))
+	if result.ErrorResult != "" {
+		t.Fatalf("Profession comparison failed: %s", result.ErrorResult)
+	}
+
+	if diff := cmp.Diff([]*proto.ProfessionPair{miningSkinning}, result.Skipped, protocmp.Transform()); diff != "" {
+		t.Fatalf("Unexpected skipped pairs (-want +got):\n%s", diff)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("Expected the Enchanting pair and the current pair, got %v", result.Entries)
+	}
+	best, current := result.Entries[0], result.Entries[1]
+	if !sameProfessionPair(best.Professions, enchantingTailoring) || current.Professions.Profession1 != proto.Profession_Mining {
+		t.Fatalf("Expected Enchanting/Tailoring to rank above the current professions, got %v then %v", best.Professions, current.Professions)
+	}
+	if ring := best.Equipment.Items[proto.ItemSlot_ItemSlotFinger1]; ring.Enchant != enchantProfComparisonRing {
+		t.Fatalf("Expected the Enchanting pair to enchant its ring, got %v", ring)
+	}
+	if current.DpsDelta.Avg != 0 || current.DpsDelta.Stdev != 0 {
+		t.Fatalf("Expected no delta for the current professions, got %v", current.DpsDelta)
+	}
+	if best.DpsDelta.Avg <= 0 || !WithinToleranceFloat64(best.Dps.Avg-current.Dps.Avg, best.DpsDelta.Avg, 0.0001) {
+		t.Fatalf("Expected a positive delta matching the difference in DPS, got %v vs %0.2f and %0.2f DPS", best.DpsDelta, best.Dps.Avg, current.Dps.Avg)
+	}
+}
@@ -0,0 +1,325 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+// Static stat bonuses of gathering professions.
+var professionStatBonuses = map[proto.Profession]stats.Stats{
+	proto.Profession_Mining:   {stats.Stamina: 60},
+	proto.Profession_Skinning: {stats.MeleeCrit: 40, stats.SpellCrit: 40},
+}
+
+// Extra primary stat alchemists get from Cataclysm flasks.
+const AlchemyFlaskBonus = 80
+
+// Maximum number of Jewelcrafting-only gems which can be equipped at once.
+const MaxJewelcraftingGems = 3
+
+// Slots where blacksmiths can add an extra prismatic socket.
+var blacksmithSocketSlots = []proto.ItemSlot{proto.ItemSlot_ItemSlotWrist, proto.ItemSlot_ItemSlotHands}
+
+// A profession-exclusive gear option used without the profession, or over
+// its limit.
+type ProfessionViolation struct {
+	Slot       proto.ItemSlot
+	Profession proto.Profession
+	// What requires the profession, e.g. "Enchant 4079".
+	Option    string
+	OverLimit bool
+}
+
+func (violation ProfessionViolation) String() string {
+	if violation.OverLimit {
+		return fmt.Sprintf("%s in %s is over the %s limit", violation.Option, violation.Slot, violation.Profession)
+	}
+	return fmt.Sprintf("%s in %s requires %s", violation.Option, violation.Slot, violation.Profession)
+}
+
+func hasProfession(professions [2]proto.Profession, prof proto.Profession) bool {
+	return prof == professions[0] || prof == professions[1]
+}
+
+func hasBlacksmithSocket(slot proto.ItemSlot, item Item, is *proto.ItemSpec) bool {
+	return slices.Contains(blacksmithSocketSlots, slot) && len(is.Gems) > len(item.GemSockets) && is.Gems[len(item.GemSockets)] != 0
+}
+
+// ValidateProfessionGear returns the items, enchants, gems and extra sockets of
+// the equipment which need a profession other than the given ones, as well as
+// Jewelcrafting gems over the limit.
+func ValidateProfessionGear(equipment *proto.EquipmentSpec, professions [2]proto.Profession) []ProfessionViolation {
+	var violations []ProfessionViolation
+	addIfMissing := func(slot proto.ItemSlot, prof proto.Profession, option string) {
+		if prof != proto.Profession_ProfessionUnknown && !hasProfession(professions, prof) {
+			violations = append(violations, ProfessionViolation{Slot: slot, Profession: prof, Option: option})
+		}
+	}
+
+	numJcGems := 0
+	for slotIdx, is := range equipment.GetItems() {
+		slot := proto.ItemSlot(slotIdx)
+		item, ok := ItemsByID[is.GetId()]
+		if !ok {
+			continue
+		}
+		addIfMissing(slot, item.RequiredProfession, item.Name)
+		if enchant, ok := EnchantsByEffectID[is.Enchant]; ok {
+			addIfMissing(slot, enchant.RequiredProfession, fmt.Sprintf("Enchant %d", enchant.EffectID))
+		}
+		for _, gemID := range is.Gems {
+			if gem, ok := GemsByID[gemID]; ok {
+				addIfMissing(slot, gem.RequiredProfession, gem.Name)
+				if gem.RequiredProfession == proto.Profession_Jewelcrafting {
+					numJcGems++
+					if numJcGems > MaxJewelcraftingGems {
+						violations = append(violations, ProfessionViolation{Slot: slot, Profession: gem.RequiredProfession, Option: gem.Name, OverLimit: true})
+					}
+				}
+			}
+		}
+		if hasBlacksmithSocket(slot, item, is) {
+			addIfMissing(slot, proto.Profession_Blacksmithing, "Extra socket")
+		}
+	}
+	return violations
+}
+
+// ApplyProfessionGear returns a copy of the equipment adjusted to the given
+// professions. Options needing other professions are swapped for the best
+// general ones, and the exclusive enchants, gems and sockets of these
+// professions are added where they beat what's there.
+//
+// Options are compared by the stats the equipment is already gemmed and
+// enchanted for. Enchants without stats can't be compared this way, so
+// they're only ever removed, never added. Engineering tinkers are consumes
+// in the sim, see ApplyProfessions.
+func ApplyProfessionGear(equipment *proto.EquipmentSpec, professions [2]proto.Profession) *proto.EquipmentSpec {
+	newEquipment := goproto.Clone(equipment).(*proto.EquipmentSpec)
+	weights := preferredGearStats(equipment)
+	score := func(s stats.Stats) float64 {
+		total := 0.0
+		for i := range s {
+			total += s[i] * weights[i]
+		}
+		return total
+	}
+
+	// Prefers the gems the player already chose, then any general gem.
+	gemPools := [][]Gem{generalGemPool(equipment), generalGems()}
+	bestGem := func(socketColor proto.GemColor) (Gem, bool) {
+		for _, pool := range gemPools {
+			var best Gem
+			found := false
+			for _, gem := range pool {
+				if gem.Color != proto.GemColor_GemColorMeta && ColorIntersects(gem.Color, socketColor) && (!found || score(gem.Stats) > score(best.Stats)) {
+					best, found = gem, true
+				}
+			}
+			if found {
+				return best, true
+			}
+		}
+		return Gem{}, false
+	}
+
+	// Remove options of other professions.
+	for slotIdx, is := range newEquipment.Items {
+		slot := proto.ItemSlot(slotIdx)
+		item, ok := ItemsByID[is.GetId()]
+		if !ok {
+			continue
+		}
+
+		if enchant, ok := EnchantsByEffectID[is.Enchant]; ok && !canUseProfessionOption(professions, enchant.RequiredProfession) {
+			is.Enchant = 0
+			if replacement, ok := bestProfessionEnchant(item.Type, proto.Profession_ProfessionUnknown, score); ok {
+				is.Enchant = replacement.EffectID
+			}
+		}
+
+		if hasBlacksmithSocket(slot, item, is) && !hasProfession(professions, proto.Profession_Blacksmithing) {
+			is.Gems = is.Gems[:len(item.GemSockets)]
+		}
+
+		for i, gemID := range is.Gems {
+			if gem, ok := GemsByID[gemID]; ok && !canUseProfessionOption(professions, gem.RequiredProfession) {
+				is.Gems[i] = 0
+				if replacement, ok := bestGem(gem.Color); ok {
+					is.Gems[i] = replacement.ID
+				}
+			}
+		}
+	}
+
+	// Add options of these professions.
+	for slotIdx, is := range newEquipment.Items {
+		slot := proto.ItemSlot(slotIdx)
+		item, ok := ItemsByID[is.GetId()]
+		if !ok {
+			continue
+		}
+
+		for _, prof := range professions {
+			if prof == proto.Profession_ProfessionUnknown {
+				continue
+			}
+			current := EnchantsByEffectID[is.Enchant]
+			if enchant, ok := bestProfessionEnchant(item.Type, prof, score); ok && score(enchant.Stats) > score(current.Stats) {
+				is.Enchant = enchant.EffectID
+			}
+		}
+
+		if hasProfession(professions, proto.Profession_Blacksmithing) && slices.Contains(blacksmithSocketSlots, slot) && !hasBlacksmithSocket(slot, item, is) {
+			if gem, ok := bestGem(proto.GemColor_GemColorPrismatic); ok {
+				gems := make([]int32, len(item.GemSockets)+1)
+				copy(gems, is.Gems)
+				gems[len(item.GemSockets)] = gem.ID
+				is.Gems = gems
+			}
+		}
+	}
+
+	if hasProfession(professions, proto.Profession_Jewelcrafting) {
+		applyJewelcraftingGems(newEquipment, score)
+	}
+	return newEquipment
+}
+
+// ApplyProfessions switches the player to the given professions, adjusting
+// its gear with ApplyProfessionGear. Players gaining Engineering without a
+// tinker get Synapse Springs, the tinker adding the most for every role.
+func ApplyProfessions(player *proto.Player, professions [2]proto.Profession) {
+	gainsEngineering := hasProfession(professions, proto.Profession_Engineering) &&
+		!hasProfession([2]proto.Profession{player.Profession1, player.Profession2}, proto.Profession_Engineering)
+
+	player.Profession1 = professions[0]
+	player.Profession2 = professions[1]
+	player.Equipment = ApplyProfessionGear(player.Equipment, professions)
+
+	if gainsEngineering && player.Consumes.GetTinkerHands() == proto.TinkerHands_TinkerHandsNone {
+		if player.Consumes == nil {
+			player.Consumes = &proto.Consumes{}
+		}
+		player.Consumes.TinkerHands = proto.TinkerHands_TinkerHandsSynapseSprings
+	}
+}
+
+func canUseProfessionOption(professions [2]proto.Profession, prof proto.Profession) bool {
+	return prof == proto.Profession_ProfessionUnknown || hasProfession(professions, prof)
+}
+
+// Stats the equipment is gemmed and enchanted for, used to weigh gear options
+// against each other. Falls back to the stats of the items themselves.
+func preferredGearStats(equipment *proto.EquipmentSpec) stats.Stats {
+	var gemsAndEnchants, items stats.Stats
+	for _, is := range equipment.Items {
+		if item, ok := ItemsByID[is.GetId()]; ok {
+			items = items.Add(item.Stats)
+		}
+		gemsAndEnchants = gemsAndEnchants.Add(EnchantsByEffectID[is.GetEnchant()].Stats)
+		for _, gemID := range is.GetGems() {
+			gemsAndEnchants = gemsAndEnchants.Add(GemsByID[gemID].Stats)
+		}
+	}
+	if gemsAndEnchants.Equals(stats.Stats{}) {
+		return items
+	}
+	return gemsAndEnchants
+}
+
+// Gems without a profession requirement already in the equipment, i.e. the
+// ones the player chose to gem for.
+func generalGemPool(equipment *proto.EquipmentSpec) []Gem {
+	seen := map[int32]bool{}
+	var pool []Gem
+	for _, is := range equipment.Items {
+		for _, gemID := range is.GetGems() {
+			if gem, ok := GemsByID[gemID]; ok && !seen[gemID] && gem.RequiredProfession == proto.Profession_ProfessionUnknown {
+				seen[gemID] = true
+				pool = append(pool, gem)
+			}
+		}
+	}
+	sort.Slice(pool, func(i, j int) bool { return pool[i].ID < pool[j].ID })
+	return pool
+}
+
+func generalGems() []Gem {
+	var gems []Gem
+	for _, gem := range GemsByID {
+		if gem.RequiredProfession == proto.Profession_ProfessionUnknown {
+			gems = append(gems, gem)
+		}
+	}
+	sort.Slice(gems, func(i, j int) bool { return gems[i].ID < gems[j].ID })
+	return gems
+}
+
+// Best scoring enchant with stats for the item type which requires the given
+// profession, or no profession if ProfessionUnknown.
+func bestProfessionEnchant(itemType proto.ItemType, prof proto.Profession, score func(stats.Stats) float64) (Enchant, bool) {
+	var best Enchant
+	found := false
+	for _, enchant := range EnchantsByEffectID {
+		if enchant.Type != itemType || enchant.RequiredProfession != prof || enchant.Stats.Equals(stats.Stats{}) {
+			continue
+		}
+		if !found || score(enchant.Stats) > score(best.Stats) ||
+			(score(enchant.Stats) == score(best.Stats) && enchant.EffectID < best.EffectID) {
+			best, found = enchant, true
+		}
+	}
+	return best, found && score(best.Stats) > 0
+}
+
+// Replaces the general gems which gain the most from it with Jewelcrafting
+// gems of the same color, up to the limit.
+func applyJewelcraftingGems(equipment *proto.EquipmentSpec, score func(stats.Stats) float64) {
+	var jcGems []Gem
+	for _, gem := range GemsByID {
+		if gem.RequiredProfession == proto.Profession_Jewelcrafting && gem.Color != proto.GemColor_GemColorMeta {
+			jcGems = append(jcGems, gem)
+		}
+	}
+	sort.Slice(jcGems, func(i, j int) bool { return jcGems[i].ID < jcGems[j].ID })
+
+	type upgrade struct {
+		slot, socket int
+		gem          Gem
+		gain         float64
+	}
+	var upgrades []upgrade
+	numJcGems := 0
+	for slot, is := range equipment.Items {
+		for socket, gemID := range is.GetGems() {
+			gem, ok := GemsByID[gemID]
+			if !ok || gem.Color == proto.GemColor_GemColorMeta {
+				continue
+			}
+			if gem.RequiredProfession == proto.Profession_Jewelcrafting {
+				numJcGems++
+				continue
+			}
+			best := upgrade{slot: slot, socket: socket}
+			for _, jcGem := range jcGems {
+				if gain := score(jcGem.Stats) - score(gem.Stats); ColorIntersects(jcGem.Color, gem.Color) && gain > best.gain {
+					best.gem, best.gain = jcGem, gain
+				}
+			}
+			if best.gain > 0 {
+				upgrades = append(upgrades, best)
+			}
+		}
+	}
+
+	sort.SliceStable(upgrades, func(i, j int) bool { return upgrades[i].gain > upgrades[j].gain })
+	for _, upgrade := range upgrades[:min(len(upgrades), max(0, MaxJewelcraftingGems-numJcGems))] {
+		equipment.Items[upgrade.slot].Gems[upgrade.socket] = upgrade.gem.ID
+	}
+}
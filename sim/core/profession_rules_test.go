@@ -0,0 +1,164 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+	goproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+const (
+	itemProfRulesWrist = 900101
+	itemProfRulesRing  = 900102
+	itemProfRulesChest = 900103
+
+	gemProfRulesBold    = 900111
+	gemProfRulesBoldJc  = 900112
+	gemProfRulesQuick   = 900113
+	gemProfRulesQuickJc = 900114
+
+	enchantProfRulesWrist   = 900121
+	enchantProfRulesWristLw = 900122
+	enchantProfRulesRing    = 900123
+)
+
+func addProfessionRulesDatabase() {
+	strength := func(amount float64) []float64 { return stats.Stats{stats.Strength: amount}.ToFloatArray() }
+	haste := func(amount float64) []float64 { return stats.Stats{stats.MeleeHaste: amount}.ToFloatArray() }
+
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: itemProfRulesWrist, Name: "Wrist", Type: proto.ItemType_ItemTypeWrist, GemSockets: []proto.GemColor{proto.GemColor_GemColorRed}},
+			{Id: itemProfRulesRing, Name: "Ring", Type: proto.ItemType_ItemTypeFinger},
+			{Id: itemProfRulesChest, Name: "Chest", Type: proto.ItemType_ItemTypeChest, GemSockets: []proto.GemColor{
+				proto.GemColor_GemColorRed, proto.GemColor_GemColorRed, proto.GemColor_GemColorYellow, proto.GemColor_GemColorRed,
+			}},
+		},
+		Gems: []*proto.SimGem{
+			{Id: gemProfRulesBold, Name: "Bold", Color: proto.GemColor_GemColorRed, Stats: strength(40)},
+			{Id: gemProfRulesBoldJc, Name: "Bold JC", Color: proto.GemColor_GemColorRed, Stats: strength(67), RequiredProfession: proto.Profession_Jewelcrafting},
+			{Id: gemProfRulesQuick, Name: "Quick", Color: proto.GemColor_GemColorYellow, Stats: haste(40)},
+			{Id: gemProfRulesQuickJc, Name: "Quick JC", Color: proto.GemColor_GemColorYellow, Stats: haste(67), RequiredProfession: proto.Profession_Jewelcrafting},
+		},
+		Enchants: []*proto.SimEnchant{
+			{EffectId: enchantProfRulesWrist, Type: proto.ItemType_ItemTypeWrist, Stats: strength(50)},
+			{EffectId: enchantProfRulesWristLw, Type: proto.ItemType_ItemTypeWrist, Stats: strength(130), RequiredProfession: proto.Profession_Leatherworking},
+			{EffectId: enchantProfRulesRing, Type: proto.ItemType_ItemTypeFinger, Stats: strength(40), RequiredProfession: proto.Profession_Enchanting},
+		},
+	})
+}
+
+func profRulesEquipment(wrist, ring1, chest *proto.ItemSpec) *proto.EquipmentSpec {
+	equipment := &proto.EquipmentSpec{Items: make([]*proto.ItemSpec, len(proto.ItemSlot_name))}
+	for i := range equipment.Items {
+		equipment.Items[i] = &proto.ItemSpec{}
+	}
+	equipment.Items[proto.ItemSlot_ItemSlotWrist] = wrist
+	equipment.Items[proto.ItemSlot_ItemSlotFinger1] = ring1
+	equipment.Items[proto.ItemSlot_ItemSlotChest] = chest
+	return equipment
+}
+
+func TestValidateProfessionGear(t *testing.T) {
+	addProfessionRulesDatabase()
+
+	equipment := profRulesEquipment(
+		&proto.ItemSpec{Id: itemProfRulesWrist, Enchant: enchantProfRulesWristLw, Gems: []int32{gemProfRulesBoldJc, gemProfRulesBold}},
+		&proto.ItemSpec{Id: itemProfRulesRing, Enchant: enchantProfRulesRing},
+		&proto.ItemSpec{Id: itemProfRulesChest, Gems: []int32{gemProfRulesBoldJc, gemProfRulesBoldJc, gemProfRulesQuickJc, gemProfRulesBold}},
+	)
+
+	got := MapSlice(ValidateProfessionGear(equipment, [2]proto.Profession{proto.Profession_Mining, proto.Profession_Jewelcrafting}), ProfessionViolation.String)
+	want := []string{
+		"Enchant 900122 in ItemSlotWrist requires Leatherworking",
+		"Bold JC in ItemSlotWrist is over the Jewelcrafting limit",
+		"Extra socket in ItemSlotWrist requires Blacksmithing",
+		"Enchant 900123 in ItemSlotFinger1 requires Enchanting",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("ValidateProfessionGear() returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyProfessionGear(t *testing.T) {
+	addProfessionRulesDatabase()
+
+	leatherworkerJewelcrafter := profRulesEquipment(
+		&proto.ItemSpec{Id: itemProfRulesWrist, Enchant: enchantProfRulesWristLw, Gems: []int32{gemProfRulesBold}},
+		&proto.ItemSpec{Id: itemProfRulesRing},
+		&proto.ItemSpec{Id: itemProfRulesChest, Gems: []int32{gemProfRulesBoldJc, gemProfRulesBoldJc, gemProfRulesQuickJc, gemProfRulesBold}},
+	)
+	original := goproto.Clone(leatherworkerJewelcrafter)
+
+	got := ApplyProfessionGear(leatherworkerJewelcrafter, [2]proto.Profession{proto.Profession_Blacksmithing, proto.Profession_Enchanting})
+	want := profRulesEquipment(
+		&proto.ItemSpec{Id: itemProfRulesWrist, Enchant: enchantProfRulesWrist, Gems: []int32{gemProfRulesBold, gemProfRulesBold}},
+		&proto.ItemSpec{Id: itemProfRulesRing, Enchant: enchantProfRulesRing},
+		&proto.ItemSpec{Id: itemProfRulesChest, Gems: []int32{gemProfRulesBold, gemProfRulesBold, gemProfRulesQuick, gemProfRulesBold}},
+	)
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Fatalf("ApplyProfessionGear() to Blacksmithing/Enchanting returned diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(original, leatherworkerJewelcrafter, protocmp.Transform()); diff != "" {
+		t.Fatalf("ApplyProfessionGear() modified its input (-want +got):\n%s", diff)
+	}
+
+	got = ApplyProfessionGear(got, [2]proto.Profession{proto.Profession_Jewelcrafting, proto.Profession_Leatherworking})
+	want = profRulesEquipment(
+		&proto.ItemSpec{Id: itemProfRulesWrist, Enchant: enchantProfRulesWristLw, Gems: []int32{gemProfRulesBold}},
+		&proto.ItemSpec{Id: itemProfRulesRing},
+		&proto.ItemSpec{Id: itemProfRulesChest, Gems: []int32{gemProfRulesBoldJc, gemProfRulesBoldJc, gemProfRulesQuick, gemProfRulesBoldJc}},
+	)
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Fatalf("ApplyProfessionGear() to Jewelcrafting/Leatherworking returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestProfessionGearWarnings(t *testing.T) {
+	addProfessionRulesDatabase()
+
+	raid := SinglePlayerRaidProto(&proto.Player{
+		Name:        "Caster",
+		Class:       proto.Class_ClassShaman,
+		Consumes:    &proto.Consumes{},
+		Buffs:       &proto.IndividualBuffs{},
+		Spec:        &proto.Player_ElementalShaman{},
+		Profession1: proto.Profession_Leatherworking,
+		Equipment: profRulesEquipment(
+			&proto.ItemSpec{Id: itemProfRulesWrist, Enchant: enchantProfRulesWristLw},
+			&proto.ItemSpec{Id: itemProfRulesRing, Enchant: enchantProfRulesRing},
+			&proto.ItemSpec{},
+		),
+	}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+
+	result := ComputeStats(&proto.ComputeStatsRequest{Raid: raid, Encounter: &proto.Encounter{Targets: []*proto.Target{{Name: "target", Level: 88}}}})
+	got := result.RaidStats.Parties[0].Players[0].Warnings
+	want := []string{"Enchant 900123 in ItemSlotFinger1 requires Enchanting"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Player stats returned warnings diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyProfessionsAddsTinker(t *testing.T) {
+	addProfessionRulesDatabase()
+	equipment := profRulesEquipment(&proto.ItemSpec{Id: itemProfRulesWrist}, &proto.ItemSpec{Id: itemProfRulesRing}, &proto.ItemSpec{})
+
+	player := &proto.Player{Profession1: proto.Profession_Mining, Equipment: equipment}
+	ApplyProfessions(player, [2]proto.Profession{proto.Profession_Mining, proto.Profession_Engineering})
+	if player.Profession2 != proto.Profession_Engineering || player.Consumes.GetTinkerHands() != proto.TinkerHands_TinkerHandsSynapseSprings {
+		t.Fatalf("Expected a player gaining Engineering to get Synapse Springs, got %v", player)
+	}
+
+	player = &proto.Player{
+		Profession1: proto.Profession_Mining,
+		Equipment:   equipment,
+		Consumes:    &proto.Consumes{TinkerHands: proto.TinkerHands_TinkerHandsQuickflipDeflectionPlates},
+	}
+	ApplyProfessions(player, [2]proto.Profession{proto.Profession_Engineering, proto.Profession_Mining})
+	if player.Consumes.TinkerHands != proto.TinkerHands_TinkerHandsQuickflipDeflectionPlates {
+		t.Fatalf("Expected the chosen tinker to be kept, got %v", player.Consumes.TinkerHands)
+	}
+}
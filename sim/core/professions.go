@@ -4,17 +4,14 @@ import (
 	"time"
 
 	"github.com/wowsims/cata/sim/core/proto"
-	"github.com/wowsims/cata/sim/core/stats"
 )
 
 // This is just the static bonuses. Most professions are handled elsewhere.
 func (character *Character) applyProfessionEffects() {
-	if character.HasProfession(proto.Profession_Mining) {
-		character.AddStat(stats.Stamina, 60)
-	}
-
-	if character.HasProfession(proto.Profession_Skinning) {
-		character.AddStats(stats.Stats{stats.MeleeCrit: 40, stats.SpellCrit: 40})
+	for prof, bonus := range professionStatBonuses {
+		if character.HasProfession(prof) {
+			character.AddStats(bonus)
+		}
 	}
 
 	if character.HasProfession(proto.Profession_Herbalism) {
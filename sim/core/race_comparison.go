@@ -0,0 +1,168 @@
+package core
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Returns the faction the equipment is restricted to by its items, if any.
+func equipmentFaction(equipment *proto.EquipmentSpec) proto.Faction {
+	for _, is := range equipment.GetItems() {
+		if item, ok := ItemsByID[is.GetId()]; ok && item.Faction != proto.Faction_Unknown {
+			return item.Faction
+		}
+	}
+	return proto.Faction_Unknown
+}
+
+// Whether a player of the class can be the race, and wear the equipment as it.
+func canBeRace(race proto.Race, class proto.Class, gearFaction proto.Faction) bool {
+	if _, ok := BaseStats[BaseStatsKey{Race: race, Class: class}]; !ok {
+		return false
+	}
+	return gearFaction == proto.Faction_Unknown || RaceFactions[race] == gearFaction
+}
+
+func findActionIDs(msg protoreflect.Message) []*proto.ActionID {
+	if actionID, ok := msg.Interface().(*proto.ActionID); ok {
+		return []*proto.ActionID{actionID}
+	}
+
+	var actionIDs []*proto.ActionID
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() == nil || fd.IsMap() || !msg.Has(fd) {
+			continue
+		}
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				actionIDs = append(actionIDs, findActionIDs(list.Get(j).Message())...)
+			}
+		} else {
+			actionIDs = append(actionIDs, findActionIDs(msg.Get(fd).Message())...)
+		}
+	}
+	return actionIDs
+}
+
+// Drops timings which come sooner after the previous one than the cooldown
+// allows.
+func spaceCooldownTimings(timings []float64, cooldown time.Duration) []float64 {
+	var spaced []float64
+	for _, timing := range timings {
+		if len(spaced) == 0 || timing >= spaced[len(spaced)-1]+cooldown.Seconds() {
+			spaced = append(spaced, timing)
+		}
+	}
+	return spaced
+}
+
+// Changes the player's race, moving the settings of the old race's racial
+// cooldown (timings, cooldown syncs and rotation actions) over to the new
+// race's, e.g. from Blood Fury to Berserking. Passive racials such as weapon
+// expertise are applied by the sim itself.
+//
+// Cooldown timings are dropped if the new racial isn't ready for them. If the
+// new race has no racial cooldown, the old timings and syncs with it are
+// removed, and rotation actions using it are left for the APL validation to
+// skip.
+func setPlayerRace(player *proto.Player, race proto.Race) {
+	oldRacial, hadRacial := getRacialCooldown(player.Race, player.Class)
+	newRacial, hasRacial := getRacialCooldown(race, player.Class)
+	player.Race = race
+	if !hadRacial || (hasRacial && oldRacial.ActionID.SameAction(newRacial.ActionID)) {
+		return
+	}
+
+	if !hasRacial {
+		if player.Cooldowns != nil {
+			var cooldowns []*proto.Cooldown
+			for _, cooldown := range player.Cooldowns.Cooldowns {
+				if ProtoToActionID(cooldown.Id).SameAction(oldRacial.ActionID) {
+					continue
+				}
+				if cooldown.SyncWith != nil && ProtoToActionID(cooldown.SyncWith).SameAction(oldRacial.ActionID) {
+					cooldown.SyncWith = nil
+				}
+				cooldowns = append(cooldowns, cooldown)
+			}
+			player.Cooldowns.Cooldowns = cooldowns
+		}
+		return
+	}
+
+	for _, msg := range []googleProto.Message{player.Cooldowns, player.Rotation} {
+		if msg == nil {
+			continue
+		}
+		for _, actionID := range findActionIDs(msg.ProtoReflect()) {
+			if ProtoToActionID(actionID).SameAction(oldRacial.ActionID) {
+				actionID.RawId = &proto.ActionID_SpellId{SpellId: newRacial.ActionID.SpellID}
+			}
+		}
+	}
+	for _, cooldown := range player.GetCooldowns().GetCooldowns() {
+		if ProtoToActionID(cooldown.Id).SameAction(newRacial.ActionID) {
+			cooldown.Timings = spaceCooldownTimings(cooldown.Timings, newRacial.Duration)
+		}
+	}
+}
+
+// Sims the player as each of the requested races, with common random numbers,
+// and ranks them.
+func CalcRaceComparison(request *proto.RaceComparisonRequest, progress chan *proto.ProgressMetrics) *proto.RaceComparisonResult {
+	variants := newPlayerVariants(request.GetBaseRequest())
+	if variants == nil {
+		return &proto.RaceComparisonResult{ErrorResult: "Race comparison needs a player"}
+	}
+	basePlayer := variants.basePlayer()
+
+	gearFaction := equipmentFaction(basePlayer.Equipment)
+	candidates := request.Races
+	if len(candidates) == 0 {
+		for race := proto.Race_RaceBloodElf; int(race) < len(proto.Race_name); race++ {
+			if canBeRace(race, basePlayer.Class, gearFaction) {
+				candidates = append(candidates, race)
+			}
+		}
+	}
+
+	// The first entry is the current race, which the deltas are measured against.
+	comparisonResult := &proto.RaceComparisonResult{
+		Entries: []*proto.RaceComparisonEntry{{Race: basePlayer.Race}},
+	}
+	for _, race := range candidates {
+		if race == basePlayer.Race {
+			continue
+		}
+		if !canBeRace(race, basePlayer.Class, gearFaction) {
+			comparisonResult.Skipped = append(comparisonResult.Skipped, race)
+			continue
+		}
+
+		variants.add(func(player *proto.Player) bool {
+			setPlayerRace(player, race)
+			return true
+		})
+		comparisonResult.Entries = append(comparisonResult.Entries, &proto.RaceComparisonEntry{Race: race})
+	}
+
+	dps, dpsDeltas, errorStr := variants.run(progress)
+	if errorStr != "" {
+		return &proto.RaceComparisonResult{ErrorResult: errorStr}
+	}
+	for i, entry := range comparisonResult.Entries {
+		entry.Dps, entry.DpsDelta = dps[i], dpsDeltas[i]
+	}
+	sort.SliceStable(comparisonResult.Entries, func(i, j int) bool {
+		return comparisonResult.Entries[i].Dps.Avg > comparisonResult.Entries[j].Dps.Avg
+	})
+
+	return comparisonResult
+}
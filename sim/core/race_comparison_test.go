@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestCanBeRace(t *testing.T) {
+	if !canBeRace(proto.Race_RaceTroll, proto.Class_ClassWarrior, proto.Faction_Unknown) {
+		t.Fatalf("Expected Troll Warriors to be allowed")
+	}
+	if canBeRace(proto.Race_RaceTroll, proto.Class_ClassWarrior, proto.Faction_Alliance) {
+		t.Fatalf("Expected Troll Warriors to be disallowed with Alliance gear")
+	}
+	if canBeRace(proto.Race_RaceTauren, proto.Class_ClassRogue, proto.Faction_Unknown) {
+		t.Fatalf("Expected Tauren Rogues to be disallowed")
+	}
+}
+
+func TestSetPlayerRace(t *testing.T) {
+	bloodFury := ActionID{SpellID: 33697}
+	berserking := ActionID{SpellID: 26297}
+	newPlayer := func() *proto.Player {
+		return &proto.Player{
+			Race:  proto.Race_RaceOrc,
+			Class: proto.Class_ClassWarrior,
+			Cooldowns: &proto.Cooldowns{Cooldowns: []*proto.Cooldown{
+				{Id: bloodFury.ToProto(), Timings: []float64{0, 120, 240}},
+				{Id: ActionID{SpellID: 12292}.ToProto(), SyncWith: bloodFury.ToProto()},
+			}},
+			Rotation: &proto.APLRotation{PriorityList: []*proto.APLListItem{
+				{Action: &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: bloodFury.ToProto()}}}},
+			}},
+		}
+	}
+
+	troll := newPlayer()
+	setPlayerRace(troll, proto.Race_RaceTroll)
+	wantTroll := newPlayer()
+	wantTroll.Race = proto.Race_RaceTroll
+	wantTroll.Cooldowns.Cooldowns[0] = &proto.Cooldown{Id: berserking.ToProto(), Timings: []float64{0, 240}}
+	wantTroll.Cooldowns.Cooldowns[1].SyncWith = berserking.ToProto()
+	wantTroll.Rotation.PriorityList[0].Action.GetCastSpell().SpellId = berserking.ToProto()
+	if diff := cmp.Diff(wantTroll, troll, protocmp.Transform()); diff != "" {
+		t.Fatalf("setPlayerRace() to Troll returned diff (-want +got):\n%s", diff)
+	}
+
+	human := newPlayer()
+	setPlayerRace(human, proto.Race_RaceHuman)
+	wantHuman := newPlayer()
+	wantHuman.Race = proto.Race_RaceHuman
+	wantHuman.Cooldowns.Cooldowns = wantHuman.Cooldowns.Cooldowns[1:]
+	wantHuman.Cooldowns.Cooldowns[0].SyncWith = nil
+	if diff := cmp.Diff(wantHuman, human, protocmp.Transform()); diff != "" {
+		t.Fatalf("setPlayerRace() to Human returned diff (-want +got):\n%s", diff)
+	}
+}
@@ -7,6 +7,53 @@ import (
 	"github.com/wowsims/cata/sim/core/stats"
 )
 
+var RaceFactions = map[proto.Race]proto.Faction{
+	proto.Race_RaceDraenei:  proto.Faction_Alliance,
+	proto.Race_RaceDwarf:    proto.Faction_Alliance,
+	proto.Race_RaceGnome:    proto.Faction_Alliance,
+	proto.Race_RaceHuman:    proto.Faction_Alliance,
+	proto.Race_RaceNightElf: proto.Faction_Alliance,
+	proto.Race_RaceWorgen:   proto.Faction_Alliance,
+
+	proto.Race_RaceBloodElf: proto.Faction_Horde,
+	proto.Race_RaceGoblin:   proto.Faction_Horde,
+	proto.Race_RaceOrc:      proto.Faction_Horde,
+	proto.Race_RaceTauren:   proto.Faction_Horde,
+	proto.Race_RaceTroll:    proto.Faction_Horde,
+	proto.Race_RaceUndead:   proto.Faction_Horde,
+}
+
+// A racial ability registered as a major cooldown, which players can time in
+// their cooldown settings and rotation.
+type racialCooldown struct {
+	ActionID ActionID
+	Duration time.Duration
+}
+
+// Returns the racial major cooldown of the race, if it has one for the class.
+func getRacialCooldown(race proto.Race, class proto.Class) (racialCooldown, bool) {
+	switch race {
+	case proto.Race_RaceBloodElf:
+		switch class {
+		case proto.Class_ClassDeathKnight:
+			return racialCooldown{ActionID{SpellID: 50613}, time.Minute * 2}, true
+		case proto.Class_ClassRogue:
+			return racialCooldown{ActionID{SpellID: 25046}, time.Minute * 2}, true
+		case proto.Class_ClassWarrior, proto.Class_ClassHunter:
+			return racialCooldown{}, false
+		default:
+			return racialCooldown{ActionID{SpellID: 28730}, time.Minute * 2}, true
+		}
+	case proto.Race_RaceDwarf:
+		return racialCooldown{ActionID{SpellID: 20594}, time.Minute * 2}, true
+	case proto.Race_RaceOrc:
+		return racialCooldown{ActionID{SpellID: 33697}, time.Minute * 2}, true
+	case proto.Race_RaceTroll:
+		return racialCooldown{ActionID{SpellID: 26297}, time.Minute * 3}, true
+	}
+	return racialCooldown{}, false
+}
+
 func applyRaceEffects(agent Agent) {
 	character := agent.GetCharacter()
 
@@ -18,20 +65,20 @@ func applyRaceEffects(agent Agent) {
 		character.PseudoStats.ReducedNatureHitTakenChance += 0.02
 		character.PseudoStats.ReducedShadowHitTakenChance += 0.02
 
-		var actionID ActionID
-
-		var resourceMetrics *ResourceMetrics = nil
-		if resourceMetrics == nil {
-			if character.HasRunicPowerBar() {
-				actionID = ActionID{SpellID: 50613}
-				resourceMetrics = character.NewRunicPowerMetrics(actionID)
-			} else if character.HasEnergyBar() {
-				actionID = ActionID{SpellID: 25046}
-				resourceMetrics = character.NewEnergyMetrics(actionID)
-			} else if character.HasManaBar() {
-				actionID = ActionID{SpellID: 28730}
-				resourceMetrics = character.NewManaMetrics(actionID)
-			}
+		racialCD, ok := getRacialCooldown(character.Race, character.Class)
+		if !ok {
+			break
+		}
+		actionID := racialCD.ActionID
+
+		var resourceMetrics *ResourceMetrics
+		switch actionID.SpellID {
+		case 50613:
+			resourceMetrics = character.NewRunicPowerMetrics(actionID)
+		case 25046:
+			resourceMetrics = character.NewEnergyMetrics(actionID)
+		default:
+			resourceMetrics = character.NewManaMetrics(actionID)
 		}
 
 		spell := character.RegisterSpell(SpellConfig{
@@ -40,16 +87,19 @@ func applyRaceEffects(agent Agent) {
 			Cast: CastConfig{
 				CD: Cooldown{
 					Timer:    character.NewTimer(),
-					Duration: time.Minute * 2,
+					Duration: racialCD.Duration,
 				},
 			},
 			ApplyEffects: func(sim *Simulation, _ *Unit, spell *Spell) {
-				if spell.Unit.HasRunicPowerBar() {
+				switch actionID.SpellID {
+				case 50613:
 					spell.Unit.AddRunicPower(sim, 15.0, resourceMetrics)
-				} else if spell.Unit.HasEnergyBar() {
+				case 25046:
 					spell.Unit.AddEnergy(sim, 15.0, resourceMetrics)
-				} else if spell.Unit.HasManaBar() {
-					spell.Unit.AddMana(sim, spell.Unit.MaxMana()*0.06, resourceMetrics)
+				default:
+					if spell.Unit.HasManaBar() {
+						spell.Unit.AddMana(sim, spell.Unit.MaxMana()*0.06, resourceMetrics)
+					}
 				}
 			},
 		})
@@ -59,9 +109,10 @@ func applyRaceEffects(agent Agent) {
 			Type:     CooldownTypeDPS,
 			Priority: CooldownPriorityLow,
 			ShouldActivate: func(sim *Simulation, character *Character) bool {
-				if spell.Unit.HasRunicPowerBar() {
+				switch actionID.SpellID {
+				case 50613:
 					return character.CurrentRunicPower() <= character.maxRunicPower-15
-				} else if spell.Unit.HasEnergyBar() {
+				case 25046:
 					return character.CurrentEnergy() <= character.maxEnergy-15
 				}
 				return true
@@ -133,7 +184,8 @@ func applyRaceEffects(agent Agent) {
 		}
 
 		// Blood Fury
-		actionID := ActionID{SpellID: 33697}
+		bloodFury, _ := getRacialCooldown(character.Race, character.Class)
+		actionID := bloodFury.ActionID
 		apBonus := 0.0
 		spBonus := 0.0
 
@@ -154,7 +206,7 @@ func applyRaceEffects(agent Agent) {
 			Cast: CastConfig{
 				CD: Cooldown{
 					Timer:    character.NewTimer(),
-					Duration: time.Minute * 2,
+					Duration: bloodFury.Duration,
 				},
 			},
 			ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
@@ -185,7 +237,8 @@ func applyRaceEffects(agent Agent) {
 		}
 
 		// Berserking
-		actionID := ActionID{SpellID: 26297}
+		berserking, _ := getRacialCooldown(character.Race, character.Class)
+		actionID := berserking.ActionID
 
 		berserkingAura := character.RegisterAura(Aura{
 			Label:    "Berserking (Troll)",
@@ -209,7 +262,7 @@ func applyRaceEffects(agent Agent) {
 			Cast: CastConfig{
 				CD: Cooldown{
 					Timer:    character.NewTimer(),
-					Duration: time.Minute * 3,
+					Duration: berserking.Duration,
 				},
 			},
 
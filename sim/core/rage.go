@@ -147,7 +147,7 @@ func (rb *rageBar) AddRage(sim *Simulation, amount float64, metrics *ResourceMet
 	}
 
 	rb.currentRage = newRage
-	if !sim.Options.Interactive {
+	if !sim.IsInteractive(rb.unit) {
 		rb.unit.ReactToEvent(sim)
 	}
 }
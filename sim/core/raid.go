@@ -124,6 +124,8 @@ type Raid struct {
 
 	nextPetIndex int32
 
+	simulateRaidCooldowns bool
+
 	replenishmentUnits         []*Unit   // All units who can receive replenishment.
 	curReplenishmentUnits      [][]*Unit // Units that currently have replenishment active, separated by source.
 	leftoverReplenishmentUnits []*Unit   // Units without replenishment currently active.
@@ -160,6 +162,8 @@ func NewRaid(raidConfig *proto.Raid) *Raid {
 		dpsMetrics:   NewDistributionMetrics(),
 		hpsMetrics:   NewDistributionMetrics(),
 		nextPetIndex: int32(numParties) * 5,
+
+		simulateRaidCooldowns: raidConfig.SimulateRaidCooldowns,
 	}
 
 	for partyIndex, partyConfig := range raidConfig.Parties {
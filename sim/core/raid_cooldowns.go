@@ -0,0 +1,141 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+// Whether raid utility cooldowns of this character apply to the rest of the
+// raid. If not, they only affect the caster and the raid gets them through
+// RaidBuffs/IndividualBuffs instead.
+func (character *Character) CastsRaidCooldowns() bool {
+	return character.Env.Raid.simulateRaidCooldowns
+}
+
+// Which players a raid cooldown reaches.
+type RaidCooldownScope byte
+
+const (
+	RaidCooldownScopeRaid RaidCooldownScope = iota
+	// For cooldowns that only reach players standing close together, like the
+	// small dome of Power Word: Barrier. The caster's party stands in for them.
+	RaidCooldownScopeParty
+)
+
+// Returns the players a raid cooldown of this character applies to.
+func (character *Character) RaidCooldownTargets(scope RaidCooldownScope) []*Unit {
+	if !character.CastsRaidCooldowns() {
+		return []*Unit{&character.Unit}
+	}
+	if scope == RaidCooldownScopeParty {
+		return MapSlice(character.Party.Players, func(player Agent) *Unit { return &player.GetCharacter().Unit })
+	}
+	return character.Env.Raid.AllPlayerUnits
+}
+
+// Creates the aura of a raid cooldown on every player it can apply to.
+func (character *Character) NewRaidCooldownAuras(scope RaidCooldownScope, makeAura func(*Unit) *Aura) AuraArray {
+	auras := make([]*Aura, len(character.Env.AllUnits))
+	for _, unit := range character.RaidCooldownTargets(scope) {
+		auras[unit.UnitIndex] = makeAura(unit)
+	}
+	return auras
+}
+
+// Returns up to n of the units with the lowest value, for raid cooldowns that
+// pick the players most in need, like Divine Hymn.
+func LowestUnitsBy(units []*Unit, n int, value func(*Unit) float64) []*Unit {
+	sorted := slices.Clone(units)
+	slices.SortStableFunc(sorted, func(a, b *Unit) int {
+		return cmp.Compare(value(a), value(b))
+	})
+	return sorted[:min(n, len(sorted))]
+}
+
+const HymnOfHopeDuration = time.Second * 8
+
+func HymnOfHopeAura(unit *Unit, actionTag int32) *Aura {
+	actionID := ActionID{SpellID: 64901, Tag: actionTag}
+	dep := unit.NewDynamicMultiplyStat(stats.Mana, 1.15)
+	return unit.GetOrRegisterAura(Aura{
+		Label:    "Hymn of Hope-" + actionID.String(),
+		ActionID: actionID,
+		Duration: HymnOfHopeDuration,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			aura.Unit.EnableDynamicStatDep(sim, dep)
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			aura.Unit.DisableDynamicStatDep(sim, dep)
+		},
+	})
+}
+
+func RallyingCryAura(unit *Unit, actionTag int32) *Aura {
+	actionID := ActionID{SpellID: 97462, Tag: actionTag}
+	healthMetrics := unit.NewHealthMetrics(actionID)
+
+	var bonusHealth float64
+	return unit.GetOrRegisterAura(Aura{
+		Label:    "Rallying Cry-" + actionID.String(),
+		ActionID: actionID,
+		Duration: time.Second * 10,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			bonusHealth = aura.Unit.MaxHealth() * 0.2
+			aura.Unit.UpdateMaxHealth(sim, bonusHealth, healthMetrics)
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			aura.Unit.UpdateMaxHealth(sim, -bonusHealth, healthMetrics)
+		},
+	})
+}
+
+func PowerWordBarrierAura(unit *Unit, actionTag int32) *Aura {
+	actionID := ActionID{SpellID: 62618, Tag: actionTag}
+	return unit.GetOrRegisterAura(Aura{
+		Label:    "Power Word: Barrier-" + actionID.String(),
+		ActionID: actionID,
+		Duration: time.Second * 10,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier *= 0.75
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier /= 0.75
+		},
+	})
+}
+
+// Aura Mastery doubles the caster's aura. Only Devotion Aura matters for the
+// sim, so this grants a second Devotion Aura's worth of armor.
+func AuraMasteryAura(unit *Unit, actionTag int32) *Aura {
+	actionID := ActionID{SpellID: 31821, Tag: actionTag}
+	bonusStats := stats.Stats{stats.Armor: DevotionAuraArmor}
+	return unit.GetOrRegisterAura(Aura{
+		Label:    "Aura Mastery-" + actionID.String(),
+		ActionID: actionID,
+		Duration: time.Second * 6,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			aura.Unit.AddStatsDynamic(sim, bonusStats)
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			aura.Unit.AddStatsDynamic(sim, bonusStats.Invert())
+		},
+	})
+}
+
+func DivineHymnAura(unit *Unit, actionTag int32) *Aura {
+	actionID := ActionID{SpellID: 64843, Tag: actionTag}
+	return unit.GetOrRegisterAura(Aura{
+		Label:    "Divine Hymn-" + actionID.String(),
+		ActionID: actionID,
+		Duration: time.Second * 8,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.HealingTakenMultiplier *= 1.1
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.HealingTakenMultiplier /= 1.1
+		},
+	})
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Every iteration is fully determined by its seed: the first iteration uses the
+// sim's seed, and iteration i reseeds with RandomSeed+i (see reseedRands). So
+// any iteration can be replayed as the only iteration of a sim with its seed.
+// This doesn't hold for test sims (IsTest), whose label rands first used after
+// the first iteration are seeded from the sim's seed rather than the iteration's.
+func replaySeed(request *proto.RaidSimRequest, seed int64, index int32) *proto.ReplayedIteration {
+	simRequest := googleProto.Clone(request).(*proto.RaidSimRequest)
+	simRequest.SimOptions.Iterations = 1
+	simRequest.SimOptions.RandomSeed = seed
+	simRequest.SimOptions.Debug = true
+	simRequest.SimOptions.SaveAllValues = false
+
+	return &proto.ReplayedIteration{
+		Index:  index,
+		Seed:   seed,
+		Result: runSim(simRequest, nil, false),
+	}
+}
+
+// Reruns single iterations of a sim with full debug logs, either a specific
+// one or the worst and best ones of the whole sim.
+func CalcReplayIteration(request *proto.ReplayIterationRequest, progress chan *proto.ProgressMetrics) *proto.ReplayIterationResult {
+	if request.Request == nil || request.Request.SimOptions == nil {
+		return &proto.ReplayIterationResult{ErrorResult: "Replay needs a sim request"}
+	}
+	simOptions := request.Request.SimOptions
+	if simOptions.IsTest {
+		return &proto.ReplayIterationResult{ErrorResult: "Cannot replay iterations of test sims"}
+	}
+
+	var iterations []*proto.ReplayedIteration
+	switch iteration := request.Iteration.(type) {
+	case *proto.ReplayIterationRequest_Seed:
+		if iteration.Seed == 0 {
+			return &proto.ReplayIterationResult{ErrorResult: "Cannot replay seed 0"}
+		}
+		index := int32(-1)
+		if simOptions.RandomSeed != 0 && iteration.Seed >= simOptions.RandomSeed && iteration.Seed-simOptions.RandomSeed < int64(simOptions.Iterations) {
+			index = int32(iteration.Seed - simOptions.RandomSeed)
+		}
+		iterations = append(iterations, replaySeed(request.Request, iteration.Seed, index))
+
+	case *proto.ReplayIterationRequest_Index:
+		if simOptions.RandomSeed == 0 {
+			return &proto.ReplayIterationResult{ErrorResult: "Replaying an iteration by index needs a fixed random seed"}
+		}
+		if iteration.Index < 0 || iteration.Index >= simOptions.Iterations {
+			return &proto.ReplayIterationResult{ErrorResult: "Iteration index out of range"}
+		}
+		iterations = append(iterations, replaySeed(request.Request, simOptions.RandomSeed+int64(iteration.Index), iteration.Index))
+
+	case *proto.ReplayIterationRequest_NumExtremes:
+		numExtremes := min(int(iteration.NumExtremes), int(simOptions.Iterations)/2)
+		if numExtremes <= 0 {
+			return &proto.ReplayIterationResult{ErrorResult: "Not enough iterations to replay"}
+		}
+
+		simRequest := googleProto.Clone(request.Request).(*proto.RaidSimRequest)
+		simRequest.SimOptions.SaveAllValues = true
+		simRequest.SimOptions.Debug = false
+		simRequest.SimOptions.DebugFirstIteration = false
+		if simRequest.SimOptions.RandomSeed == 0 {
+			simRequest.SimOptions.RandomSeed = time.Now().UnixNano()
+		}
+
+		results, errorStr := runSimBatch([]*proto.RaidSimRequest{simRequest}, progress)
+		if errorStr != "" {
+			return &proto.ReplayIterationResult{ErrorResult: errorStr}
+		}
+		dpsValues := results[0].RaidMetrics.Dps.AllValues
+
+		indices := make([]int, len(dpsValues))
+		for i := range indices {
+			indices[i] = i
+		}
+		sort.SliceStable(indices, func(i, j int) bool {
+			return dpsValues[indices[i]] < dpsValues[indices[j]]
+		})
+
+		var selected []int
+		selected = append(selected, indices[:numExtremes]...)
+		for i := len(indices) - 1; i >= len(indices)-numExtremes; i-- {
+			selected = append(selected, indices[i])
+		}
+		for _, idx := range selected {
+			replayed := replaySeed(simRequest, simRequest.SimOptions.RandomSeed+int64(idx), int32(idx))
+			replayed.ExpectedDps = dpsValues[idx]
+			iterations = append(iterations, replayed)
+		}
+
+	default:
+		return &proto.ReplayIterationResult{ErrorResult: "Replay needs a seed, index or number of extremes"}
+	}
+
+	for _, replayed := range iterations {
+		if replayed.Result.ErrorResult != "" {
+			return &proto.ReplayIterationResult{ErrorResult: replayed.Result.ErrorResult}
+		}
+	}
+	return &proto.ReplayIterationResult{Iterations: iterations}
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestReplayIterationMatchesSim(t *testing.T) {
+	spellID := ActionID{SpellID: 42}.ToProto()
+	request := &proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{Iterations: 5, RandomSeed: 100, SaveAllValues: true},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+			Rotation: &proto.APLRotation{
+				Type: proto.APLRotation_TypeAPL,
+				PriorityList: []*proto.APLListItem{{Action: &proto.APLAction{
+					Condition: &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{
+						Val: &proto.APLValue{Value: &proto.APLValue_DotIsActive{DotIsActive: &proto.APLValueDotIsActive{SpellId: spellID}}},
+					}}},
+					Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: spellID}},
+				}}},
+			},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets:           []*proto.Target{{Name: "target", Level: 88}},
+			Duration:          60,
+			DurationVariation: 10,
+		},
+	}
+	dpsValues := RunRaidSim(request).RaidMetrics.Dps.AllValues
+
+	for i, dps := range dpsValues {
+		result := CalcReplayIteration(&proto.ReplayIterationRequest{
+			Request:   request,
+			Iteration: &proto.ReplayIterationRequest_Index{Index: int32(i)},
+		}, nil)
+		if result.ErrorResult != "" {
+			t.Fatalf("Replay failed: %s", result.ErrorResult)
+		}
+		if replayed := result.Iterations[0].Result.RaidMetrics.Dps.Avg; replayed != dps {
+			t.Fatalf("Expected replayed iteration %d to match the sim's %0.4f DPS, got %0.4f", i, dps, replayed)
+		}
+	}
+}
@@ -7,6 +7,22 @@ type ShieldConfig struct {
 
 	Spell *Spell
 
+	// Schools of damage the shield absorbs, e.g. SpellSchoolMagic for Anti-Magic
+	// Shell. Defaults to all damage.
+	School SpellSchool
+
+	// Shields with higher priority absorb damage first. Shields with the same
+	// priority absorb in the order they were applied.
+	Priority float64
+
+	// If set, reapplying the shield adds to the remaining absorb instead of
+	// replacing it, up to this amount.
+	MaxAbsorb float64
+
+	// Fraction of each hit the shield absorbs, e.g. 0.75 for Anti-Magic Shell.
+	// Defaults to the whole hit.
+	AbsorbFraction float64
+
 	Aura
 }
 
@@ -16,30 +32,57 @@ type Shield struct {
 
 	// Embed Aura so we can use IsActive/Refresh/etc directly.
 	*Aura
+
+	School         SpellSchool
+	Priority       float64
+	MaxAbsorb      float64
+	AbsorbFraction float64
+
+	// Damage this shield can still absorb.
+	Remaining float64
 }
 
 func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
 	caster := shield.Spell.Unit
 	target := shield.Aura.Unit
-	//attackTable := caster.AttackTables[target.UnitIndex]
 
 	// Shields are not affected by healing pseudostats the same way heals are.
 	// So we only apply the spell-specific multiplier.
 	shieldAmount *= shield.Spell.DamageMultiplier
 
+	remaining := shieldAmount
+	if shield.MaxAbsorb > 0 {
+		if shield.IsActive() {
+			remaining += shield.Remaining
+		}
+		remaining = min(remaining, shield.MaxAbsorb)
+		if shield.IsActive() {
+			shieldAmount = remaining - shield.Remaining
+		} else {
+			shieldAmount = remaining
+		}
+	}
+
 	shield.Aura.Deactivate(sim)
 	shield.Aura.Activate(sim)
+	shield.Remaining = remaining
 
-	threat := 0.0 // TODO
+	// Shields generate threat like heals do, based on the amount applied.
+	threat := shield.Spell.ThreatFromDamage(OutcomeHit, shieldAmount)
 	shield.Spell.SpellMetrics[target.UnitIndex].TotalThreat += threat
 	shield.Spell.SpellMetrics[target.UnitIndex].TotalShielding += shieldAmount
 	shield.Spell.SpellMetrics[target.UnitIndex].Hits++
 
 	if sim.Log != nil {
-		caster.Log(sim, "%s %s Hit for %0.3f shielding. (Threat: %0.3f)", target.LogLabel(), shield.Spell.ActionID, shieldAmount, threat)
+		caster.Log(sim, "%s %s Hit for %0.3f shielding, %0.3f remaining. (Threat: %0.3f)", target.LogLabel(), shield.Spell.ActionID, shieldAmount, remaining, threat)
 	}
 }
 
+// Whether this shield can absorb damage from the given spell.
+func (shield *Shield) absorbs(spell *Spell) bool {
+	return shield.School == SpellSchoolNone || spell.SpellSchool.Matches(shield.School)
+}
+
 func newShield(config Shield) *Shield {
 	shield := &Shield{}
 	*shield = config
@@ -62,7 +105,11 @@ func (spell *Spell) createShields(config ShieldConfig) {
 		config.Spell = spell
 	}
 	shield := Shield{
-		Spell: config.Spell,
+		Spell:          config.Spell,
+		School:         config.School,
+		Priority:       config.Priority,
+		MaxAbsorb:      config.MaxAbsorb,
+		AbsorbFraction: config.AbsorbFraction,
 	}
 
 	auraConfig := config.Aura
@@ -72,8 +119,8 @@ func (spell *Spell) createShields(config ShieldConfig) {
 
 	caster := shield.Spell.Unit
 	if config.SelfOnly {
-		shield.Aura = caster.GetOrRegisterAura(auraConfig)
 		spell.selfShield = newShield(shield)
+		spell.selfShield.Aura = caster.GetOrRegisterAura(spell.selfShield.wrapAuraConfig(auraConfig))
 	} else {
 		auraConfig.Label += "-" + strconv.Itoa(int(caster.UnitIndex))
 		if spell.shields == nil {
@@ -81,9 +128,87 @@ func (spell *Spell) createShields(config ShieldConfig) {
 		}
 		for _, target := range caster.Env.AllUnits {
 			if !caster.IsOpponent(target) {
-				shield.Aura = target.GetOrRegisterAura(auraConfig)
-				spell.shields[target.UnitIndex] = newShield(shield)
+				targetShield := newShield(shield)
+				targetShield.Aura = target.GetOrRegisterAura(targetShield.wrapAuraConfig(auraConfig))
+				spell.shields[target.UnitIndex] = targetShield
 			}
 		}
 	}
 }
+
+// Hooks the shield into its unit's absorb list while the aura is active.
+func (shield *Shield) wrapAuraConfig(config Aura) Aura {
+	oldOnGain := config.OnGain
+	oldOnExpire := config.OnExpire
+
+	config.OnGain = func(aura *Aura, sim *Simulation) {
+		aura.Unit.addActiveShield(shield)
+		if oldOnGain != nil {
+			oldOnGain(aura, sim)
+		}
+	}
+	config.OnExpire = func(aura *Aura, sim *Simulation) {
+		aura.Unit.removeActiveShield(shield)
+		shield.Remaining = 0
+		if oldOnExpire != nil {
+			oldOnExpire(aura, sim)
+		}
+	}
+	return config
+}
+
+func (unit *Unit) addActiveShield(shield *Shield) {
+	idx := len(unit.activeShields)
+	for i, activeShield := range unit.activeShields {
+		if shield.Priority > activeShield.Priority {
+			idx = i
+			break
+		}
+	}
+	unit.activeShields = append(unit.activeShields, nil)
+	copy(unit.activeShields[idx+1:], unit.activeShields[idx:])
+	unit.activeShields[idx] = shield
+}
+
+func (unit *Unit) removeActiveShield(shield *Shield) {
+	for i, activeShield := range unit.activeShields {
+		if activeShield == shield {
+			unit.activeShields = append(unit.activeShields[:i], unit.activeShields[i+1:]...)
+			return
+		}
+	}
+}
+
+// Soaks up as much of a hit on this unit as its active shields allow, reducing
+// result.Damage accordingly. Shields that are used up are removed, while those
+// activated without an amount to absorb are left alone.
+func (unit *Unit) absorbDamage(sim *Simulation, spell *Spell, result *SpellResult) {
+	for i := 0; i < len(unit.activeShields) && result.Damage > 0; {
+		shield := unit.activeShields[i]
+		if !shield.absorbs(spell) || shield.Remaining <= 0 {
+			i++
+			continue
+		}
+
+		absorbable := result.Damage
+		if shield.AbsorbFraction > 0 {
+			absorbable *= shield.AbsorbFraction
+		}
+		absorbed := min(absorbable, shield.Remaining)
+		shield.Remaining -= absorbed
+		result.Damage -= absorbed
+		result.Absorbed += absorbed
+		shield.Spell.SpellMetrics[unit.UnitIndex].TotalAbsorbed += absorbed
+
+		if sim.Log != nil {
+			unit.Log(sim, "%s absorbed %0.3f damage from %s, %0.3f remaining.", shield.Spell.ActionID, absorbed, spell.ActionID, shield.Remaining)
+		}
+
+		if shield.Remaining <= 0 {
+			// Removes the shield from activeShields.
+			shield.Deactivate(sim)
+		} else {
+			i++
+		}
+	}
+}
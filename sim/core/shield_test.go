@@ -0,0 +1,132 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestShieldAbsorbOrder(t *testing.T) {
+	sim := &Simulation{}
+
+	unit := &Unit{
+		Type:        PlayerUnit,
+		auraTracker: newAuraTracker(),
+	}
+	newTestShield := func(label string, school SpellSchool, priority float64) *Shield {
+		shield := &Shield{
+			Spell:    &Spell{Unit: unit, DamageMultiplier: 1, SpellMetrics: make([]SpellMetrics, 1)},
+			School:   school,
+			Priority: priority,
+		}
+		shield.Aura = unit.GetOrRegisterAura(shield.wrapAuraConfig(Aura{Label: label, Duration: NeverExpires}))
+		return shield
+	}
+	magic := newTestShield("Magic", SpellSchoolMagic, 0)
+	low := newTestShield("Low", SpellSchoolNone, 0)
+	high := newTestShield("High", SpellSchoolNone, 1)
+
+	magic.Apply(sim, 100)
+	low.Apply(sim, 100)
+	high.Apply(sim, 50)
+
+	// Physical damage skips the magic shield, and is soaked by the higher priority shield first.
+	result := &SpellResult{Damage: 120}
+	unit.absorbDamage(sim, &Spell{SpellSchool: SpellSchoolPhysical}, result)
+	if result.Damage != 0 || result.Absorbed != 120 {
+		t.Fatalf("expected 120 absorbed, got %0.1f absorbed and %0.1f left", result.Absorbed, result.Damage)
+	}
+	if high.IsActive() || low.Remaining != 30 || magic.Remaining != 100 {
+		t.Fatalf("unexpected shields after physical hit: high active %t, low %0.1f, magic %0.1f", high.IsActive(), low.Remaining, magic.Remaining)
+	}
+
+	// Magic damage goes through the remaining shields in the order they were applied.
+	result = &SpellResult{Damage: 150}
+	unit.absorbDamage(sim, &Spell{SpellSchool: SpellSchoolFire}, result)
+	if result.Damage != 20 || result.Absorbed != 130 {
+		t.Fatalf("expected 130 absorbed, got %0.1f absorbed and %0.1f left", result.Absorbed, result.Damage)
+	}
+	if magic.IsActive() || low.IsActive() || len(unit.activeShields) != 0 {
+		t.Fatalf("expected all shields to be used up")
+	}
+	if magic.Spell.SpellMetrics[0].TotalAbsorbed != 100 {
+		t.Fatalf("expected 100 absorbed by the magic shield, got %0.1f", magic.Spell.SpellMetrics[0].TotalAbsorbed)
+	}
+}
+
+func TestShieldMaxAbsorb(t *testing.T) {
+	sim := &Simulation{}
+
+	unit := &Unit{
+		Type:        PlayerUnit,
+		auraTracker: newAuraTracker(),
+	}
+	shield := &Shield{
+		Spell:     &Spell{Unit: unit, DamageMultiplier: 1, SpellMetrics: make([]SpellMetrics, 1)},
+		MaxAbsorb: 150,
+	}
+	shield.Aura = unit.GetOrRegisterAura(shield.wrapAuraConfig(Aura{Label: "Stacking", Duration: NeverExpires}))
+
+	shield.Apply(sim, 100)
+	shield.Apply(sim, 100)
+	if shield.Remaining != 150 {
+		t.Fatalf("expected stacking shield to be capped at 150, got %0.1f", shield.Remaining)
+	}
+	if shielding := shield.Spell.SpellMetrics[0].TotalShielding; shielding != 150 {
+		t.Fatalf("expected 150 total shielding, got %0.1f", shielding)
+	}
+}
+
+func TestShieldDamageTaken(t *testing.T) {
+	sim := SetupFakeSim()
+	player := sim.Raid.AllPlayerUnits[0]
+	target := sim.Encounter.TargetUnits[0]
+
+	shieldSpell := player.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: 1001},
+		SpellSchool: SpellSchoolHoly,
+		ProcMask:    ProcMaskSpellHealing,
+		Flags:       SpellFlagHelpful,
+		Shield: ShieldConfig{
+			SelfOnly:       true,
+			AbsorbFraction: 0.75,
+			Aura:           Aura{Label: "Fake Shield", Duration: NeverExpires},
+		},
+		DamageMultiplier: 1,
+	})
+	hitSpell := target.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{SpellID: 1002},
+		SpellSchool:      SpellSchoolPhysical,
+		ProcMask:         ProcMaskSpellDamage,
+		Flags:            SpellFlagNoOnDamageDealt,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			spell.CalcAndDealDamage(sim, target, 1000, spell.OutcomeAlwaysHit)
+		},
+	})
+
+	// A shield activated without an amount to absorb soaks up nothing and stays up.
+	shield := shieldSpell.SelfShield()
+	shield.Activate(sim)
+	hitSpell.Cast(sim, player)
+	if !shield.IsActive() || hitSpell.SpellMetrics[player.UnitIndex].TotalDamage != 1000 {
+		t.Fatalf("Expected an empty shield to stay up and absorb nothing")
+	}
+
+	// Only part of each hit is absorbed, and absorbed damage is not taken and
+	// generates no threat.
+	shield.Apply(sim, 1000)
+	hitSpell.Cast(sim, player)
+	metrics := hitSpell.SpellMetrics[player.UnitIndex]
+	if metrics.TotalDamage != 1250 || metrics.TotalThreat != 1250 {
+		t.Fatalf("Expected 250 damage and threat from a 75%% absorbed hit, got %0.1f damage and %0.1f threat", metrics.TotalDamage-1000, metrics.TotalThreat-1000)
+	}
+	if shield.Remaining != 250 || shieldSpell.SpellMetrics[player.UnitIndex].TotalAbsorbed != 750 {
+		t.Fatalf("Expected 750 absorbed and 250 left, got %0.1f left", shield.Remaining)
+	}
+
+	shield.Deactivate(sim)
+	sim.Cleanup()
+	if dtps := player.Metrics.ToProto().Dtps.Avg; !WithinToleranceFloat64(1250/sim.Duration.Seconds(), dtps, 0.0001) {
+		t.Fatalf("Expected DTPS of the unabsorbed damage only, got %0.3f", dtps)
+	}
+}
@@ -37,6 +37,8 @@ type Simulation struct {
 	Duration       time.Duration // Duration of current iteration
 	NeedsInput     bool          // Sim is in interactive mode and needs input
 
+	interactiveUnit *Unit // The only unit waiting for input in interactive mode, if set.
+
 	ProgressReport func(*proto.ProgressMetrics)
 
 	Log func(string, ...interface{})
@@ -49,7 +51,6 @@ type Simulation struct {
 	nextExecuteDamage   float64
 
 	endOfCombatDuration time.Duration
-	endOfCombatDamage   float64
 
 	minTrackerTime time.Duration
 	trackers       []*auraTracker
@@ -167,7 +168,7 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 			runtime.Gosched() // allow time for message to make it back out.
 		}
 		// Use pre-sim as estimate for length of fight (when using health fight)
-		if sim.Encounter.EndFightAtHealth > 0 && presimResult != nil {
+		if sim.Encounter.UseHealth && presimResult != nil {
 			sim.BaseDuration = time.Duration(presimResult.AvgIterationDuration) * time.Second
 			sim.Duration = time.Duration(presimResult.AvgIterationDuration) * time.Second
 			sim.Encounter.DurationIsEstimate = false // we now have a pretty good value for duration
@@ -246,6 +247,11 @@ func (sim *Simulation) RandomExpFloat(label string) float64 {
 	return rand.New(sim.labelRand(label)).ExpFloat64()
 }
 
+// Returns a normally distributed float64 with mean 0 and standard deviation 1.
+func (sim *Simulation) RandomNormFloat(label string) float64 {
+	return rand.New(sim.labelRand(label)).NormFloat64()
+}
+
 // Shorthand for commonly-used RNG behavior.
 // Returns a random number between min and max.
 func (sim *Simulation) Roll(min float64, max float64) float64 {
@@ -294,7 +300,7 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 
 	sim.runOnce()
 	firstIterationDuration := sim.Duration
-	if sim.Encounter.EndFightAtHealth != 0 {
+	if sim.Encounter.UseHealth {
 		firstIterationDuration = sim.CurrentTime
 	}
 	totalDuration := firstIterationDuration
@@ -318,7 +324,7 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 
 		sim.runOnce()
 		iterDuration := sim.Duration
-		if sim.Encounter.EndFightAtHealth != 0 {
+		if sim.Encounter.UseHealth {
 			iterDuration = sim.CurrentTime
 		}
 		totalDuration += iterDuration
@@ -383,10 +389,8 @@ func (sim *Simulation) reset() {
 
 	// Use duration as an end check if not using health.
 	sim.endOfCombatDuration = sim.Duration
-	sim.endOfCombatDamage = math.MaxFloat64
-	if sim.Encounter.EndFightAtHealth > 0 {
+	if sim.Encounter.UseHealth {
 		sim.endOfCombatDuration = NeverExpires
-		sim.endOfCombatDamage = sim.Encounter.EndFightAtHealth
 	}
 
 	sim.CurrentTime = 0
@@ -436,6 +440,10 @@ func (sim *Simulation) Cleanup() {
 	// quite at the Duration. Explicitly set this so that accesses to CurrentTime
 	// during the doneIteration phase will return the Duration value, which is
 	// intuitive.
+	if sim.Encounter.UseHealth && sim.CurrentTime > 0 {
+		// Health fights last until the required targets die.
+		sim.Duration = sim.CurrentTime
+	}
 	sim.CurrentTime = sim.Duration
 
 	for _, pa := range sim.pendingActions {
@@ -468,7 +476,7 @@ func (sim *Simulation) Step() bool {
 	pa := sim.pendingActions[last]
 
 	if pa.NextActionAt >= sim.minWeaponAttackTime && sim.minWeaponAttackTime <= sim.minTaskTime {
-		if sim.minWeaponAttackTime > sim.endOfCombatDuration || sim.Encounter.DamageTaken > sim.endOfCombatDamage {
+		if sim.minWeaponAttackTime > sim.endOfCombatDuration || sim.Encounter.requiredTargetsDead() {
 			return true
 		}
 		sim.advanceWeaponAttacks()
@@ -476,7 +484,7 @@ func (sim *Simulation) Step() bool {
 	}
 
 	if pa.NextActionAt >= sim.minTaskTime {
-		if sim.minTaskTime > sim.endOfCombatDuration || sim.Encounter.DamageTaken > sim.endOfCombatDamage {
+		if sim.minTaskTime > sim.endOfCombatDuration || sim.Encounter.requiredTargetsDead() {
 			return true
 		}
 		sim.advanceTasks()
@@ -488,7 +496,7 @@ func (sim *Simulation) Step() bool {
 		return false
 	}
 
-	if pa.NextActionAt > sim.endOfCombatDuration || sim.Encounter.DamageTaken > sim.endOfCombatDamage {
+	if pa.NextActionAt > sim.endOfCombatDuration || sim.Encounter.requiredTargetsDead() {
 		return true
 	}
 
@@ -556,8 +564,8 @@ func (sim *Simulation) setupReverseExecute(phase int32, activeProportion float64
 func (sim *Simulation) nextExecutePhase() {
 	setup := func(phase int32, damage float64, health float64) {
 		sim.executePhase = phase
-		if sim.Encounter.EndFightAtHealth > 0 {
-			sim.nextExecuteDamage = (1 - damage) * sim.Encounter.EndFightAtHealth
+		if sim.Encounter.UseHealth {
+			sim.nextExecuteDamage = (1 - damage) * sim.Encounter.RequiredHealth
 		} else {
 			sim.nextExecuteDuration = time.Duration((1 - health) * float64(sim.Duration))
 		}
@@ -623,15 +631,39 @@ func (sim *Simulation) IsExecutePhase90() bool {
 	return sim.executePhase > 90
 }
 
+// Like IsExecutePhase20 and friends, but for a single target. In health fights
+// this follows the target's own health.
+func (sim *Simulation) IsTargetExecutePhase20(target *Unit) bool {
+	return sim.ExecutePhaseOf(target) <= 20
+}
+func (sim *Simulation) IsTargetExecutePhase25(target *Unit) bool {
+	return sim.ExecutePhaseOf(target) <= 25
+}
+func (sim *Simulation) IsTargetExecutePhase35(target *Unit) bool {
+	return sim.ExecutePhaseOf(target) <= 35
+}
+func (sim *Simulation) IsTargetExecutePhase90(target *Unit) bool {
+	return sim.ExecutePhaseOf(target) > 90
+}
+
+// Returns the execute phase for the given target. In health fights this
+// follows the target's own health rather than that of the whole encounter.
+func (sim *Simulation) ExecutePhaseOf(target *Unit) int32 {
+	if target != nil && target.Type == EnemyUnit && target.HasHealthBar() {
+		return sim.Encounter.Targets[target.Index].executePhase
+	}
+	return sim.executePhase
+}
+
 func (sim *Simulation) GetRemainingDuration() time.Duration {
-	if sim.Encounter.EndFightAtHealth > 0 {
+	if sim.Encounter.UseHealth {
 		if !sim.Encounter.DurationIsEstimate || sim.CurrentTime < time.Second*5 {
 			return sim.Duration - sim.CurrentTime
 		}
 
 		// Estimate time remaining via avg dps
 		dps := sim.Encounter.DamageTaken / sim.CurrentTime.Seconds()
-		dur := time.Duration((sim.Encounter.EndFightAtHealth-sim.Encounter.DamageTaken)/dps) * time.Second
+		dur := time.Duration((sim.Encounter.RequiredHealth-sim.Encounter.DamageTaken)/dps) * time.Second
 		return dur
 	}
 	return sim.Duration - sim.CurrentTime
@@ -639,8 +671,8 @@ func (sim *Simulation) GetRemainingDuration() time.Duration {
 
 // Returns the percentage of time remaining in the current iteration, as a value from 0-1.
 func (sim *Simulation) GetRemainingDurationPercent() float64 {
-	if sim.Encounter.EndFightAtHealth > 0 {
-		return 1.0 - sim.Encounter.DamageTaken/sim.Encounter.EndFightAtHealth
+	if sim.Encounter.UseHealth {
+		return 1.0 - sim.Encounter.DamageTaken/sim.Encounter.RequiredHealth
 	}
 	return float64(sim.Duration-sim.CurrentTime) / float64(sim.Duration)
 }
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Returns a copy of simOptions set up for comparing several sims against each
+// other using common random numbers, i.e. iteration i of every sim sees the same
+// random rolls. This is the same trick used by the stat weights calculation:
+// differences between the sims are much more precise than between independent
+// sims, so far fewer iterations are needed to see them.
+func commonRandomNumbersOptions(simOptions *proto.SimOptions) *proto.SimOptions {
+	options := googleProto.Clone(simOptions).(*proto.SimOptions)
+	options.SaveAllValues = true
+
+	// Make sure an RNG seed is always set because it gives more consistent results.
+	// When there is no user-supplied seed it needs to be a randomly-selected seed
+	// though, so that run-run differences still exist.
+	if options.RandomSeed == 0 {
+		options.RandomSeed = time.Now().UnixNano()
+	}
+
+	// Reduce variance even more by using test-level RNG controls.
+	options.IsTest = true
+
+	return options
+}
+
+// Runs all the given sims concurrently, and returns their results in the same
+// order. Progress for the whole batch is reported on progress, if it is non-nil.
+// Returns the first error encountered, if any.
+func runSimBatch(requests []*proto.RaidSimRequest, progress chan *proto.ProgressMetrics) ([]*proto.RaidSimResult, string) {
+	results := make([]*proto.RaidSimResult, len(requests))
+
+	var iterationsTotal int32
+	var iterationsDone int32
+	var simsCompleted int32
+	for _, request := range requests {
+		iterationsTotal += request.SimOptions.Iterations
+	}
+	simsTotal := int32(len(requests))
+
+	concurrency := (runtime.NumCPU() - 1) * 2
+	if concurrency <= 0 {
+		concurrency = 2
+	}
+
+	tickets := make(chan struct{}, concurrency)
+	for i := 0; i < concurrency; i++ {
+		tickets <- struct{}{}
+	}
+
+	var waitGroup sync.WaitGroup
+	for i, request := range requests {
+		waitGroup.Add(1)
+		go func(idx int, simRequest *proto.RaidSimRequest) {
+			defer waitGroup.Done()
+			// wait until we have CPU time available.
+			<-tickets
+			defer func() { tickets <- struct{}{} }()
+
+			reporter := make(chan *proto.ProgressMetrics, 10)
+			go RunSim(simRequest, reporter)
+
+			var localIterations int32
+			for metrics := range reporter {
+				atomic.AddInt32(&iterationsDone, metrics.CompletedIterations-localIterations)
+				localIterations = metrics.CompletedIterations
+				if metrics.FinalRaidResult != nil {
+					atomic.AddInt32(&simsCompleted, 1)
+				}
+				if progress != nil {
+					progress <- &proto.ProgressMetrics{
+						TotalIterations:     iterationsTotal,
+						CompletedIterations: atomic.LoadInt32(&iterationsDone),
+						CompletedSims:       atomic.LoadInt32(&simsCompleted),
+						TotalSims:           simsTotal,
+					}
+				}
+				if metrics.FinalRaidResult != nil {
+					results[idx] = metrics.FinalRaidResult
+					break
+				}
+			}
+		}(i, request)
+	}
+	waitGroup.Wait()
+
+	for _, result := range results {
+		if result == nil {
+			return nil, "sim did not return a result"
+		}
+		if result.ErrorResult != "" {
+			return nil, result.ErrorResult
+		}
+	}
+	return results, ""
+}
+
+// Sims variants of the first player of a request, e.g. as other races, against
+// the unmodified player, with common random numbers.
+type playerVariants struct {
+	baseRequest *proto.RaidSimRequest
+	requests    []*proto.RaidSimRequest
+}
+
+// Returns nil if the request has no player.
+func newPlayerVariants(request *proto.RaidSimRequest) *playerVariants {
+	parties := request.GetRaid().GetParties()
+	if len(parties) == 0 || len(parties[0].Players) == 0 {
+		return nil
+	}
+
+	baseRequest := googleProto.Clone(request).(*proto.RaidSimRequest)
+	baseRequest.SimOptions = commonRandomNumbersOptions(baseRequest.SimOptions)
+	if database := baseRequest.Raid.Parties[0].Players[0].Database; database != nil {
+		addToDatabase(database)
+	}
+	return &playerVariants{
+		baseRequest: baseRequest,
+		requests:    []*proto.RaidSimRequest{baseRequest},
+	}
+}
+
+func (variants *playerVariants) basePlayer() *proto.Player {
+	return variants.baseRequest.Raid.Parties[0].Players[0]
+}
+
+// Adds a variant, made by apply from a copy of the player, unless apply
+// returns false because the variant can't be simmed.
+func (variants *playerVariants) add(apply func(player *proto.Player) bool) bool {
+	request := googleProto.Clone(variants.baseRequest).(*proto.RaidSimRequest)
+	if !apply(request.Raid.Parties[0].Players[0]) {
+		return false
+	}
+	variants.requests = append(variants.requests, request)
+	return true
+}
+
+// Sims the unmodified player and the variants, in the order they were added,
+// and returns the DPS of each with its difference from the unmodified player's.
+func (variants *playerVariants) run(progress chan *proto.ProgressMetrics) ([]*proto.MetricEstimate, []*proto.MetricEstimate, string) {
+	results, errorStr := runSimBatch(variants.requests, progress)
+	if errorStr != "" {
+		return nil, nil, errorStr
+	}
+
+	baseline := results[0].RaidMetrics.Parties[0].Players[0].Dps.AllValues
+	dps := make([]*proto.MetricEstimate, len(results))
+	dpsDeltas := make([]*proto.MetricEstimate, len(results))
+	for i, result := range results {
+		values := result.RaidMetrics.Parties[0].Players[0].Dps.AllValues
+		dps[i] = newMetricEstimate(values)
+		dpsDeltas[i] = newPairedMetricEstimate(baseline, values)
+	}
+	return dps, dpsDeltas, ""
+}
+
+// Summarizes a metric from its per-iteration values.
+func newMetricEstimate(values []float64) *proto.MetricEstimate {
+	var agg aggregator
+	for _, v := range values {
+		agg.add(v)
+	}
+	return agg.toMetricEstimate()
+}
+
+// Summarizes the per-iteration difference between two sims that were run with
+// common random numbers.
+func newPairedMetricEstimate(baseline []float64, values []float64) *proto.MetricEstimate {
+	var agg aggregator
+	for i := 0; i < min(len(baseline), len(values)); i++ {
+		agg.add(values[i] - baseline[i])
+	}
+	return agg.toMetricEstimate()
+}
+
+func (x *aggregator) toMetricEstimate() *proto.MetricEstimate {
+	if x.n == 0 {
+		return &proto.MetricEstimate{}
+	}
+	mean, stdev := x.meanAndStdDev()
+	if math.IsNaN(stdev) {
+		// Rounding errors can produce a tiny negative variance.
+		stdev = 0
+	}
+	return &proto.MetricEstimate{
+		Avg:                mean,
+		Stdev:              stdev,
+		ConfidenceInterval: 1.96 * stdev / math.Sqrt(float64(x.n)),
+	}
+}
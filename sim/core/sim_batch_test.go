@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func TestRunSimBatchCommonRandomNumbers(t *testing.T) {
+	spellID := ActionID{SpellID: 42}.ToProto()
+	request := &proto.RaidSimRequest{
+		SimOptions: commonRandomNumbersOptions(&proto.SimOptions{Iterations: 50, RandomSeed: 100}),
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+			Rotation: &proto.APLRotation{
+				Type: proto.APLRotation_TypeAPL,
+				PriorityList: []*proto.APLListItem{{Action: &proto.APLAction{
+					Condition: &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{
+						Val: &proto.APLValue{Value: &proto.APLValue_DotIsActive{DotIsActive: &proto.APLValueDotIsActive{SpellId: spellID}}},
+					}}},
+					Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: spellID}},
+				}}},
+			},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		// Fights of random length, so the DPS varies between iterations.
+		Encounter: &proto.Encounter{
+			Targets:           []*proto.Target{{Name: "target", Level: 88}},
+			Duration:          60,
+			DurationVariation: 10,
+		},
+	}
+	buffedRequest := googleProto.Clone(request).(*proto.RaidSimRequest)
+	bonusStats := stats.Stats{stats.SpellPower: 500}
+	buffedRequest.Raid.Parties[0].Players[0].BonusStats = &proto.UnitStats{Stats: bonusStats.ToFloatArray()}
+
+	progress := make(chan *proto.ProgressMetrics, 1000)
+	results, errorStr := runSimBatch([]*proto.RaidSimRequest{request, googleProto.Clone(request).(*proto.RaidSimRequest), buffedRequest}, progress)
+	if errorStr != "" {
+		t.Fatalf("Batch failed: %s", errorStr)
+	}
+	close(progress)
+	var last *proto.ProgressMetrics
+	for metrics := range progress {
+		last = metrics
+	}
+	if last == nil || last.CompletedSims != 3 || last.TotalSims != 3 || last.CompletedIterations != 150 {
+		t.Fatalf("Expected progress for all 3 sims and 150 iterations, got %v", last)
+	}
+
+	dpsValues := func(result *proto.RaidSimResult) []float64 {
+		return result.RaidMetrics.Parties[0].Players[0].Dps.AllValues
+	}
+	baseline := dpsValues(results[0])
+	if diff := cmp.Diff(baseline, dpsValues(results[1])); diff != "" {
+		t.Fatalf("Expected identical sims to see the same random numbers each iteration (-want +got):\n%s", diff)
+	}
+
+	if newMetricEstimate(baseline).Stdev == 0 {
+		t.Fatalf("Expected the DPS to vary between iterations")
+	}
+
+	// With 6x the damage per tick and the same fight length each iteration, the
+	// buffed sim is exactly 6x as much DPS in every iteration.
+	buffed := dpsValues(results[2])
+	for i := range baseline {
+		if !WithinToleranceFloat64(6*baseline[i], buffed[i], 0.0001) {
+			t.Fatalf("Expected iteration %d to be paired, got %0.2f vs %0.2f DPS", i, baseline[i], buffed[i])
+		}
+	}
+	paired := newPairedMetricEstimate(baseline, buffed)
+	if !WithinToleranceFloat64(5*newMetricEstimate(baseline).Avg, paired.Avg, 0.0001) {
+		t.Fatalf("Expected a paired difference of 5x the baseline DPS, got %v", paired)
+	}
+}
@@ -60,6 +60,10 @@ type SpellConfig struct {
 	Shield ShieldConfig
 
 	RelatedAuras []AuraArray
+
+	// The aura this spell applies to its caster, for spells like defensive
+	// cooldowns whose effect is a self buff.
+	RelatedSelfBuff *Aura
 }
 
 type Spell struct {
@@ -96,7 +100,8 @@ type Spell struct {
 	SharedCD           Cooldown
 	ExtraCastCondition CanCastCondition
 
-	castTimeFn func(spell *Spell) time.Duration // allows to override CastTime()
+	castTimeFn  func(spell *Spell) time.Duration // allows to override CastTime()
+	ignoreHaste bool                             // see CastConfig.IgnoreHaste
 
 	// Performs a cast of this spell.
 	castFn CastSuccessFunc
@@ -145,6 +150,9 @@ type Spell struct {
 
 	// Per-target auras that are related to this spell, usually buffs or debuffs applied by the spell.
 	RelatedAuras []AuraArray
+
+	// The aura this spell applies to its caster, if any.
+	RelatedSelfBuff *Aura
 }
 
 func (unit *Unit) OnSpellRegistered(handler SpellRegisteredHandler) {
@@ -229,7 +237,8 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 
 		splitSpellMetrics: make([][]SpellMetrics, max(1, config.MetricSplits)),
 
-		RelatedAuras: config.RelatedAuras,
+		RelatedAuras:    config.RelatedAuras,
+		RelatedSelfBuff: config.RelatedSelfBuff,
 	}
 
 	switch {
@@ -275,6 +284,7 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 	if spell.DefaultCast.GCD <= 0 && spell.DefaultCast.CastTime == 0 {
 		config.Cast.IgnoreHaste = true
 	}
+	spell.ignoreHaste = config.Cast.IgnoreHaste
 
 	if spell.DefaultCast == emptyCast {
 		if config.ExtraCastCondition == nil && config.Cast.CD.Timer == nil && config.Cast.SharedCD.Timer == nil {
@@ -536,9 +546,9 @@ func (spell *Spell) applyEffects(sim *Simulation, target *Unit) {
 }
 
 func (spell *Spell) ApplyAOEThreatIgnoreMultipliers(threatAmount float64) {
-	numTargets := spell.Unit.Env.GetNumTargets()
-	for i := int32(0); i < numTargets; i++ {
-		spell.SpellMetrics[i].TotalThreat += threatAmount
+	for _, target := range spell.Unit.Env.Encounter.ActiveTargetUnits {
+		spell.SpellMetrics[target.UnitIndex].TotalThreat += threatAmount
+		spell.Unit.addThreat(target, threatAmount)
 	}
 }
 func (spell *Spell) ApplyAOEThreat(threatAmount float64) {
@@ -581,6 +591,18 @@ func (spell *Spell) CastTime() time.Duration {
 	return spell.castTimeFn(spell)
 }
 
+// GCD triggered by the default cast, hasted the same way as when casting.
+func (spell *Spell) DefaultGCD() time.Duration {
+	gcd := spell.DefaultCast.GCD
+	if gcd <= 0 {
+		return 0
+	}
+	if !spell.ignoreHaste {
+		gcd = spell.Unit.ApplyCastSpeed(gcd)
+	}
+	return max(GCDMin, gcd)
+}
+
 func (spell *Spell) TravelTime() time.Duration {
 	if spell.MissileSpeed == 0 {
 		return 0
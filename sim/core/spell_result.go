@@ -18,6 +18,7 @@ type SpellResult struct {
 
 	ResistanceMultiplier float64 // Partial Resists / Armor multiplier
 	PreOutcomeDamage     float64 // Damage done by this cast before Outcome is applied
+	Absorbed             float64 // Damage soaked up by the target's shields
 
 	inUse bool
 }
@@ -31,6 +32,7 @@ func (spell *Spell) NewResult(target *Unit) *SpellResult {
 	result.Target = target
 	result.Damage = 0
 	result.Threat = 0
+	result.Absorbed = 0
 	result.Outcome = OutcomeEmpty // for blocks
 	result.inUse = true
 
@@ -275,15 +277,20 @@ func (spell *Spell) CalcAndDealOutcome(sim *Simulation, target *Unit, outcomeApp
 
 // Applies the fully computed spell result to the sim.
 func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result *SpellResult) {
+	// Shields soak up damage before it is recorded, so absorbed damage counts
+	// neither as damage taken nor towards threat.
+	if result.Damage > 0 && len(result.Target.activeShields) > 0 {
+		absorbedBefore := result.Absorbed
+		result.Target.absorbDamage(sim, spell, result)
+		if result.Absorbed > absorbedBefore {
+			result.Threat = spell.ThreatFromDamage(result.Outcome, result.Damage)
+		}
+	}
+
 	if sim.CurrentTime >= 0 {
 		spell.SpellMetrics[result.Target.UnitIndex].TotalDamage += result.Damage
 		spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
-	}
-
-	// Mark total damage done in raid so far for health based fights.
-	// Don't include damage done by EnemyUnits to Players
-	if result.Target.Type == EnemyUnit {
-		sim.Encounter.DamageTaken += result.Damage
+		spell.Unit.addThreat(result.Target, result.Threat)
 	}
 
 	if sim.Log != nil {
@@ -304,6 +311,15 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 		}
 	}
 
+	if spell.Unit.Type == EnemyUnit && result.Target.Type == PlayerUnit {
+		sim.Encounter.Targets[spell.Unit.Index].recordDamageSpikeHit(spell, result)
+	}
+
+	// In health fights, targets lose health and die once it runs out.
+	if result.Target.Type == EnemyUnit && sim.Encounter.UseHealth {
+		sim.Encounter.Targets[result.Target.Index].takeDamage(sim, result.Damage)
+	}
+
 	spell.DisposeResult(result)
 }
 func (spell *Spell) DealDamage(sim *Simulation, result *SpellResult) {
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Returns the evenly spaced deltas covered by a stat curve axis.
+func statCurveAxisDeltas(axis *proto.StatCurveAxis) []float64 {
+	if axis.NumSteps < 2 || axis.MinDelta == axis.MaxDelta {
+		return []float64{axis.MinDelta}
+	}
+
+	deltas := make([]float64, axis.NumSteps)
+	step := (axis.MaxDelta - axis.MinDelta) / float64(axis.NumSteps-1)
+	for i := range deltas {
+		deltas[i] = axis.MinDelta + step*float64(i)
+	}
+	return deltas
+}
+
+// Returns every combination of deltas for the given axes, i.e. a line for a
+// single axis and a grid for two.
+func statCurveGrid(axes []*proto.StatCurveAxis) [][]float64 {
+	grid := [][]float64{{}}
+	for _, axis := range axes {
+		var newGrid [][]float64
+		for _, point := range grid {
+			for _, delta := range statCurveAxisDeltas(axis) {
+				newGrid = append(newGrid, append(append([]float64{}, point...), delta))
+			}
+		}
+		grid = newGrid
+	}
+	return grid
+}
+
+// Sweeps one or two stats over a range and measures the player's DPS/TPS/HPS at
+// each point. All points and the unmodified baseline use common random numbers,
+// so the shape of the curve (caps, breakpoints) is visible with few iterations.
+func CalcStatCurve(scr *proto.StatCurveRequest, progress chan *proto.ProgressMetrics) *proto.StatCurveResult {
+	if len(scr.Axes) == 0 || len(scr.Axes) > 2 {
+		return &proto.StatCurveResult{ErrorResult: "Stat curves need 1 or 2 axes"}
+	}
+	for _, axis := range scr.Axes {
+		if len(axis.Stats) == 0 {
+			return &proto.StatCurveResult{ErrorResult: "Stat curve axis has no stats"}
+		}
+	}
+
+	player := googleProto.Clone(scr.Player).(*proto.Player)
+	if player.BonusStats == nil {
+		player.BonusStats = &proto.UnitStats{}
+	}
+	if player.BonusStats.Stats == nil {
+		player.BonusStats.Stats = make([]float64, stats.Len)
+	}
+	if player.BonusStats.PseudoStats == nil {
+		player.BonusStats.PseudoStats = make([]float64, stats.PseudoStatsLen)
+	}
+
+	raidProto := SinglePlayerRaidProto(player, scr.PartyBuffs, scr.RaidBuffs, scr.Debuffs)
+	raidProto.Tanks = scr.Tanks
+
+	baseSimRequest := &proto.RaidSimRequest{
+		Raid:       raidProto,
+		Encounter:  scr.Encounter,
+		SimOptions: commonRandomNumbersOptions(scr.SimOptions),
+	}
+
+	grid := statCurveGrid(scr.Axes)
+
+	// The first request is the baseline, which the deltas are measured against.
+	requests := []*proto.RaidSimRequest{baseSimRequest}
+	for _, deltas := range grid {
+		simRequest := googleProto.Clone(baseSimRequest).(*proto.RaidSimRequest)
+		bonusStats := simRequest.Raid.Parties[0].Players[0].BonusStats
+		for axisIdx, axis := range scr.Axes {
+			for _, stat := range axis.Stats {
+				stats.UnitStatFromStat(stats.Stat(stat)).AddToStatsProto(bonusStats, deltas[axisIdx])
+			}
+		}
+		requests = append(requests, simRequest)
+	}
+
+	results, errorStr := runSimBatch(requests, progress)
+	if errorStr != "" {
+		return &proto.StatCurveResult{ErrorResult: errorStr}
+	}
+
+	baselinePlayer := results[0].RaidMetrics.Parties[0].Players[0]
+	curveResult := &proto.StatCurveResult{}
+	for i, deltas := range grid {
+		modPlayer := results[i+1].RaidMetrics.Parties[0].Players[0]
+		curveResult.Points = append(curveResult.Points, &proto.StatCurvePoint{
+			Deltas:   deltas,
+			Dps:      newMetricEstimate(modPlayer.Dps.AllValues),
+			Tps:      newMetricEstimate(modPlayer.Threat.AllValues),
+			Hps:      newMetricEstimate(modPlayer.Hps.AllValues),
+			DpsDelta: newPairedMetricEstimate(baselinePlayer.Dps.AllValues, modPlayer.Dps.AllValues),
+		})
+	}
+
+	return curveResult
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestStatCurveGrid(t *testing.T) {
+	line := statCurveGrid([]*proto.StatCurveAxis{
+		{MinDelta: -100, MaxDelta: 100, NumSteps: 5},
+	})
+	expectedLine := [][]float64{{-100}, {-50}, {0}, {50}, {100}}
+	if diff := cmp.Diff(expectedLine, line); diff != "" {
+		t.Fatalf("Unexpected single axis points (-want +got):\n%s", diff)
+	}
+
+	grid := statCurveGrid([]*proto.StatCurveAxis{
+		{MinDelta: 0, MaxDelta: 10, NumSteps: 2},
+		{MinDelta: 5, MaxDelta: 5, NumSteps: 3},
+	})
+	expectedGrid := [][]float64{{0, 5}, {10, 5}}
+	if diff := cmp.Diff(expectedGrid, grid); diff != "" {
+		t.Fatalf("Unexpected grid points (-want +got):\n%s", diff)
+	}
+}
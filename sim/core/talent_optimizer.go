@@ -0,0 +1,416 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	talenttrees "github.com/wowsims/cata/ui/core/talents/trees"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	defaultTalentPoints      = 41
+	defaultPrimaryTreePoints = 31
+	defaultMaxTalentBuilds   = 2000
+
+	// Points needed in the primary tree before the other trees unlock.
+	talentPointsToUnlockOtherTrees = 31
+	// Points needed in lower rows of a tree for each row.
+	talentPointsPerRow = 5
+)
+
+// A single talent, as seen by the talent optimizer.
+type optimizerTalent struct {
+	fieldName string
+	index     int // Position within the tree's part of the talents string.
+	pos       int // Position within optimizerTree.talents.
+	row       int
+	col       int
+	minPoints int
+	maxPoints int // Points the optimizer may spend, i.e. minPoints for non-candidates.
+	rankCap   int // Real max rank of the talent.
+	prereq    *optimizerTalent
+}
+
+type optimizerTree struct {
+	size    int                // Length of the tree's part of the talents string.
+	talents []*optimizerTalent // Sorted by row, then column.
+
+	buildsByPoints map[int][][]int
+}
+
+func talentsMessageForClass(class proto.Class) protoreflect.Message {
+	switch class {
+	case proto.Class_ClassDeathKnight:
+		return (&proto.DeathKnightTalents{}).ProtoReflect()
+	case proto.Class_ClassDruid:
+		return (&proto.DruidTalents{}).ProtoReflect()
+	case proto.Class_ClassHunter:
+		return (&proto.HunterTalents{}).ProtoReflect()
+	case proto.Class_ClassMage:
+		return (&proto.MageTalents{}).ProtoReflect()
+	case proto.Class_ClassPaladin:
+		return (&proto.PaladinTalents{}).ProtoReflect()
+	case proto.Class_ClassPriest:
+		return (&proto.PriestTalents{}).ProtoReflect()
+	case proto.Class_ClassRogue:
+		return (&proto.RogueTalents{}).ProtoReflect()
+	case proto.Class_ClassShaman:
+		return (&proto.ShamanTalents{}).ProtoReflect()
+	case proto.Class_ClassWarlock:
+		return (&proto.WarlockTalents{}).ProtoReflect()
+	case proto.Class_ClassWarrior:
+		return (&proto.WarriorTalents{}).ProtoReflect()
+	}
+	return nil
+}
+
+// Builds the optimizer's view of the talent trees, checking the given layouts,
+// or else the UI's layouts of the class, against the class's talents proto.
+func newOptimizerTrees(class proto.Class, settings *proto.TalentOptimizerSettings) ([3]*optimizerTree, error) {
+	var trees [3]*optimizerTree
+
+	talentsMessage := talentsMessageForClass(class)
+	if talentsMessage == nil {
+		return trees, fmt.Errorf("talent optimizer: no talents for class %s", class)
+	}
+	treeLayouts := settings.Trees
+	if len(treeLayouts) == 0 {
+		var err error
+		if treeLayouts, err = talenttrees.ForClass(class); err != nil {
+			return trees, fmt.Errorf("talent optimizer: %s", err)
+		}
+	}
+	if len(treeLayouts) != 3 {
+		return trees, fmt.Errorf("talent optimizer: expected 3 talent trees, found %d", len(treeLayouts))
+	}
+
+	candidates := make(map[string]bool, len(settings.CandidateTalents))
+	for _, name := range settings.CandidateTalents {
+		candidates[name] = true
+	}
+	fields := talentsMessage.Descriptor().Fields()
+
+	var offset int
+	for treeIdx, treeLayout := range treeLayouts {
+		tree := &optimizerTree{
+			size:           len(treeLayout.Talents),
+			buildsByPoints: make(map[int][][]int),
+		}
+		byLocation := make(map[[2]int32]*optimizerTalent, len(treeLayout.Talents))
+
+		// Like the UI, talents are listed in talents string order. Names come from
+		// the talents proto, because the scraped layouts aren't always clean.
+		for index, layout := range treeLayout.Talents {
+			fd := fields.ByNumber(protowire.Number(offset + index + 1))
+			if fd == nil {
+				return trees, fmt.Errorf("talent optimizer: too many talents in tree %d", treeIdx)
+			}
+			if layout.MaxPoints < 1 || (fd.Kind() == protoreflect.BoolKind && layout.MaxPoints != 1) {
+				return trees, fmt.Errorf("talent optimizer: invalid max points %d for talent %q", layout.MaxPoints, fd.JSONName())
+			}
+
+			talent := &optimizerTalent{
+				fieldName: fd.JSONName(),
+				index:     index,
+				row:       int(layout.GetLocation().GetRowIdx()),
+				col:       int(layout.GetLocation().GetColIdx()),
+				rankCap:   int(layout.MaxPoints),
+			}
+			if len(settings.CandidateTalents) == 0 || candidates[talent.fieldName] {
+				talent.maxPoints = talent.rankCap
+				delete(candidates, talent.fieldName)
+			}
+			tree.talents = append(tree.talents, talent)
+			byLocation[[2]int32{int32(talent.row), int32(talent.col)}] = talent
+		}
+
+		for i, layout := range treeLayout.Talents {
+			if layout.PrereqLocation == nil {
+				continue
+			}
+			prereq, ok := byLocation[[2]int32{layout.PrereqLocation.RowIdx, layout.PrereqLocation.ColIdx}]
+			if !ok {
+				return trees, fmt.Errorf("talent optimizer: missing prerequisite for talent %q", tree.talents[i].fieldName)
+			}
+			tree.talents[i].prereq = prereq
+		}
+
+		sort.SliceStable(tree.talents, func(i, j int) bool {
+			if tree.talents[i].row != tree.talents[j].row {
+				return tree.talents[i].row < tree.talents[j].row
+			}
+			return tree.talents[i].col < tree.talents[j].col
+		})
+		for i, talent := range tree.talents {
+			talent.pos = i
+		}
+
+		trees[treeIdx] = tree
+		offset += tree.size
+	}
+
+	for name := range candidates {
+		return trees, fmt.Errorf("talent optimizer: unknown candidate talent %q", name)
+	}
+
+	for treeIdx, treeStr := range strings.Split(settings.RequiredTalents, "-") {
+		if treeStr == "" {
+			continue
+		}
+		if treeIdx >= 3 || len(treeStr) > trees[treeIdx].size {
+			return trees, fmt.Errorf("talent optimizer: invalid required talents %q", settings.RequiredTalents)
+		}
+		for _, talent := range trees[treeIdx].talents {
+			if talent.index >= len(treeStr) {
+				continue
+			}
+			points, err := strconv.Atoi(string(treeStr[talent.index]))
+			if err != nil || points > talent.rankCap {
+				return trees, fmt.Errorf("talent optimizer: invalid required talents %q", settings.RequiredTalents)
+			}
+			talent.minPoints = points
+			talent.maxPoints = max(talent.maxPoints, points)
+		}
+	}
+
+	return trees, nil
+}
+
+// Returns every legal way of spending exactly the given number of points in this
+// tree, as points per talent in talents string order. Returns false if there are
+// more than limit of them.
+func (tree *optimizerTree) builds(points int, limit int) ([][]int, bool) {
+	if builds, ok := tree.buildsByPoints[points]; ok {
+		return builds, true
+	}
+
+	numTalents := len(tree.talents)
+	// Smallest and largest number of points that can still be spent from each talent onwards.
+	suffixMin := make([]int, numTalents+1)
+	suffixMax := make([]int, numTalents+1)
+	// Position of the first talent in the same row as each talent.
+	rowStart := make([]int, numTalents)
+	for i := numTalents - 1; i >= 0; i-- {
+		suffixMin[i] = suffixMin[i+1] + tree.talents[i].minPoints
+		suffixMax[i] = suffixMax[i+1] + tree.talents[i].maxPoints
+	}
+	for i, talent := range tree.talents {
+		if i > 0 && tree.talents[i-1].row == talent.row {
+			rowStart[i] = rowStart[i-1]
+		} else {
+			rowStart[i] = i
+		}
+	}
+
+	var builds [][]int
+	ranks := make([]int, numTalents)
+	spentBefore := make([]int, numTalents+1)
+
+	var search func(i int) bool
+	search = func(i int) bool {
+		spent := spentBefore[i]
+		if i == numTalents {
+			if spent != points || !tree.prereqsMet(ranks) {
+				return true
+			}
+			build := make([]int, tree.size)
+			for pos, talent := range tree.talents {
+				build[talent.index] = ranks[pos]
+			}
+			builds = append(builds, build)
+			return len(builds) <= limit
+		}
+
+		talent := tree.talents[i]
+		rowUnlocked := spentBefore[rowStart[i]] >= talent.row*talentPointsPerRow
+		for rank := talent.minPoints; rank <= talent.maxPoints; rank++ {
+			if rank > 0 && !rowUnlocked {
+				break
+			}
+			if spent+rank+suffixMin[i+1] > points {
+				break
+			}
+			if spent+rank+suffixMax[i+1] < points {
+				continue
+			}
+			ranks[i] = rank
+			spentBefore[i+1] = spent + rank
+			if !search(i + 1) {
+				return false
+			}
+		}
+		ranks[i] = 0
+		return true
+	}
+
+	if !search(0) {
+		return nil, false
+	}
+	tree.buildsByPoints[points] = builds
+	return builds, true
+}
+
+func (tree *optimizerTree) prereqsMet(ranks []int) bool {
+	for pos, talent := range tree.talents {
+		if ranks[pos] > 0 && talent.prereq != nil && ranks[talent.prereq.pos] != talent.prereq.rankCap {
+			return false
+		}
+	}
+	return true
+}
+
+func talentTreeString(build []int) string {
+	var sb strings.Builder
+	for _, points := range build {
+		sb.WriteString(strconv.Itoa(points))
+	}
+	return strings.TrimRight(sb.String(), "0")
+}
+
+// Returns the talents strings of every legal build that meets the constraints.
+func talentOptimizerBuilds(trees [3]*optimizerTree, settings *proto.TalentOptimizerSettings) ([]string, error) {
+	totalPoints := int(settings.TotalPoints)
+	if totalPoints <= 0 {
+		totalPoints = defaultTalentPoints
+	}
+	primaryPoints := int(settings.PrimaryTreePoints)
+	if primaryPoints <= 0 {
+		primaryPoints = min(defaultPrimaryTreePoints, totalPoints)
+	}
+	maxBuilds := int(settings.MaxBuilds)
+	if maxBuilds <= 0 {
+		maxBuilds = defaultMaxTalentBuilds
+	}
+	if settings.PrimaryTree < 0 || settings.PrimaryTree > 2 {
+		return nil, fmt.Errorf("talent optimizer: invalid primary tree %d", settings.PrimaryTree)
+	}
+
+	primary := int(settings.PrimaryTree)
+	other1, other2 := (primary+1)%3, (primary+2)%3
+	tooMany := fmt.Errorf("talent optimizer: more than %d builds match the constraints, require more talents or narrow down the candidates", maxBuilds)
+
+	var results []string
+	for points := primaryPoints; points <= totalPoints; points++ {
+		remaining := totalPoints - points
+		if remaining > 0 && points < talentPointsToUnlockOtherTrees {
+			continue
+		}
+		primaryBuilds, ok := trees[primary].builds(points, maxBuilds)
+		if !ok {
+			return nil, tooMany
+		}
+		for points1 := 0; points1 <= remaining; points1++ {
+			builds1, ok1 := trees[other1].builds(points1, maxBuilds)
+			builds2, ok2 := trees[other2].builds(remaining-points1, maxBuilds)
+			if !ok1 || !ok2 {
+				return nil, tooMany
+			}
+			for _, primaryBuild := range primaryBuilds {
+				for _, build1 := range builds1 {
+					for _, build2 := range builds2 {
+						treeStrs := make([]string, 3)
+						treeStrs[primary] = talentTreeString(primaryBuild)
+						treeStrs[other1] = talentTreeString(build1)
+						treeStrs[other2] = talentTreeString(build2)
+						results = append(results, strings.Join(treeStrs, "-"))
+						if len(results) > maxBuilds {
+							return nil, tooMany
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("talent optimizer: no legal builds match the constraints")
+	}
+	return results, nil
+}
+
+// Returns every combination of up to 3 of the candidate glyphs, or just the
+// current glyphs if there are no candidates.
+func glyphCombinations(candidates []int32, current [3]int32) [][3]int32 {
+	var unique []int32
+	seen := make(map[int32]bool)
+	for _, glyph := range candidates {
+		if glyph != 0 && !seen[glyph] {
+			seen[glyph] = true
+			unique = append(unique, glyph)
+		}
+	}
+	if len(unique) == 0 {
+		return [][3]int32{current}
+	}
+
+	var combos [][3]int32
+	var combo [3]int32
+	var choose func(start int, slot int)
+	choose = func(start int, slot int) {
+		if slot == min(3, len(unique)) {
+			combos = append(combos, combo)
+			return
+		}
+		for i := start; i < len(unique); i++ {
+			combo[slot] = unique[i]
+			choose(i+1, slot+1)
+		}
+		combo[slot] = 0
+	}
+	choose(0, 0)
+	return combos
+}
+
+// Generates the talent loadouts for the bulk sim to compare, from the talent
+// optimizer constraints.
+func talentOptimizerLoadouts(player *proto.Player, settings *proto.TalentOptimizerSettings) ([]*proto.TalentLoadout, error) {
+	trees, err := newOptimizerTrees(player.Class, settings)
+	if err != nil {
+		return nil, err
+	}
+	talentStrings, err := talentOptimizerBuilds(trees, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	glyphs := player.GetGlyphs()
+	primeCombos := glyphCombinations(settings.PrimeGlyphs, [3]int32{glyphs.GetPrime1(), glyphs.GetPrime2(), glyphs.GetPrime3()})
+	majorCombos := glyphCombinations(settings.MajorGlyphs, [3]int32{glyphs.GetMajor1(), glyphs.GetMajor2(), glyphs.GetMajor3()})
+
+	maxBuilds := int(settings.MaxBuilds)
+	if maxBuilds <= 0 {
+		maxBuilds = defaultMaxTalentBuilds
+	}
+	if numBuilds := len(talentStrings) * len(primeCombos) * len(majorCombos); numBuilds > maxBuilds {
+		return nil, fmt.Errorf("talent optimizer: %d talent and glyph builds match the constraints, more than the maximum of %d", numBuilds, maxBuilds)
+	}
+
+	var loadouts []*proto.TalentLoadout
+	for _, talentsString := range talentStrings {
+		for _, primes := range primeCombos {
+			for _, majors := range majorCombos {
+				loadouts = append(loadouts, &proto.TalentLoadout{
+					TalentsString: talentsString,
+					Name:          talentsString,
+					Glyphs: &proto.Glyphs{
+						Prime1: primes[0],
+						Prime2: primes[1],
+						Prime3: primes[2],
+						Major1: majors[0],
+						Major2: majors[1],
+						Major3: majors[2],
+						Minor1: glyphs.GetMinor1(),
+						Minor2: glyphs.GetMinor2(),
+						Minor3: glyphs.GetMinor3(),
+					},
+				})
+			}
+		}
+	}
+	return loadouts, nil
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestTalentOptimizerTreeBuilds(t *testing.T) {
+	// Two talents in the first row, one in the second that requires the first talent to be maxed.
+	first := &optimizerTalent{index: 0, pos: 0, row: 0, maxPoints: 3, rankCap: 3}
+	second := &optimizerTalent{index: 1, pos: 1, row: 0, maxPoints: 3, rankCap: 3}
+	third := &optimizerTalent{index: 2, pos: 2, row: 1, maxPoints: 2, rankCap: 2, prereq: first}
+	tree := &optimizerTree{
+		size:           3,
+		talents:        []*optimizerTalent{first, second, third},
+		buildsByPoints: make(map[int][][]int),
+	}
+
+	builds, ok := tree.builds(6, 100)
+	if !ok {
+		t.Fatalf("builds(6) hit the limit")
+	}
+	want := [][]int{{3, 2, 1}, {3, 3, 0}}
+	if diff := cmp.Diff(want, builds); diff != "" {
+		t.Fatalf("builds(6) returned unexpected builds (-want +got):\n%s", diff)
+	}
+
+	// The second row needs 5 points in the first.
+	builds, _ = tree.builds(4, 100)
+	want = [][]int{{1, 3, 0}, {2, 2, 0}, {3, 1, 0}}
+	if diff := cmp.Diff(want, builds); diff != "" {
+		t.Fatalf("builds(4) returned unexpected builds (-want +got):\n%s", diff)
+	}
+
+	if _, ok := tree.builds(3, 2); ok {
+		t.Fatalf("builds(3) should have hit the limit")
+	}
+}
+
+func TestGlyphCombinations(t *testing.T) {
+	current := [3]int32{1, 2, 3}
+	if diff := cmp.Diff([][3]int32{current}, glyphCombinations(nil, current)); diff != "" {
+		t.Fatalf("glyphCombinations without candidates (-want +got):\n%s", diff)
+	}
+
+	want := [][3]int32{{4, 5, 6}, {4, 5, 7}, {4, 6, 7}, {5, 6, 7}}
+	if diff := cmp.Diff(want, glyphCombinations([]int32{4, 5, 6, 7, 5}, current)); diff != "" {
+		t.Fatalf("glyphCombinations returned unexpected combos (-want +got):\n%s", diff)
+	}
+}
+
+func TestOptimizerTreesDefaultToUITrees(t *testing.T) {
+	for _, class := range []proto.Class{
+		proto.Class_ClassDeathKnight, proto.Class_ClassDruid, proto.Class_ClassHunter, proto.Class_ClassMage, proto.Class_ClassPaladin,
+		proto.Class_ClassPriest, proto.Class_ClassRogue, proto.Class_ClassShaman, proto.Class_ClassWarlock, proto.Class_ClassWarrior,
+	} {
+		trees, err := newOptimizerTrees(class, &proto.TalentOptimizerSettings{})
+		if err != nil {
+			t.Fatalf("Failed to load the talent trees of %s: %s", class, err)
+		}
+		if fields := talentsMessageForClass(class).Descriptor().Fields().Len(); trees[0].size+trees[1].size+trees[2].size != fields {
+			t.Fatalf("Expected the talent trees of %s to cover all %d talents", class, fields)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"slices"
 	"strconv"
 	"time"
 
@@ -19,13 +20,24 @@ type Encounter struct {
 	ExecuteProportion_35 float64
 	ExecuteProportion_90 float64
 
-	EndFightAtHealth float64
-	// DamageTaken is used to track health fights instead of duration fights.
-	//  Once primary target has taken its health worth of damage, fight ends.
+	// In health fights, each target has its own health pool and dies when it
+	// runs out. The fight ends once all required targets are dead.
+	UseHealth bool
+	// Total health of the required targets in a health fight.
+	RequiredHealth float64
+	// DamageTaken is the health lost by the required targets so far, which
+	// drives execute phases and duration estimates in health fights.
 	DamageTaken float64
 	// In health fight: set to true until we get something to base on
 	DurationIsEstimate bool
 
+	// Targets which are still alive. Dead targets are removed in health fights.
+	ActiveTargets     []*Target
+	ActiveTargetUnits []*Unit
+
+	numRequiredAlive int
+	retargets        []retarget // Players switched off a dead target, to undo on reset.
+
 	// Value to multiply by, for damage spells which are subject to the aoe cap.
 	aoeCapMultiplier float64
 }
@@ -41,17 +53,9 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		ExecuteProportion_25: max(options.ExecuteProportion_25, 0),
 		ExecuteProportion_35: max(options.ExecuteProportion_35, 0),
 		ExecuteProportion_90: max(options.ExecuteProportion_90, 0),
+		UseHealth:            options.UseHealth,
 		Targets:              []*Target{},
 	}
-	// If UseHealth is set, we use the sum of targets health.
-	if options.UseHealth {
-		for _, t := range options.Targets {
-			encounter.EndFightAtHealth += t.Stats[stats.Health]
-		}
-		if encounter.EndFightAtHealth == 0 {
-			encounter.EndFightAtHealth = 1 // default to something so we don't instantly end without anything.
-		}
-	}
 
 	for targetIndex, targetOptions := range options.Targets {
 		target := NewTarget(targetOptions, int32(targetIndex))
@@ -66,17 +70,67 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		encounter.TargetUnits = append(encounter.TargetUnits, &target.Unit)
 	}
 
-	if encounter.EndFightAtHealth > 0 {
+	if encounter.UseHealth {
+		for _, target := range encounter.Targets {
+			if target.stats[stats.Health] > 0 {
+				target.EnableHealthBar()
+			}
+		}
+		if !slices.ContainsFunc(encounter.Targets, (*Target).isRequiredKill) {
+			// Default to something so we don't instantly end without anything.
+			target := encounter.Targets[0]
+			target.stats[stats.Health] = 1
+			target.optionalKill = false
+			target.EnableHealthBar()
+		}
+		for _, target := range encounter.Targets {
+			if target.isRequiredKill() {
+				encounter.RequiredHealth += target.stats[stats.Health]
+			}
+		}
+
 		// Until we pre-sim set duration to 10m
 		encounter.Duration = time.Minute * 10
 		encounter.DurationIsEstimate = true
 	}
 
+	encounter.ActiveTargets = slices.Clone(encounter.Targets)
+	encounter.ActiveTargetUnits = slices.Clone(encounter.TargetUnits)
+
 	encounter.updateAOECapMultiplier()
 
 	return encounter
 }
 
+type retarget struct {
+	unit   *Unit
+	target *Unit
+}
+
+func (encounter *Encounter) reset() {
+	encounter.DamageTaken = 0
+
+	encounter.ActiveTargets = append(encounter.ActiveTargets[:0], encounter.Targets...)
+	encounter.ActiveTargetUnits = append(encounter.ActiveTargetUnits[:0], encounter.TargetUnits...)
+
+	encounter.numRequiredAlive = 0
+	for _, target := range encounter.Targets {
+		if target.isRequiredKill() {
+			encounter.numRequiredAlive++
+		}
+	}
+
+	for i := len(encounter.retargets) - 1; i >= 0; i-- {
+		encounter.retargets[i].unit.CurrentTarget = encounter.retargets[i].target
+	}
+	encounter.retargets = encounter.retargets[:0]
+}
+
+// Whether a health fight is over because all required targets are dead.
+func (encounter *Encounter) requiredTargetsDead() bool {
+	return encounter.UseHealth && encounter.numRequiredAlive == 0
+}
+
 func (encounter *Encounter) AOECapMultiplier() float64 {
 	return encounter.aoeCapMultiplier
 }
@@ -110,6 +164,20 @@ type Target struct {
 	Unit
 
 	AI TargetAI
+
+	// Tanks this target chooses its target from by threat, main tank first.
+	Tanks []*Unit
+
+	threat         []float64 // Threat of each tank, including taunts.
+	tauntExpiresAt time.Duration
+
+	damageSpikes []DamageSpike // Damage spikes published by the AI.
+
+	optionalKill bool
+	dead         bool
+
+	executePhase          int32 // Like Simulation.executePhase, but from this target's health.
+	executePhaseCallbacks []func(*Simulation, *Target, int32)
 }
 
 func NewTarget(options *proto.Target, targetIndex int32) *Target {
@@ -150,10 +218,13 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 	target.PseudoStats.ParryHaste = options.ParryHaste
 	target.PseudoStats.InFrontOfTarget = true
 	target.PseudoStats.DamageSpread = options.DamageSpread
+	target.optionalKill = options.OptionalKill
 
 	preset := GetPresetTargetWithID(options.Id)
 	if preset != nil && preset.AI != nil {
 		target.AI = preset.AI()
+	} else if len(options.Abilities) > 0 {
+		target.AI = NewScriptedAI()()
 	}
 
 	return target
@@ -162,17 +233,108 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 func (target *Target) Reset(sim *Simulation) {
 	target.Unit.reset(sim, nil)
 	target.SetGCDTimer(sim, 0)
+	target.resetThreat(sim)
 	if target.AI != nil {
 		target.AI.Reset(sim)
+		target.resetDamageSpikes(sim)
 	}
+
+	target.dead = false
+	target.executePhase = 100
+	target.executePhaseCallbacks = nil
 }
 
+// Returns the next living target after this one, wrapping around.
 func (target *Target) NextTarget() *Target {
-	nextIndex := target.Index + 1
-	if nextIndex >= target.Env.GetNumTargets() {
-		nextIndex = 0
+	targets := target.Env.Encounter.Targets
+	for i := 1; i < len(targets); i++ {
+		next := targets[(int(target.Index)+i)%len(targets)]
+		if !next.dead {
+			return next
+		}
+	}
+	return target
+}
+
+func (target *Target) isRequiredKill() bool {
+	return target.HasHealthBar() && !target.optionalKill
+}
+
+// Whether this target has died in a health fight.
+func (target *Target) IsDead() bool {
+	return target.dead
+}
+
+// Registers a callback for when this target enters an execute phase in a
+// health fight. Like Simulation.RegisterExecutePhaseCallback, callbacks must
+// be registered again on every reset.
+func (target *Target) RegisterExecutePhaseCallback(callback func(sim *Simulation, target *Target, executePhase int32)) {
+	target.executePhaseCallbacks = append(target.executePhaseCallbacks, callback)
+}
+
+// Execute phases in the order they are reached, as health percentages.
+var executePhases = []int32{90, 35, 25, 20}
+
+// Removes health from this target in a health fight, advancing its execute
+// phase and killing it once it runs out.
+func (target *Target) takeDamage(sim *Simulation, damage float64) {
+	if target.dead || !target.HasHealthBar() || damage <= 0 {
+		return
+	}
+
+	healthLost := min(damage, target.CurrentHealth())
+	target.RemoveHealth(sim, damage)
+	if !target.optionalKill {
+		sim.Encounter.DamageTaken += healthLost
+	}
+
+	healthPercent := target.CurrentHealthPercent() * 100
+	for _, phase := range executePhases {
+		if phase < target.executePhase && healthPercent <= float64(phase) {
+			target.executePhase = phase
+			for _, callback := range target.executePhaseCallbacks {
+				callback(sim, target, phase)
+			}
+		}
+	}
+
+	if target.CurrentHealth() <= 0 {
+		target.die(sim)
+	}
+}
+
+func (target *Target) die(sim *Simulation) {
+	target.dead = true
+	if sim.Log != nil {
+		target.Log(sim, "Died")
+	}
+
+	encounter := &sim.Encounter
+	if idx := slices.Index(encounter.ActiveTargets, target); idx != -1 {
+		// Delete from copies, so that AOE spells looping over the active targets
+		// while this target dies still see the old ones.
+		encounter.ActiveTargets = slices.Delete(slices.Clone(encounter.ActiveTargets), idx, idx+1)
+		encounter.ActiveTargetUnits = slices.Delete(slices.Clone(encounter.ActiveTargetUnits), idx, idx+1)
+	}
+	if !target.optionalKill {
+		encounter.numRequiredAlive--
+	}
+
+	target.AutoAttacks.CancelAutoSwing(sim)
+	if target.rotationAction != nil {
+		target.CancelGCDTimer(sim)
+	}
+	target.auraTracker.expireAll(sim)
+
+	// Players move on to the next living target.
+	if len(encounter.ActiveTargetUnits) > 0 {
+		for _, unit := range sim.Raid.AllUnits {
+			if unit.CurrentTarget == &target.Unit {
+				encounter.retargets = append(encounter.retargets, retarget{unit: unit, target: unit.CurrentTarget})
+				unit.CurrentTarget = encounter.ActiveTargetUnits[0]
+			}
+		}
 	}
-	return target.Env.GetTarget(nextIndex)
 }
 
 func (target *Target) GetMetricsProto() *proto.UnitMetrics {
@@ -44,6 +44,13 @@ func (target *Target) initialize(config *proto.Target) {
 		target.rotationAction = &PendingAction{
 			Priority: ActionPriorityGCD,
 			OnAction: func(sim *Simulation) {
+				if hc := &target.Hardcast; hc.Expires != startingCDTime && hc.Expires <= sim.CurrentTime {
+					hc.Expires = startingCDTime
+					if hc.OnComplete != nil {
+						hc.OnComplete(sim, hc.Target)
+					}
+				}
+
 				target.Rotation.DoNextAction(sim)
 			},
 		}
@@ -58,7 +65,7 @@ func (target *Target) GetCharacter() *Character          { return nil }
 func (target *Target) Initialize()                       {}
 
 func (target *Target) ExecuteCustomRotation(sim *Simulation) {
-	if target.AI != nil {
+	if target.AI != nil && !target.dead {
 		target.AI.ExecuteCustomRotation(sim)
 	}
 }
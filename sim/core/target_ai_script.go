@@ -0,0 +1,272 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Scripted abilities are used at most this often, like the server tick of real
+// boss AIs, which only pick their next ability every 1.62s. Configured
+// cooldowns below this are raised to it, so an ability with no cooldown is used
+// every 1.62s rather than on every GCD.
+const scriptedAbilityMinCooldown = time.Millisecond * 1620
+
+// TargetAI for targets without a custom AI, which uses the abilities scripted
+// in the target's config.
+type ScriptedAI struct {
+	Target *Target
+
+	Abilities []*ScriptedAbility
+
+	// Reused by DamageSpikes.
+	damageSpikes []DamageSpike
+}
+
+type ScriptedAbility struct {
+	Config *proto.TargetAbility
+	Spell  *Spell
+
+	initialDelay time.Duration
+	debuffs      AuraArray
+}
+
+func NewScriptedAI() AIFactory {
+	return func() TargetAI {
+		return &ScriptedAI{}
+	}
+}
+
+func (ai *ScriptedAI) Initialize(target *Target, config *proto.Target) {
+	ai.Target = target
+
+	for _, abilityConfig := range config.Abilities {
+		ai.Abilities = append(ai.Abilities, ai.registerAbility(abilityConfig))
+	}
+}
+
+// Rejects ability configs the sim can't run.
+func validateScriptedAbility(config *proto.TargetAbility) {
+	actionID := ProtoToActionID(config.Id)
+	if config.DotTickDamage > 0 && config.DotNumTicks > 0 && config.DotTickInterval <= 0 {
+		panic(fmt.Sprintf("[USER_ERROR] Target ability %s has DoT ticks but no tick interval", actionID))
+	}
+	if config.DebuffDuration > 0 && config.DebuffHealingTakenReductionPerStack*float64(max(1, config.DebuffMaxStacks)) >= 1 {
+		panic(fmt.Sprintf("[USER_ERROR] Target ability %s reduces healing taken by 100%% or more at max stacks", actionID))
+	}
+}
+
+func (ai *ScriptedAI) registerAbility(config *proto.TargetAbility) *ScriptedAbility {
+	validateScriptedAbility(config)
+
+	target := ai.Target
+	ability := &ScriptedAbility{
+		Config:       config,
+		initialDelay: DurationFromSeconds(config.InitialDelay),
+	}
+
+	actionID := ProtoToActionID(config.Id)
+	school := SpellSchoolFromProto(config.School)
+
+	procMask := ProcMaskSpellDamage
+	flags := SpellFlagNone
+	if school == SpellSchoolPhysical {
+		procMask = ProcMaskMeleeMHSpecial
+		flags = SpellFlagMeleeMetrics
+	}
+
+	if config.DebuffDuration > 0 {
+		damageTakenPerStack := config.DebuffDamageTakenPerStack
+		healingTakenPerStack := config.DebuffHealingTakenReductionPerStack
+		// The debuffs go on players, which aura arrays call allies.
+		ability.debuffs = target.NewAllyAuraArray(func(unit *Unit) *Aura {
+			return unit.GetOrRegisterAura(Aura{
+				Label:     actionID.String() + " Debuff",
+				ActionID:  actionID,
+				Duration:  DurationFromSeconds(config.DebuffDuration),
+				MaxStacks: max(1, config.DebuffMaxStacks),
+				OnStacksChange: func(aura *Aura, sim *Simulation, oldStacks int32, newStacks int32) {
+					schoolIndex := ability.Spell.SchoolIndex
+					aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[schoolIndex] *= (1 + damageTakenPerStack*float64(newStacks)) / (1 + damageTakenPerStack*float64(oldStacks))
+					aura.Unit.PseudoStats.HealingTakenMultiplier *= (1 - healingTakenPerStack*float64(newStacks)) / (1 - healingTakenPerStack*float64(oldStacks))
+				},
+			})
+		})
+	}
+
+	var dotConfig DotConfig
+	if config.DotTickDamage > 0 && config.DotNumTicks > 0 {
+		tickDamage := config.DotTickDamage
+		dotConfig = DotConfig{
+			Aura: Aura{
+				Label:     actionID.String() + " DoT",
+				MaxStacks: max(1, config.DotMaxStacks),
+			},
+			NumberOfTicks: config.DotNumTicks,
+			TickLength:    DurationFromSeconds(config.DotTickInterval),
+
+			// Snapshot the stacks, as they are gone by the time the last tick happens.
+			OnSnapshot: func(sim *Simulation, target *Unit, dot *Dot, isRollover bool) {
+				dot.SnapshotBaseDamage = tickDamage * float64(dot.GetStacks())
+				if !isRollover {
+					dot.SnapshotAttackerMultiplier = dot.Spell.AttackerDamageMultiplier(dot.Spell.Unit.AttackTables[target.UnitIndex], true)
+				}
+			},
+			OnTick: func(sim *Simulation, target *Unit, dot *Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeTick)
+			},
+		}
+	}
+
+	castTime := DurationFromSeconds(config.CastTime)
+	ability.Spell = target.RegisterSpell(SpellConfig{
+		ActionID:    actionID,
+		SpellSchool: school,
+		ProcMask:    procMask,
+		Flags:       flags,
+
+		Cast: CastConfig{
+			CD: Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: max(scriptedAbilityMinCooldown, DurationFromSeconds(config.Cooldown)),
+			},
+			DefaultCast: Cast{
+				CastTime: castTime,
+			},
+			ModifyCast: func(sim *Simulation, spell *Spell, cast *Cast) {
+				if cast.CastTime > 0 {
+					spell.Unit.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+cast.CastTime, false)
+				}
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		Dot: dotConfig,
+
+		ApplyEffects: func(sim *Simulation, _ *Unit, spell *Spell) {
+			for _, unit := range ai.selectTargets(sim, config.TargetSelection) {
+				ability.apply(sim, spell, unit)
+			}
+		},
+	})
+
+	return ability
+}
+
+func (ability *ScriptedAbility) apply(sim *Simulation, spell *Spell, target *Unit) {
+	config := ability.Config
+	if config.MaxDamage > 0 {
+		// Physical abilities can be avoided like melee swings, others always hit.
+		outcome := spell.OutcomeAlwaysHit
+		if spell.SpellSchool == SpellSchoolPhysical {
+			outcome = spell.OutcomeEnemyMeleeWhite
+		}
+		result := spell.CalcAndDealDamage(sim, target, sim.Roll(config.MinDamage, config.MaxDamage), outcome)
+		if !result.Landed() {
+			return
+		}
+	}
+
+	if dot := spell.Dot(target); dot != nil {
+		if dot.IsActive() {
+			dot.Refresh(sim)
+			dot.AddStack(sim)
+		} else {
+			dot.Apply(sim)
+			dot.SetStacks(sim, 1)
+		}
+		dot.TakeSnapshot(sim, true)
+	}
+
+	if debuff := ability.debuffs.Get(target); debuff != nil {
+		debuff.Activate(sim)
+		debuff.AddStack(sim)
+	}
+}
+
+func (ai *ScriptedAI) selectTargets(sim *Simulation, selection proto.TargetAbility_TargetSelection) []*Unit {
+	players := ai.Target.Env.Raid.AllPlayerUnits
+	switch selection {
+	case proto.TargetAbility_OffTank:
+		if offTank := ai.Target.OffTank(); offTank != nil {
+			return []*Unit{offTank}
+		}
+		return nil
+	case proto.TargetAbility_RandomPlayer:
+		if len(players) == 0 {
+			return nil
+		}
+		idx := int(sim.RandomFloat("Scripted Ability Target") * float64(len(players)))
+		return []*Unit{players[min(idx, len(players)-1)]}
+	case proto.TargetAbility_AllPlayers:
+		return players
+	default:
+		if ai.Target.CurrentTarget == nil {
+			return nil
+		}
+		return []*Unit{ai.Target.CurrentTarget}
+	}
+}
+
+func (ai *ScriptedAI) Reset(sim *Simulation) {
+	for _, ability := range ai.Abilities {
+		if ability.initialDelay > 0 {
+			ability.Spell.CD.Set(ability.initialDelay)
+		}
+	}
+}
+
+func (ai *ScriptedAI) ExecuteCustomRotation(sim *Simulation) {
+	// Casts resume the rotation once they complete.
+	if !ai.Target.GCD.IsReady(sim) {
+		return
+	}
+
+	for _, ability := range ai.Abilities {
+		if !ability.Spell.IsReady(sim) {
+			continue
+		}
+		ability.Spell.Cast(sim, ai.Target.CurrentTarget)
+		if !ai.Target.GCD.IsReady(sim) {
+			return
+		}
+	}
+
+	waitUntil := NeverExpires
+	for _, ability := range ai.Abilities {
+		waitUntil = min(waitUntil, ability.Spell.ReadyAt())
+	}
+	if waitUntil != NeverExpires {
+		ai.Target.WaitUntil(sim, max(waitUntil, sim.CurrentTime))
+	}
+}
+
+// Publishes the abilities that hit tanks as damage spikes.
+func (ai *ScriptedAI) DamageSpikes(sim *Simulation) []DamageSpike {
+	spikes := ai.damageSpikes[:0]
+	for _, ability := range ai.Abilities {
+		if ability.Config.MaxDamage == 0 {
+			continue
+		}
+		spike := DamageSpike{
+			Spell:  ability.Spell,
+			Damage: ability.Config.MaxDamage,
+			NextAt: max(ability.Spell.ReadyAt(), sim.CurrentTime),
+		}
+		switch ability.Config.TargetSelection {
+		case proto.TargetAbility_CurrentTarget:
+		case proto.TargetAbility_OffTank:
+			if spike.Target = ai.Target.OffTank(); spike.Target == nil {
+				continue
+			}
+		default:
+			continue
+		}
+		spikes = append(spikes, spike)
+	}
+	ai.damageSpikes = spikes
+	return spikes
+}
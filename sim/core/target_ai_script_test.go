@@ -0,0 +1,83 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func scriptedAbilitiesRequest(abilities ...*proto.TargetAbility) *proto.RaidSimRequest {
+	raid := SinglePlayerRaidProto(&proto.Player{
+		Name:      "Caster",
+		Class:     proto.Class_ClassShaman,
+		Consumes:  &proto.Consumes{},
+		Buffs:     &proto.IndividualBuffs{},
+		Spec:      &proto.Player_ElementalShaman{},
+		Equipment: &proto.EquipmentSpec{},
+	}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+	raid.Tanks = []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 0}}
+	return &proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid:       raid,
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 88, Abilities: abilities}},
+			Duration: 10,
+		},
+	}
+}
+
+func TestScriptedAbilitiesHitTank(t *testing.T) {
+	nuke := &proto.TargetAbility{Id: ActionID{SpellID: 2001}.ToProto(), School: proto.SpellSchool_SpellSchoolShadow, MinDamage: 1000, MaxDamage: 1000}
+	dot := &proto.TargetAbility{Id: ActionID{SpellID: 2002}.ToProto(), School: proto.SpellSchool_SpellSchoolNature, Cooldown: 60,
+		DotTickDamage: 100, DotTickInterval: 2, DotNumTicks: 3}
+	debuff := &proto.TargetAbility{Id: ActionID{SpellID: 2003}.ToProto(), School: proto.SpellSchool_SpellSchoolFire, Cooldown: 2,
+		DebuffDuration: 30, DebuffMaxStacks: 2, DebuffDamageTakenPerStack: 0.1, DebuffHealingTakenReductionPerStack: 0.25}
+
+	sim := NewSim(scriptedAbilitiesRequest(nuke, dot, debuff))
+	player := sim.Raid.AllPlayerUnits[0]
+	ai := sim.Encounter.Targets[0].AI.(*ScriptedAI)
+
+	sim.reset()
+	sim.PrePull()
+	for sim.CurrentTime < time.Second*5 {
+		sim.Step()
+	}
+	debuffAura := ai.Abilities[2].debuffs.Get(player)
+	if debuffAura.GetStacks() != 2 {
+		t.Fatalf("Expected the debuff to stack to its max of 2, got %d", debuffAura.GetStacks())
+	}
+	if !WithinToleranceFloat64(0.5, player.PseudoStats.HealingTakenMultiplier, 0.0001) ||
+		!WithinToleranceFloat64(1.2, player.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexFire], 0.0001) {
+		t.Fatalf("Expected 2 stacks to reduce healing taken by 50%% and increase fire damage taken by 20%%")
+	}
+
+	sim.runPendingActions()
+	sim.Cleanup()
+	if player.PseudoStats.HealingTakenMultiplier != 1 {
+		t.Fatalf("Expected healing taken to be restored when the debuff expires, got %0.3f", player.PseudoStats.HealingTakenMultiplier)
+	}
+
+	// Without a cooldown, the nuke is used every 1.62s: at 0, 1.62, ..., 9.72s.
+	nukeMetrics := ai.Abilities[0].Spell.SpellMetrics[player.UnitIndex]
+	if nukeMetrics.Casts != 7 || nukeMetrics.TotalDamage != 7000 {
+		t.Fatalf("Expected 7 nukes for 7000 damage, got %d for %0.1f", nukeMetrics.Casts, nukeMetrics.TotalDamage)
+	}
+	if dotDamage := ai.Abilities[1].Spell.SpellMetrics[player.UnitIndex].TotalDamage; dotDamage != 300 {
+		t.Fatalf("Expected 3 ticks of 100 damage, got %0.1f", dotDamage)
+	}
+}
+
+func TestScriptedAbilityValidation(t *testing.T) {
+	for _, ability := range []*proto.TargetAbility{
+		{Id: ActionID{SpellID: 2002}.ToProto(), DotTickDamage: 100, DotNumTicks: 3},
+		{Id: ActionID{SpellID: 2003}.ToProto(), DebuffDuration: 30, DebuffMaxStacks: 4, DebuffHealingTakenReductionPerStack: 0.25},
+	} {
+		result := RunRaidSim(scriptedAbilitiesRequest(ability))
+		if !strings.HasPrefix(result.ErrorResult, "[USER_ERROR]") {
+			t.Fatalf("Expected a user error for %v, got %q", ability, result.ErrorResult)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func setupFakeHealthFight() *Simulation {
+	targets := make([]*proto.Target, 3)
+	for i := range targets {
+		targetStats := stats.Stats{stats.Health: 1000}
+		targets[i] = &proto.Target{Name: "target", Level: 83, Stats: targetStats[:], OptionalKill: i == 2}
+	}
+
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets:   targets,
+			UseHealth: true,
+		},
+	})
+	sim.Reset()
+
+	return sim
+}
+
+func TestHealthFightTargetDeath(t *testing.T) {
+	sim := setupFakeHealthFight()
+	player := sim.Raid.AllPlayerUnits[0]
+	first, second, optional := sim.Encounter.Targets[0], sim.Encounter.Targets[1], sim.Encounter.Targets[2]
+
+	if sim.Encounter.RequiredHealth != 2000 {
+		t.Fatalf("Expected required health of 2000, got %0.0f", sim.Encounter.RequiredHealth)
+	}
+
+	var phases []int32
+	first.RegisterExecutePhaseCallback(func(_ *Simulation, _ *Target, executePhase int32) {
+		phases = append(phases, executePhase)
+	})
+
+	first.takeDamage(sim, 700)
+	if !slices.Equal(phases, []int32{90, 35}) {
+		t.Fatalf("Expected execute phases [90 35] at 30%% health, got %v", phases)
+	}
+	if sim.ExecutePhaseOf(&first.Unit) != 35 || sim.ExecutePhaseOf(&second.Unit) != 100 {
+		t.Fatalf("Execute phases should follow each target's own health")
+	}
+	if !sim.IsTargetExecutePhase35(&first.Unit) || sim.IsTargetExecutePhase35(&second.Unit) {
+		t.Fatalf("Target execute checks should follow each target's own health")
+	}
+
+	// AOE spells looping over the active targets still reach every target when
+	// one of them dies part way through.
+	aoeTargets := sim.Encounter.ActiveTargetUnits
+	first.takeDamage(sim, 500)
+	if !slices.Equal(aoeTargets, []*Unit{&first.Unit, &second.Unit, &optional.Unit}) {
+		t.Fatalf("Expected a target dying not to change an AOE loop in progress")
+	}
+	if !slices.Equal(phases, []int32{90, 35, 25, 20}) {
+		t.Fatalf("Expected all execute phases once dead, got %v", phases)
+	}
+	if !first.IsDead() || sim.GetNumTargets() != 2 {
+		t.Fatalf("Expected the first target to die, leaving 2 targets, got %d", sim.GetNumTargets())
+	}
+	if player.CurrentTarget != &second.Unit {
+		t.Fatalf("Expected the player to switch to the next living target")
+	}
+	if optional.NextTarget() != second {
+		t.Fatalf("Expected NextTarget to skip dead targets")
+	}
+
+	second.takeDamage(sim, 1000)
+	if !sim.Encounter.requiredTargetsDead() {
+		t.Fatalf("Expected the fight to end once all required targets are dead")
+	}
+	if sim.Encounter.DamageTaken != 2000 {
+		t.Fatalf("Expected overkill not to count towards damage taken, got %0.0f", sim.Encounter.DamageTaken)
+	}
+
+	sim.Reset()
+	if sim.GetNumTargets() != 3 || player.CurrentTarget != &first.Unit || first.CurrentHealth() != 1000 {
+		t.Fatalf("Expected targets and retargeting to be restored on reset")
+	}
+}
@@ -0,0 +1,314 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+const (
+	// How much more threat than the current target a tank needs to pull aggro.
+	threatPullThreshold = 1.1
+
+	// How long a taunted target is forced to attack the taunter.
+	tauntDuration = time.Second * 3
+
+	// How often targets with several tanks check their threat table.
+	threatUpdateInterval = time.Second
+
+	// Vengeance gives tanks attack power from the damage they take, capped at a
+	// fraction of their health, and decays once they stop taking damage.
+	vengeanceDamageRatio   = 0.05
+	vengeanceHealthCap     = 0.1
+	vengeanceDuration      = time.Second * 20
+	vengeanceDecayInterval = time.Second * 2
+	vengeanceDecayRatio    = 0.1
+)
+
+// Taunt spell for each class that can tank.
+var tauntSpellIDs = map[proto.Class]int32{
+	proto.Class_ClassWarrior:     355,
+	proto.Class_ClassPaladin:     62124,
+	proto.Class_ClassDeathKnight: 56222,
+	proto.Class_ClassDruid:       6795,
+}
+
+// Resolves Raid.tanks for each target. The tank at the target's tank_index is
+// its main tank, and the other tanks are off-tanks that can take the target
+// over through threat or taunts.
+func (env *Environment) assignTanks(raidProto *proto.Raid, encounterProto *proto.Encounter) {
+	var tanks []*Unit
+	for _, tankProto := range raidProto.Tanks {
+		if tank := env.GetUnit(tankProto, nil); tank != nil && !unitInList(tank, tanks) {
+			tanks = append(tanks, tank)
+		}
+	}
+
+	for _, target := range env.Encounter.Targets {
+		if target.Index >= int32(len(encounterProto.Targets)) {
+			continue
+		}
+		targetProto := encounterProto.Targets[target.Index]
+		if targetProto.TankIndex < 0 || targetProto.TankIndex >= int32(len(raidProto.Tanks)) || raidProto.Tanks[targetProto.TankIndex] == nil {
+			continue
+		}
+		mainTank := env.GetUnit(raidProto.Tanks[targetProto.TankIndex], nil)
+		if mainTank == nil {
+			continue
+		}
+
+		target.CurrentTarget = mainTank
+		target.Tanks = []*Unit{mainTank}
+		for _, tank := range tanks {
+			if tank != mainTank {
+				target.Tanks = append(target.Tanks, tank)
+			}
+		}
+		target.threat = make([]float64, len(target.Tanks))
+	}
+}
+
+// Whether the unit is one of the tanks of any target.
+func (unit *Unit) IsTanking() bool {
+	for _, target := range unit.Env.Encounter.Targets {
+		if unitInList(unit, target.Tanks) {
+			return true
+		}
+	}
+	return false
+}
+
+func unitInList(unit *Unit, units []*Unit) bool {
+	for _, u := range units {
+		if u == unit {
+			return true
+		}
+	}
+	return false
+}
+
+// Registers the taunt spell and Vengeance of every tank, so they can be used
+// for tank swaps.
+func (env *Environment) registerTankSpells() {
+	for _, target := range env.Encounter.Targets {
+		for _, tank := range target.Tanks {
+			if agent := env.Raid.GetPlayerFromUnit(tank); agent != nil {
+				agent.GetCharacter().registerTauntSpell()
+				agent.GetCharacter().registerVengeance()
+			}
+		}
+	}
+}
+
+func (character *Character) registerTauntSpell() {
+	spellID, ok := tauntSpellIDs[character.Class]
+	if !ok {
+		return
+	}
+
+	character.GetOrRegisterSpell(SpellConfig{
+		ActionID: ActionID{SpellID: spellID},
+		ProcMask: ProcMaskEmpty,
+		Flags:    SpellFlagNoOnCastComplete,
+
+		Cast: CastConfig{
+			CD: Cooldown{
+				Timer:    character.NewTimer(),
+				Duration: time.Second * 8,
+			},
+		},
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			if target.Type == EnemyUnit {
+				spell.Unit.Env.GetTarget(target.Index).Taunt(sim, spell.Unit)
+			}
+		},
+	})
+}
+
+func (character *Character) registerVengeance() {
+	if _, ok := tauntSpellIDs[character.Class]; !ok || character.GetAura("Vengeance") != nil {
+		return
+	}
+
+	var bonus float64
+	var lastDamageAt time.Duration
+	var decay *PendingAction
+	setBonus := func(sim *Simulation, newBonus float64) {
+		character.AddStatDynamic(sim, stats.AttackPower, newBonus-bonus)
+		bonus = newBonus
+	}
+
+	vengeanceAura := character.RegisterAura(Aura{
+		Label:    "Vengeance",
+		ActionID: ActionID{SpellID: 76691},
+		Duration: vengeanceDuration,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			decay = StartPeriodicAction(sim, PeriodicActionOptions{
+				Period: vengeanceDecayInterval,
+				OnAction: func(sim *Simulation) {
+					if sim.CurrentTime-lastDamageAt < vengeanceDecayInterval {
+						return
+					}
+					if newBonus := bonus - vengeanceDecayRatio*vengeanceHealthCap*character.MaxHealth(); newBonus > 0 {
+						setBonus(sim, newBonus)
+					} else {
+						aura.Deactivate(sim)
+					}
+				},
+			})
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			decay.Cancel(sim)
+			setBonus(sim, 0)
+		},
+	})
+
+	onDamageTaken := func(sim *Simulation, result *SpellResult) {
+		if result.Damage <= 0 {
+			return
+		}
+		lastDamageAt = sim.CurrentTime
+		vengeanceAura.Activate(sim)
+		setBonus(sim, min(bonus+vengeanceDamageRatio*result.Damage, vengeanceHealthCap*character.MaxHealth()))
+	}
+	MakePermanent(character.RegisterAura(Aura{
+		Label: "Vengeance Trigger",
+		OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			onDamageTaken(sim, result)
+		},
+		OnPeriodicDamageTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			onDamageTaken(sim, result)
+		},
+	}))
+}
+
+// Returns the taunt spell of the unit, or nil if it cannot taunt.
+func (unit *Unit) TauntSpell() *Spell {
+	if unit.Type != PlayerUnit {
+		return nil
+	}
+	character := unit.Env.Raid.GetPlayerFromUnit(unit).GetCharacter()
+	spellID, ok := tauntSpellIDs[character.Class]
+	if !ok {
+		return nil
+	}
+	return unit.GetSpell(ActionID{SpellID: spellID})
+}
+
+func (target *Target) tankIndex(unit *Unit) int {
+	for i, tank := range target.Tanks {
+		if tank == unit {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the threat a tank has on this target, including threat from taunts.
+func (target *Target) ThreatOf(unit *Unit) float64 {
+	if idx := target.tankIndex(unit); idx != -1 {
+		return target.threat[idx]
+	}
+	return 0
+}
+
+// Adds threat the unit generated against target to the target's threat table.
+func (unit *Unit) addThreat(target *Unit, threat float64) {
+	if target.Type != EnemyUnit {
+		return
+	}
+	enemy := unit.Env.GetTarget(target.Index)
+	if idx := enemy.tankIndex(unit); idx != -1 {
+		enemy.threat[idx] += threat
+	}
+}
+
+// Returns the tank with the most threat that this target is not attacking, for
+// abilities that hit the off-tank. Returns nil if there is no off-tank.
+func (target *Target) OffTank() *Unit {
+	var offTank *Unit
+	maxThreat := -1.0
+	for _, tank := range target.Tanks {
+		if tank == target.CurrentTarget {
+			continue
+		}
+		if threat := target.ThreatOf(tank); threat > maxThreat {
+			offTank = tank
+			maxThreat = threat
+		}
+	}
+	return offTank
+}
+
+// Forces this target to attack unit for a few seconds, and raises unit's threat
+// to that of the highest threat tank.
+func (target *Target) Taunt(sim *Simulation, unit *Unit) {
+	idx := target.tankIndex(unit)
+	if idx == -1 {
+		return
+	}
+
+	maxThreat := 0.0
+	for _, tank := range target.Tanks {
+		maxThreat = max(maxThreat, target.ThreatOf(tank))
+	}
+	if threat := target.ThreatOf(unit); threat < maxThreat {
+		target.threat[idx] += maxThreat - threat
+	}
+
+	target.tauntExpiresAt = sim.CurrentTime + tauntDuration
+	target.changeThreatTarget(sim, unit)
+}
+
+func (target *Target) changeThreatTarget(sim *Simulation, unit *Unit) {
+	if target.CurrentTarget == unit {
+		return
+	}
+	if sim.Log != nil {
+		target.Log(sim, "Changing target from %s to %s", target.CurrentTarget.Label, unit.Label)
+	}
+	target.CurrentTarget = unit
+}
+
+// Switches to the tank at the top of the threat table, if it has pulled aggro.
+func (target *Target) updateThreatTarget(sim *Simulation) {
+	if sim.CurrentTime < target.tauntExpiresAt {
+		return
+	}
+
+	var newTarget *Unit
+	pullThreat := target.ThreatOf(target.CurrentTarget) * threatPullThreshold
+	for _, tank := range target.Tanks {
+		if tank == target.CurrentTarget {
+			continue
+		}
+		if threat := target.ThreatOf(tank); threat > pullThreat {
+			newTarget = tank
+			pullThreat = threat
+		}
+	}
+	if newTarget != nil {
+		target.changeThreatTarget(sim, newTarget)
+	}
+}
+
+func (target *Target) resetThreat(sim *Simulation) {
+	for i := range target.threat {
+		target.threat[i] = 0
+	}
+	if len(target.Tanks) < 2 {
+		return
+	}
+
+	target.tauntExpiresAt = 0
+	target.CurrentTarget = target.Tanks[0]
+
+	StartPeriodicAction(sim, PeriodicActionOptions{
+		Period: threatUpdateInterval,
+		OnAction: func(sim *Simulation) {
+			target.updateThreatTarget(sim)
+		},
+	})
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func TestThreatTargetSelection(t *testing.T) {
+	sim := &Simulation{}
+
+	mainTank := &Unit{Type: PlayerUnit, Label: "MT"}
+	offTank := &Unit{Type: PlayerUnit, Label: "OT"}
+
+	target := &Target{
+		Unit:   Unit{Type: EnemyUnit, CurrentTarget: mainTank},
+		Tanks:  []*Unit{mainTank, offTank},
+		threat: []float64{1000, 1050},
+	}
+
+	// The off-tank needs more than 110% of the main tank's threat to pull aggro.
+	target.updateThreatTarget(sim)
+	if target.CurrentTarget != mainTank {
+		t.Fatalf("off-tank pulled aggro without 110%% threat")
+	}
+	if target.OffTank() != offTank {
+		t.Fatalf("expected OT to be the off-tank")
+	}
+
+	target.threat[1] = 1200
+	target.updateThreatTarget(sim)
+	if target.CurrentTarget != offTank {
+		t.Fatalf("off-tank did not pull aggro with 120%% threat")
+	}
+
+	// Taunting matches the top threat and forces the target for a few seconds.
+	target.Taunt(sim, mainTank)
+	if target.CurrentTarget != mainTank || target.ThreatOf(mainTank) != 1200 {
+		t.Fatalf("taunt did not take over the target, threat %0.1f", target.ThreatOf(mainTank))
+	}
+
+	target.threat[1] = 2000
+	sim.CurrentTime = time.Second
+	target.updateThreatTarget(sim)
+	if target.CurrentTarget != mainTank {
+		t.Fatalf("target changed while taunted")
+	}
+	sim.CurrentTime = tauntDuration
+	target.updateThreatTarget(sim)
+	if target.CurrentTarget != offTank {
+		t.Fatalf("target did not change after the taunt expired")
+	}
+}
+
+func TestThreatAndVengeance(t *testing.T) {
+	raid := SinglePlayerRaidProto(&proto.Player{
+		Name:      "Tank",
+		Race:      proto.Race_RaceHuman,
+		Class:     proto.Class_ClassWarrior,
+		Consumes:  &proto.Consumes{},
+		Buffs:     &proto.IndividualBuffs{},
+		Spec:      &proto.Player_ElementalShaman{},
+		Equipment: &proto.EquipmentSpec{},
+	}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+	raid.Tanks = []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 0}}
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid:       raid,
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{{Name: "target", Level: 88, Abilities: []*proto.TargetAbility{{
+				Id:        ActionID{SpellID: 2001}.ToProto(),
+				School:    proto.SpellSchool_SpellSchoolShadow,
+				MinDamage: 1e6,
+				MaxDamage: 1e6,
+				Cooldown:  60,
+			}}}},
+			Duration: 10,
+		},
+	})
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.Targets[0]
+	if !fa.IsTanking() {
+		t.Fatalf("Expected the player in Raid.tanks to be tanking")
+	}
+
+	sim.reset()
+	baseAttackPower := fa.GetStat(stats.AttackPower)
+	sim.PrePull()
+	fa.Spell.Cast(sim, fa.CurrentTarget)
+	for sim.CurrentTime < time.Second {
+		sim.Step()
+	}
+
+	// A single large hit fills Vengeance to 10% of the tank's health.
+	vengeance := fa.GetAura("Vengeance")
+	attackPower := fa.GetStat(stats.AttackPower)
+	vengeanceCap := 0.1 * fa.MaxHealth()
+	if vengeance == nil || !vengeance.IsActive() || vengeanceCap <= 0 || attackPower-baseAttackPower != vengeanceCap {
+		t.Fatalf("Expected taking damage to give %0.1f attack power from Vengeance, got %0.1f", vengeanceCap, attackPower-baseAttackPower)
+	}
+
+	// Without more damage taken, it decays by 10% of the cap every 2s.
+	for sim.CurrentTime < time.Second*5 {
+		sim.Step()
+	}
+	if decayed := attackPower - fa.GetStat(stats.AttackPower); !WithinToleranceFloat64(0.2*vengeanceCap, decayed, 0.01) {
+		t.Fatalf("Expected Vengeance to decay by %0.1f after 4s, got %0.1f", 0.2*vengeanceCap, decayed)
+	}
+
+	// The threat table follows the threat metrics of the tank's spells.
+	if threat := fa.Spell.SpellMetrics[target.UnitIndex].TotalThreat; threat <= 0 || target.ThreatOf(&fa.Unit) != threat {
+		t.Fatalf("Expected a threat of %0.1f, got %0.1f", threat, target.ThreatOf(&fa.Unit))
+	}
+}
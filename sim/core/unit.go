@@ -61,6 +61,9 @@ type Unit struct {
 	// Amount of time following a post-GCD channel tick, to when the next action can be performed.
 	ChannelClipDelay time.Duration
 
+	// Delays of a human player executing the rotation, nil for perfect play.
+	playerModel *playerModel
+
 	// How far this unit is from its target(s). Measured in yards, this is used
 	// for calculating spell travel time for certain spells.
 	DistanceFromTarget float64
@@ -138,6 +141,9 @@ type Unit struct {
 	AttackTables                []*AttackTable
 	DynamicDamageTakenModifiers []DynamicDamageTakenModifier
 
+	// Shields currently on this unit, in the order they absorb damage.
+	activeShields []*Shield
+
 	GCD *Timer
 
 	// Separate from GCD timer to support spell queueing and off-GCD actions
@@ -545,6 +551,7 @@ func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	unit.statsWithoutDeps = unit.initialStatsWithoutDeps
 	unit.stats = unit.initialStats
 	unit.PseudoStats = unit.initialPseudoStats
+	unit.activeShields = unit.activeShields[:0]
 	unit.auraTracker.reset(sim)
 	for _, spell := range unit.Spellbook {
 		spell.reset(sim)
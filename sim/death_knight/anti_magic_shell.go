@@ -11,10 +11,7 @@ import (
 func (dk *DeathKnight) registerAntiMagicShellSpell() {
 	actionID := core.ActionID{SpellID: 48707}
 
-	currentShield := 0.0
-
-	var shieldSpell *core.Spell
-	shieldSpell = dk.RegisterSpell(core.SpellConfig{
+	shieldSpell := dk.RegisterSpell(core.SpellConfig{
 		ActionID:    actionID,
 		ProcMask:    core.ProcMaskSpellHealing,
 		SpellSchool: core.SpellSchoolShadow,
@@ -34,36 +31,18 @@ func (dk *DeathKnight) registerAntiMagicShellSpell() {
 		ThreatMultiplier: 1,
 
 		Shield: core.ShieldConfig{
-			SelfOnly: true,
+			SelfOnly:       true,
+			School:         core.SpellSchoolMagic,
+			AbsorbFraction: 0.75,
 			Aura: core.Aura{
 				Label:    "Anti-Magic Shell",
 				ActionID: actionID,
 				Duration: time.Second*5 + core.TernaryDuration(dk.HasMajorGlyph(proto.DeathKnightMajorGlyph_GlyphOfAntiMagicShell), 2*time.Second, 0),
-
-				OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-					if spell.SpellSchool.Matches(core.SpellSchoolPhysical) {
-						return
-					}
-
-					if currentShield <= 0 || result.Damage <= 0 {
-						return
-					}
-
-					damageReduced := min(result.Damage, currentShield)
-					currentShield -= damageReduced
-
-					dk.GainHealth(sim, damageReduced, shieldSpell.HealthMetrics(result.Target))
-
-					if currentShield <= 0 {
-						shieldSpell.SelfShield().Deactivate(sim)
-					}
-				},
 			},
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			currentShield = dk.MaxHealth() * 0.5
-			spell.SelfShield().Apply(sim, currentShield)
+			spell.SelfShield().Apply(sim, dk.MaxHealth()*0.5)
 		},
 	})
 
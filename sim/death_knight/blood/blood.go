@@ -94,7 +94,6 @@ func (bdk *BloodDeathKnight) ApplyTalents() {
 
 	// Mastery: Blood Shield
 	shieldAmount := 0.0
-	currentShield := 0.0
 	shieldSpell := bdk.GetOrRegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 77535},
 		ProcMask:    core.ProcMaskSpellHealing,
@@ -105,6 +104,7 @@ func (bdk *BloodDeathKnight) ApplyTalents() {
 
 		Shield: core.ShieldConfig{
 			SelfOnly: true,
+			School:   core.SpellSchoolPhysical,
 			Aura: core.Aura{
 				Label:    "Blood Shield",
 				Duration: core.NeverExpires,
@@ -112,34 +112,15 @@ func (bdk *BloodDeathKnight) ApplyTalents() {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			if currentShield < bdk.MaxHealth() {
-				shieldAmount = min(shieldAmount, bdk.MaxHealth()-currentShield)
-				currentShield += shieldAmount
-				spell.SelfShield().Apply(sim, shieldAmount)
-			}
+			// Blood Shield stacks up to the DK's max health.
+			shield := spell.SelfShield()
+			shield.MaxAbsorb = bdk.MaxHealth()
+			shield.Apply(sim, shieldAmount)
 		},
 	})
 	core.MakePermanent(bdk.GetOrRegisterAura(core.Aura{
 		Label:    "Mastery: Blood Shield",
 		ActionID: core.ActionID{SpellID: 77513},
-		OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if !spell.SpellSchool.Matches(core.SpellSchoolPhysical) {
-				return
-			}
-
-			if currentShield <= 0 || result.Damage <= 0 {
-				return
-			}
-
-			damageReduced := min(result.Damage, currentShield)
-			currentShield -= damageReduced
-
-			bdk.GainHealth(sim, damageReduced, shieldSpell.HealthMetrics(result.Target))
-
-			if currentShield <= 0 {
-				shieldSpell.SelfShield().Deactivate(sim)
-			}
-		},
 		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
 			if spell.ClassSpellMask&death_knight.DeathKnightSpellDeathStrikeHeal == 0 {
 				return
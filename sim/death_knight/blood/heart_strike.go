@@ -8,8 +8,7 @@ import (
 var HeartStrikeActionID = core.ActionID{SpellID: 55050}
 
 func (dk *BloodDeathKnight) registerHeartStrikeSpell() {
-	numHits := min(3, dk.Env.GetNumTargets())
-	results := make([]*core.SpellResult, numHits)
+	results := make([]*core.SpellResult, 3)
 
 	dk.GetOrRegisterSpell(core.SpellConfig{
 		ActionID:       HeartStrikeActionID,
@@ -38,6 +37,7 @@ func (dk *BloodDeathKnight) registerHeartStrikeSpell() {
 			baseDamage := dk.ClassSpellScaling*0.72799998522 +
 				spell.Unit.MHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
 
+			numHits := min(3, sim.Environment.GetNumTargets())
 			currentTarget := target
 			for idx := int32(0); idx < numHits; idx++ {
 				targetDamage := baseDamage * dk.GetDiseaseMulti(currentTarget, 1.0, 0.15)
@@ -51,7 +51,7 @@ func (dk *BloodDeathKnight) registerHeartStrikeSpell() {
 				currentTarget = dk.Env.NextTargetUnit(currentTarget)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:numHits] {
 				spell.DealDamage(sim, result)
 				spell.DamageMultiplier /= 0.75
 			}
@@ -60,8 +60,7 @@ func (dk *BloodDeathKnight) registerHeartStrikeSpell() {
 }
 
 func (dk *BloodDeathKnight) registerDrwHeartStrikeSpell() *core.Spell {
-	numHits := min(3, dk.Env.GetNumTargets())
-	results := make([]*core.SpellResult, numHits)
+	results := make([]*core.SpellResult, 3)
 	return dk.RuneWeapon.RegisterSpell(core.SpellConfig{
 		ActionID:    HeartStrikeActionID,
 		SpellSchool: core.SpellSchoolPhysical,
@@ -72,6 +71,7 @@ func (dk *BloodDeathKnight) registerDrwHeartStrikeSpell() *core.Spell {
 			baseDamage := dk.ClassSpellScaling*0.72799998522 +
 				spell.Unit.MHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
 
+			numHits := min(3, sim.Environment.GetNumTargets())
 			currentTarget := target
 			for idx := int32(0); idx < numHits; idx++ {
 				targetDamage := baseDamage * dk.RuneWeapon.GetDiseaseMulti(currentTarget, 1.0, 0.15)
@@ -82,7 +82,7 @@ func (dk *BloodDeathKnight) registerDrwHeartStrikeSpell() *core.Spell {
 				currentTarget = dk.Env.NextTargetUnit(currentTarget)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:numHits] {
 				spell.DealDamage(sim, result)
 				spell.DamageMultiplier /= 0.75
 			}
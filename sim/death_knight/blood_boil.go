@@ -8,7 +8,7 @@ var BloodBoilActionID = core.ActionID{SpellID: 48721}
 
 func (dk *DeathKnight) registerBloodBoilSpell() {
 	rpMetric := dk.NewRunicPowerMetrics(BloodBoilActionID)
-	results := make([]*core.SpellResult, dk.Env.GetNumTargets())
+	results := make([]*core.SpellResult, len(dk.Env.Encounter.TargetUnits))
 	dk.RegisterSpell(core.SpellConfig{
 		ActionID:       BloodBoilActionID,
 		Flags:          core.SpellFlagAPL,
@@ -31,7 +31,8 @@ func (dk *DeathKnight) registerBloodBoilSpell() {
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			anyHit := false
-			for idx, aoeTarget := range sim.Encounter.TargetUnits {
+			targets := sim.Encounter.ActiveTargetUnits
+			for idx, aoeTarget := range targets {
 				baseDamage := dk.ClassSpellScaling*0.31700000167 + 0.08*spell.MeleeAttackPower()
 				baseDamage *= core.TernaryFloat64(dk.DiseasesAreActive(aoeTarget), 1.5, 1.0)
 				baseDamage *= sim.Encounter.AOECapMultiplier()
@@ -44,7 +45,7 @@ func (dk *DeathKnight) registerBloodBoilSpell() {
 				dk.AddRunicPower(sim, 10, rpMetric)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:len(targets)] {
 				spell.DealDamage(sim, result)
 			}
 		},
@@ -52,14 +53,15 @@ func (dk *DeathKnight) registerBloodBoilSpell() {
 }
 
 func (dk *DeathKnight) registerDrwBloodBoilSpell() *core.Spell {
-	results := make([]*core.SpellResult, dk.Env.GetNumTargets())
+	results := make([]*core.SpellResult, len(dk.Env.Encounter.TargetUnits))
 	return dk.RuneWeapon.RegisterSpell(core.SpellConfig{
 		ActionID:    BloodBoilActionID,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for idx, aoeTarget := range sim.Encounter.TargetUnits {
+			targets := sim.Encounter.ActiveTargetUnits
+			for idx, aoeTarget := range targets {
 				baseDamage := dk.ClassSpellScaling*0.31700000167 + 0.08*spell.MeleeAttackPower()
 				baseDamage *= core.TernaryFloat64(dk.RuneWeapon.DiseasesAreActive(aoeTarget), 1.5, 1.0)
 				baseDamage *= sim.Encounter.AOECapMultiplier()
@@ -67,7 +69,7 @@ func (dk *DeathKnight) registerDrwBloodBoilSpell() *core.Spell {
 				results[idx] = spell.CalcDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:len(targets)] {
 				spell.DealDamage(sim, result)
 			}
 		},
@@ -40,9 +40,10 @@ func (dk *DeathKnight) registerBoneShieldSpell() {
 	})
 
 	spell := dk.RegisterSpell(core.SpellConfig{
-		ActionID:       actionID,
-		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
-		ClassSpellMask: DeathKnightSpellBoneShield,
+		ActionID:        actionID,
+		RelatedSelfBuff: aura,
+		Flags:           core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
+		ClassSpellMask:  DeathKnightSpellBoneShield,
 
 		RuneCost: core.RuneCostOptions{
 			UnholyRuneCost: 1,
@@ -27,9 +27,10 @@ func (dk *DeathKnight) registerIceboundFortitudeSpell() {
 	})
 
 	spell := dk.RegisterSpell(core.SpellConfig{
-		ActionID:       actionID,
-		Flags:          core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
-		ClassSpellMask: DeathKnightSpellIceboundFortitude,
+		ActionID:        actionID,
+		RelatedSelfBuff: aura,
+		Flags:           core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
+		ClassSpellMask:  DeathKnightSpellIceboundFortitude,
 
 		RuneCost: core.RuneCostOptions{
 			RunicPowerCost: 20,
@@ -108,7 +108,7 @@ func (dk *DeathKnight) applyMercilessCombat() {
 		Callback:       core.CallbackOnApplyEffects,
 		ClassSpellMask: DeathKnightSpellMercilessCombat,
 		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if sim.IsExecutePhase35() {
+			if sim.IsTargetExecutePhase35(result.Target) {
 				debuffs.Get(result.Target).Activate(sim)
 			}
 		},
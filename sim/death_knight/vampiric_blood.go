@@ -42,8 +42,9 @@ func (dk *DeathKnight) registerVampiricBloodSpell() {
 	})
 
 	spell := dk.RegisterSpell(core.SpellConfig{
-		ActionID:       actionID,
-		ClassSpellMask: DeathKnightSpellVampiricBlood,
+		ActionID:        actionID,
+		RelatedSelfBuff: aura,
+		ClassSpellMask:  DeathKnightSpellVampiricBlood,
 
 		Cast: core.CastConfig{
 			CD: core.Cooldown{
@@ -380,7 +380,7 @@ func (cat *FeralDruid) doRotation(sim *core.Simulation) (bool, time.Duration) {
 	lacerateDot := cat.Lacerate.CurDot()
 	isBleedActive := cat.AssumeBleedActive || ripDot.IsActive() || rakeDot.IsActive() || lacerateDot.IsActive()
 	regenRate := cat.EnergyRegenPerSecond()
-	isExecutePhase := rotation.BiteDuringExecute && sim.IsExecutePhase25()
+	isExecutePhase := rotation.BiteDuringExecute && sim.IsTargetExecutePhase25(cat.CurrentTarget)
 	tfActive := cat.TigersFuryAura.IsActive()
 
 	// Prioritize using Rip with omen procs if bleed isnt active
@@ -65,7 +65,7 @@ func (druid *Druid) registerFerociousBiteSpell() {
 				// Blood in the Water
 				ripDot := druid.Rip.Dot(target)
 
-				if sim.IsExecutePhase25() && ripDot.IsActive() && sim.Proc(ripRefreshChance, "Blood in the Water") {
+				if sim.IsTargetExecutePhase25(target) && ripDot.IsActive() && sim.Proc(ripRefreshChance, "Blood in the Water") {
 					ripDot.NumberOfTicks = RipBaseNumTicks
 					ripDot.Apply(sim)
 				}
@@ -35,7 +35,8 @@ func (druid *Druid) registerFrenziedRegenerationCD() {
 	})
 
 	druid.FrenziedRegeneration = druid.RegisterSpell(Bear, core.SpellConfig{
-		ActionID: actionID,
+		ActionID:        actionID,
+		RelatedSelfBuff: druid.FrenziedRegenerationAura,
 		Cast: core.CastConfig{
 			CD: core.Cooldown{
 				Timer:    cdTimer,
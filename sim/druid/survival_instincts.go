@@ -30,7 +30,8 @@ func (druid *Druid) registerSurvivalInstinctsCD() {
 	})
 
 	druid.SurvivalInstincts = druid.RegisterSpell(Cat|Bear, core.SpellConfig{
-		ActionID: actionID,
+		ActionID:        actionID,
+		RelatedSelfBuff: druid.SurvivalInstinctsAura,
 		Cast: core.CastConfig{
 			CD: core.Cooldown{
 				Timer:    cdTimer,
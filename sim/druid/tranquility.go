@@ -0,0 +1,83 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (druid *Druid) RegisterTranquilityCD() {
+	// Tranquility heals the raid, so it is only used when raid cooldowns are simulated.
+	if !druid.CastsRaidCooldowns() {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 740}
+	targets := druid.RaidCooldownTargets(core.RaidCooldownScopeRaid)
+
+	healSpell := druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:    actionID.WithTag(1),
+		SpellSchool: core.SpellSchoolNature,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   druid.DefaultHealingCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: 0.398,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, 3882, spell.OutcomeHealingCrit)
+		},
+	})
+
+	// Heals the 5 players lowest on health every 2s while channeled.
+	var pa *core.PendingAction
+	channelAura := druid.RegisterAura(core.Aura{
+		Label:    "Tranquility",
+		ActionID: actionID,
+		Duration: time.Second * 8,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			pa = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				NumTicks: 4,
+				OnAction: func(sim *core.Simulation) {
+					for _, unit := range core.LowestUnitsBy(targets, 5, (*core.Unit).CurrentHealthPercent) {
+						healSpell.Cast(sim, unit)
+					}
+				},
+			})
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			pa.Cancel(sim)
+		},
+	})
+
+	tranquility := druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID: actionID,
+		Flags:    core.SpellFlagHelpful | core.SpellFlagChanneled,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.32,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    druid.NewTimer(),
+				Duration: time.Minute*8 - time.Second*150*time.Duration(druid.Talents.MalfurionsGift),
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			channelAura.Activate(sim)
+			druid.WaitUntil(sim, channelAura.ExpiresAt())
+		},
+	})
+
+	druid.AddMajorCooldown(core.MajorCooldown{
+		Spell: tranquility.Spell,
+		Type:  core.CooldownTypeSurvival,
+	})
+}
@@ -42,11 +42,23 @@ func addPatchwerk25(bossPrefix string) {
 	})
 }
 
+// Patchwerk enrages below 5% health.
+const patchwerkFrenzyHealthPercent = 0.05
+
 type Patchwerk25AI struct {
 	Target *core.Target
 
 	HatefulStrike *core.Spell
 	Frenzy        *core.Spell
+	FrenzyAura    *core.Aura
+
+	swingDamage float64
+
+	// Hateful Strike hits the off-tank picked at the last boss swing.
+	offTank *core.Unit
+
+	// Reused by DamageSpikes.
+	damageSpikes []core.DamageSpike
 }
 
 func NewPatchwerk25AI() core.AIFactory {
@@ -57,12 +69,23 @@ func NewPatchwerk25AI() core.AIFactory {
 
 func (ai *Patchwerk25AI) Initialize(target *core.Target, config *proto.Target) {
 	ai.Target = target
+	ai.swingDamage = config.MinBaseDamage
+
+	ai.registerHatefulStrikeSpell(target)
+	ai.registerFrenzySpell(target)
 
-	//ai.registerHatefulStrikeSpell(target)
-	//ai.registerFrenzySpell(target)
+	core.MakePermanent(target.RegisterAura(core.Aura{
+		Label: "Hateful Strike Targeting",
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell.ProcMask.Matches(core.ProcMaskMeleeMHAuto) {
+				ai.offTank = ai.Target.OffTank()
+			}
+		},
+	}))
 }
 
 func (ai *Patchwerk25AI) Reset(*core.Simulation) {
+	ai.offTank = ai.Target.OffTank()
 }
 
 func (ai *Patchwerk25AI) registerHatefulStrikeSpell(target *core.Target) {
@@ -93,7 +116,7 @@ func (ai *Patchwerk25AI) registerHatefulStrikeSpell(target *core.Target) {
 
 func (ai *Patchwerk25AI) registerFrenzySpell(target *core.Target) {
 	actionID := core.ActionID{SpellID: 28131}
-	frenzyAura := target.GetOrRegisterAura(core.Aura{
+	ai.FrenzyAura = target.GetOrRegisterAura(core.Aura{
 		ActionID: actionID,
 		Label:    "Frenzy",
 		Duration: 5 * time.Minute,
@@ -119,30 +142,62 @@ func (ai *Patchwerk25AI) registerFrenzySpell(target *core.Target) {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			frenzyAura.Activate(sim)
+			ai.FrenzyAura.Activate(sim)
 		},
 	})
 }
 
+func (ai *Patchwerk25AI) DamageSpikes(sim *core.Simulation) []core.DamageSpike {
+	frenzyAt := sim.EstimatedTimeAtRemainingPercent(patchwerkFrenzyHealthPercent)
+	if ai.FrenzyAura.IsActive() {
+		frenzyAt = ai.FrenzyAura.StartedAt()
+	}
+	spikes := append(ai.damageSpikes[:0], core.DamageSpike{
+		Spell:    ai.Frenzy,
+		Damage:   ai.swingDamage * 1.25,
+		NextAt:   frenzyAt,
+		Duration: ai.FrenzyAura.Duration,
+		Aura:     ai.FrenzyAura,
+	})
+
+	if ai.offTank != nil {
+		spikes = append(spikes, core.DamageSpike{
+			Spell:  ai.HatefulStrike,
+			Target: ai.offTank,
+			Damage: 80000,
+			NextAt: max(ai.HatefulStrike.ReadyAt(), sim.CurrentTime),
+		})
+	}
+	ai.damageSpikes = spikes
+	return spikes
+}
+
 func (ai *Patchwerk25AI) ExecuteCustomRotation(sim *core.Simulation) {
 	if ai.Target.CurrentTarget == nil {
 		return
 	}
 
-	// TODO: Re-enable Frenzy when we have a feature to flag for tank cooldown timing
-	//       Otherwise users get confused why the default settings say they die a lot...
-	//if ai.Frenzy.IsReady(sim) && sim.GetRemainingDurationPercent() < 0.05 {
-	//	ai.Frenzy.Cast(sim, ai.Target.CurrentTarget)
-	//}
-
-	// TODO: Only enable Hateful Strike in solo sim if you are assigned OT instead of MT
-	// TODO: Actual targeting logic for Hateful Strike in raidsim
-	//if ai.HatefulStrike.IsReady(sim) {
-	//	ai.HatefulStrike.Cast(sim, ai.Target.CurrentTarget)
-	//}
-
-	//if ai.Target.GCD.IsReady(sim) {
-	//	waitUntil := 0 //ai.HatefulStrike.ReadyAt()
-	//	ai.Target.WaitUntil(sim, waitUntil)
-	//}
+	if ai.Frenzy.IsReady(sim) && sim.GetRemainingDurationPercent() < patchwerkFrenzyHealthPercent {
+		ai.Frenzy.Cast(sim, ai.Target.CurrentTarget)
+	}
+
+	// Hateful Strike hits the off-tank, so it is only used when the raid has one.
+	offTank := ai.offTank
+	if offTank != nil && ai.HatefulStrike.IsReady(sim) {
+		ai.HatefulStrike.Cast(sim, offTank)
+	}
+
+	if ai.Target.GCD.IsReady(sim) {
+		waitUntil := core.NeverExpires
+		if offTank != nil {
+			waitUntil = ai.HatefulStrike.ReadyAt()
+		}
+		if ai.Frenzy.IsReady(sim) {
+			// Health based fights can't tell exactly when Frenzy is due, so check regularly.
+			waitUntil = min(waitUntil, sim.CurrentTime+time.Second)
+		}
+		if waitUntil != core.NeverExpires {
+			ai.Target.WaitUntil(sim, waitUntil)
+		}
+	}
 }
@@ -41,7 +41,7 @@ func (hunter *Hunter) registerCobraShotSpell() {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			baseDamage := hunter.AutoAttacks.Ranged().CalculateNormalizedWeaponDamage(sim, spell.RangedAttackPower(target)) + (276.806 + spell.RangedAttackPower(target)*0.017)
 			focus := 9.0
-			if hunter.Talents.Termination != 0 && sim.IsExecutePhase25() {
+			if hunter.Talents.Termination != 0 && sim.IsTargetExecutePhase25(target) {
 				focus = float64(hunter.Talents.Termination) * 3
 			}
 			hunter.AddFocus(sim, focus, csMetrics)
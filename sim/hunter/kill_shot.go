@@ -51,7 +51,7 @@ func (hunter *Hunter) registerKillShotSpell() {
 			},
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return sim.IsExecutePhase20()
+			return sim.IsTargetExecutePhase20(target)
 		},
 
 		BonusCritRating:  0 + 5*core.CritRatingPerCritChance*float64(hunter.Talents.SniperTraining),
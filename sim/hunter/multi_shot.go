@@ -32,23 +32,18 @@ func (hunter *Hunter) registerMultiShotSpell() {
 		BonusCoefficient: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			numHits := hunter.Env.GetNumTargets() // Multi is uncapped in Cata
+			targets := sim.Encounter.ActiveTargetUnits // Multi is uncapped in Cata
 
 			sharedDmg := hunter.AutoAttacks.Ranged().BaseDamage(sim)
 
-			baseDamageArray := make([]*core.SpellResult, numHits)
-			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
-				currentTarget := hunter.Env.GetTargetUnit(hitIndex)
+			baseDamageArray := make([]*core.SpellResult, len(targets))
+			for hitIndex, currentTarget := range targets {
 				baseDamage := sharedDmg + 0.2*spell.RangedAttackPower(currentTarget)
-				baseDamageArray[hitIndex] = spell.CalcDamage(sim, target, baseDamage, spell.OutcomeRangedHitAndCrit)
-
+				baseDamageArray[hitIndex] = spell.CalcDamage(sim, currentTarget, baseDamage, spell.OutcomeRangedHitAndCrit)
 			}
 			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
-				curTarget := target
-				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
-					spell.DealDamage(sim, baseDamageArray[hitIndex])
-
-					curTarget = sim.Environment.NextTargetUnit(curTarget)
+				for _, result := range baseDamageArray {
+					spell.DealDamage(sim, result)
 				}
 			})
 
@@ -166,31 +166,14 @@ var hunterPetBaseStats = stats.Stats{
 	stats.MeleeCrit: (3.2 + 1.8) * core.CritRatingPerCritChance,
 }
 
-const PetExpertiseScale = 3.25
-
 func (hunter *Hunter) makeStatInheritance() core.PetStatInheritance {
-
 	return func(ownerStats stats.Stats) stats.Stats {
-		// EJ posts claim this value is passed through math.Floor, but in-game testing
-		// shows pets benefit from each point of owner hit rating in WotLK Classic.
-		// https://web.archive.org/web/20120112003252/http://elitistjerks.com/f80/t100099-demonology_releasing_demon_you
-
 		return stats.Stats{
 			stats.Stamina:           ownerStats[stats.Stamina] * 0.3,
 			stats.Armor:             ownerStats[stats.Armor] * 0.35,
 			stats.AttackPower:       ownerStats[stats.RangedAttackPower] * 0.425,
 			stats.RangedAttackPower: ownerStats[stats.RangedAttackPower] * 0.40,
-
-			stats.MeleeHit:  ownerStats[stats.MeleeHit],
-			stats.Expertise: ownerStats[stats.MeleeHit] * PetExpertiseScale,
-			stats.SpellHit:  ownerStats[stats.MeleeHit],
-
-			stats.MeleeCrit: ownerStats[stats.MeleeCrit],
-			stats.SpellCrit: ownerStats[stats.MeleeCrit],
-
-			stats.MeleeHaste: ownerStats[stats.MeleeHaste],
-			stats.SpellHaste: ownerStats[stats.MeleeHaste],
-		}
+		}.Add(core.InheritPhysicalHit(ownerStats)).Add(core.InheritPhysicalCritAndHaste(ownerStats))
 	}
 }
 
@@ -225,11 +225,7 @@ func (hp *HunterPet) newAcidSpit() *core.Spell {
 		School:  core.SpellSchoolNature,
 		OnSpellHitDealt: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
 			if result.Landed() {
-				aura := acidSpitAuras.Get(result.Target)
-				aura.Activate(sim)
-				if aura.IsActive() {
-					aura.AddStack(sim)
-				}
+				acidSpitAuras.Get(result.Target).Activate(sim)
 			}
 		},
 	})
@@ -247,7 +243,7 @@ func (hp *HunterPet) newDemoralizingScreech() *core.Spell {
 		School:  core.SpellSchoolPhysical,
 		OnSpellHitDealt: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
 			if result.Landed() {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					debuffs.Get(aoeTarget).Activate(sim)
 				}
 			}
@@ -475,7 +471,7 @@ func (hp *HunterPet) newSporeCloud() *core.Spell {
 
 		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
 			spell.AOEDot().Apply(sim)
-			for _, target := range spell.Unit.Env.Encounter.TargetUnits {
+			for _, target := range sim.Encounter.ActiveTargetUnits {
 				debuffs.Get(target).Activate(sim)
 			}
 		},
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/stats"
 )
 
@@ -159,7 +160,7 @@ func (hp *HunterPet) registerRoarOfRecoveryCD() {
 	// This CD is enabled even if not talented, for prepull. See below.
 	hunter := hp.hunterOwner
 	actionID := core.ActionID{SpellID: 53517}
-	focusMetrics := hunter.NewFocusMetrics(actionID)
+	focusMetrics := hp.NewOwnerResourceMetrics(actionID, proto.ResourceType_ResourceTypeFocus)
 
 	rorSpell := hunter.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
@@ -0,0 +1,38 @@
+package hunter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func TestHunterPetStatInheritance(t *testing.T) {
+	ownerStats := stats.Stats{
+		stats.Stamina:           5000,
+		stats.RangedAttackPower: 20000,
+		stats.MeleeHit:          8 * core.MeleeHitRatingPerHitChance,
+		stats.MeleeCrit:         3000,
+		stats.MeleeHaste:        1500,
+	}
+	petStats := (&Hunter{}).makeStatInheritance()(ownerStats)
+
+	expected := stats.Stats{
+		stats.Stamina:           1500,
+		stats.AttackPower:       8500,
+		stats.RangedAttackPower: 8000,
+		stats.MeleeHit:          8 * core.MeleeHitRatingPerHitChance,
+		stats.SpellHit:          17 * core.SpellHitRatingPerHitChance,
+		stats.Expertise:         26 * core.ExpertisePerQuarterPercentReduction,
+		stats.MeleeCrit:         3000,
+		stats.SpellCrit:         3000,
+		stats.MeleeHaste:        1500,
+		stats.SpellHaste:        1500,
+	}
+	for stat := range expected {
+		if math.Abs(petStats[stat]-expected[stat]) > 1e-6 {
+			t.Errorf("pet %s: expected %0.3f, got %0.3f", stats.Stat(stat).StatName(), expected[stat], petStats[stat])
+		}
+	}
+}
@@ -45,7 +45,7 @@ func (hunter *Hunter) registerSteadyShotSpell() {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			baseDamage := (hunter.AutoAttacks.Ranged().CalculateNormalizedWeaponDamage(sim, spell.RangedAttackPower(target)) * 0.62) + (280.182 + spell.RangedAttackPower(target)*0.021)
 			focus := 9.0
-			if hunter.Talents.Termination != 0 && sim.IsExecutePhase25() {
+			if hunter.Talents.Termination != 0 && sim.IsTargetExecutePhase25(target) {
 				focus = float64(hunter.Talents.Termination) * 3
 			}
 
@@ -232,7 +232,7 @@ func (hunter *Hunter) applyTermination() {
 	core.MakePermanent(hunter.RegisterAura(core.Aura{
 		Label: "Termination",
 		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if sim.IsExecutePhase25() && spell == hunter.SteadyShot || spell == hunter.CobraShot {
+			if sim.IsTargetExecutePhase25(result.Target) && spell == hunter.SteadyShot || spell == hunter.CobraShot {
 				hunter.AddFocus(sim, float64(hunter.Talents.Termination)*3, focusMetrics)
 			}
 		},
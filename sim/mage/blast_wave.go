@@ -35,7 +35,7 @@ func (mage *Mage) registerBlastWaveSpell() {
 		ThreatMultiplier:         1,
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			var targetCount int32
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				targetCount++
 				baseDamage := sim.Roll(1047, 1233)
 				baseDamage *= sim.Encounter.AOECapMultiplier()
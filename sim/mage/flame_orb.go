@@ -63,7 +63,7 @@ func (mage *Mage) registerFlameOrbExplodeSpell() {
 
 			damage := 1.318 * mage.ScalingBaseDamage
 
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				spell.CalcAndDealDamage(sim, aoeTarget, damage, spell.OutcomeMagicHitAndCrit)
 			}
 
@@ -144,7 +144,7 @@ func (fo *FlameOrb) registerFlameOrbTickSpell() {
 		ThreatMultiplier: 1,
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			damage := 0.278 * fo.mageOwner.ScalingBaseDamage
-			randomTarget := sim.Encounter.TargetUnits[int(sim.Roll(0, float64(len(sim.Encounter.TargetUnits))))]
+			randomTarget := sim.Encounter.ActiveTargetUnits[int(sim.Roll(0, float64(len(sim.Encounter.ActiveTargetUnits))))]
 			spell.CalcAndDealDamage(sim, randomTarget, damage, spell.OutcomeMagicHitAndCrit)
 			fo.TickCount += 1
 			if fo.TickCount == 15 {
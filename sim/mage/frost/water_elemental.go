@@ -60,6 +60,9 @@ func (Mage *FrostMage) NewWaterElemental(disobeyChance float64) *WaterElemental
 	}
 	waterElemental.EnableManaBarWithModifier(0.333)
 
+	// The elemental keeps scaling with the mage's stats while it is out.
+	waterElemental.EnableLiveStatInheritance()
+
 	Mage.AddPet(waterElemental)
 
 	return waterElemental
@@ -99,16 +102,16 @@ var waterElementalBaseStats = stats.Stats{
 var waterElementalStatInheritance = func(ownerStats stats.Stats) stats.Stats {
 	// These numbers are just rough guesses based on looking at some logs.
 	return stats.Stats{
-		// TODO Pet stats scale dynamically in combat
 		stats.Stamina:    ownerStats[stats.Stamina] * 0.2,
 		stats.Intellect:  ownerStats[stats.Intellect] * 0.3,
 		stats.SpellPower: ownerStats[stats.SpellPower] * 0.333,
+		stats.SpellHaste: ownerStats[stats.SpellHaste],
 		// TODO test crit chance. It does crit, so figure out how often and if it scales
 		/* Results: owner 5% crit, Waterbolt 13% crit
 		owner 18% crit, waterbolt 18% crit
 		*/
 		// stats.SpellCrit:  ownerStats[stats.SpellCrit],
-	}
+	}.Add(core.InheritSpellHit(ownerStats))
 }
 
 func (we *WaterElemental) registerWaterboltSpell() {
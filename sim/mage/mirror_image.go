@@ -119,11 +119,10 @@ var mirrorImageBaseStats = stats.Stats{
 var createMirrorImageInheritance = func() func(stats.Stats) stats.Stats {
 	return func(ownerStats stats.Stats) stats.Stats {
 		return stats.Stats{
-			stats.SpellHit: ownerStats[stats.SpellHit],
 			// seems to be about 8% baseline in wotlk
 			stats.SpellCrit:  8 * core.CritRatingPerCritChance,
 			stats.SpellPower: ownerStats[stats.SpellPower] * 0.33,
-		}
+		}.Add(core.InheritSpellHit(ownerStats))
 	}
 }
 
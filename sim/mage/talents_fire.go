@@ -211,7 +211,7 @@ func (mage *Mage) applyPyromaniac() {
 		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
 			dotSpells := []*core.Spell{mage.LivingBomb, mage.Ignite, mage.PyroblastDot, mage.Combustion}
 			activeDotTargets := 0
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				for _, spells := range dotSpells {
 					if aoeTarget.GetAuraByID(spells.ActionID).IsActive() {
 						activeDotTargets++
@@ -386,37 +386,35 @@ func (mage *Mage) applyImpact() {
 		return
 	}
 
-	// TODO make this work :)
-	// Currently casts a fresh set of DoTs
-	// afaik it should spread exact copies of the DoTs
+	// Impact spreads exact copies of the DoTs on the target to all other targets.
 	mage.ImpactAura = mage.RegisterAura(core.Aura{
 		Label:    "Impact",
 		ActionID: core.ActionID{SpellID: 64343},
 		Duration: time.Second * 10,
 		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell != mage.FireBlast {
+				return
+			}
 
-			if spell == mage.FireBlast {
-				originalTarget := mage.CurrentTarget
-
-				duplicatableDots := map[*core.Spell]float64{
-					mage.LivingBombImpact:   mage.LivingBomb.Dot(originalTarget).SnapshotBaseDamage,
-					mage.PyroblastDotImpact: mage.PyroblastDot.Dot(originalTarget).SnapshotBaseDamage,
-					mage.Ignite:             mage.Ignite.Dot(originalTarget).SnapshotBaseDamage,
-					mage.CombustionImpact:   mage.Combustion.Dot(originalTarget).SnapshotBaseDamage,
+			originalTarget := mage.CurrentTarget
+			spreadDots := []struct {
+				dot         *core.Dot
+				impactSpell *core.Spell
+			}{
+				{mage.LivingBomb.Dot(originalTarget), mage.LivingBombImpact},
+				{mage.PyroblastDot.Dot(originalTarget), mage.PyroblastDotImpact},
+				{mage.Ignite.Dot(originalTarget), mage.Ignite},
+				{mage.Combustion.Dot(originalTarget), mage.CombustionImpact},
+			}
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
+				if aoeTarget == originalTarget {
+					continue
 				}
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
-					mage.CurrentTarget = aoeTarget
-					if mage.CurrentTarget == originalTarget {
-						continue
-					}
-					for spell, damage := range duplicatableDots {
-						spell.Dot(aoeTarget).Snapshot(aoeTarget, damage)
-						spell.Dot(aoeTarget).Apply(sim)
-					}
+				for _, spread := range spreadDots {
+					spread.dot.CopyTo(sim, spread.impactSpell.Dot(aoeTarget))
 				}
-				mage.CurrentTarget = originalTarget // reset to original target
-				aura.Deactivate(sim)
 			}
+			aura.Deactivate(sim)
 		},
 	})
 
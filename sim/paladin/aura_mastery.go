@@ -0,0 +1,44 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (paladin *Paladin) RegisterAuraMasteryCD() {
+	// Aura Mastery protects the raid, so it is only used when raid cooldowns are
+	// simulated. Only the Devotion Aura effect is modeled.
+	if !paladin.Talents.AuraMastery || !paladin.CastsRaidCooldowns() || paladin.PaladinAura != proto.PaladinAura_DevotionAura {
+		return
+	}
+
+	amAuras := paladin.NewRaidCooldownAuras(core.RaidCooldownScopeRaid, func(unit *core.Unit) *core.Aura {
+		return core.AuraMasteryAura(unit, paladin.Index)
+	})
+
+	amSpell := paladin.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 31821},
+		Flags:    core.SpellFlagHelpful,
+
+		Cast: core.CastConfig{
+			IgnoreHaste: true,
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: time.Minute * 2,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, unit := range paladin.RaidCooldownTargets(core.RaidCooldownScopeRaid) {
+				amAuras.Get(unit).Activate(sim)
+			}
+		},
+	})
+
+	paladin.AddMajorCooldown(core.MajorCooldown{
+		Spell: amSpell,
+		Type:  core.CooldownTypeSurvival,
+	})
+}
@@ -54,6 +54,7 @@ func (holy *HolyPaladin) ApplyTalents() {
 
 func (holy *HolyPaladin) Initialize() {
 	holy.Paladin.Initialize()
+	holy.RegisterAuraMasteryCD()
 }
 
 func (holy *HolyPaladin) Reset(sim *core.Simulation) {
@@ -0,0 +1,83 @@
+package sim
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func frostMageRequest() *proto.RaidSimRequest {
+	bonusStats := stats.Stats{
+		stats.SpellPower: 1000,
+		stats.SpellHit:   17 * core.SpellHitRatingPerHitChance,
+	}
+	mage := &proto.Player{
+		Class:      proto.Class_ClassMage,
+		Race:       proto.Race_RaceHuman,
+		Equipment:  &proto.EquipmentSpec{},
+		Consumes:   &proto.Consumes{},
+		Buffs:      &proto.IndividualBuffs{},
+		BonusStats: &proto.UnitStats{Stats: bonusStats.ToFloatArray()},
+		Rotation:   &proto.APLRotation{Type: proto.APLRotation_TypeAPL},
+		Spec: &proto.Player_FrostMage{FrostMage: &proto.FrostMage{
+			Options: &proto.FrostMage_Options{ClassOptions: &proto.MageOptions{}},
+		}},
+	}
+	return &proto.RaidSimRequest{
+		Raid:       core.SinglePlayerRaidProto(mage, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter:  STEncounter,
+		SimOptions: SimOptions,
+	}
+}
+
+func expectPetStat(t *testing.T, pet *core.Pet, stat stats.Stat, expected float64) {
+	t.Helper()
+	if actual := pet.GetStats()[stat]; math.Abs(actual-expected) > 0.01 {
+		t.Fatalf("%s %s: expected %0.2f, got %0.2f", pet.Name, stat.StatName(), expected, actual)
+	}
+}
+
+// The Water Elemental follows its owner's stats, while Mirror Image snapshots
+// them on summon.
+func TestPetStatInheritance(t *testing.T) {
+	sim := core.NewSim(frostMageRequest())
+	sim.Reset()
+	mage := sim.Raid.Parties[0].Players[0].GetCharacter()
+
+	var waterElemental, mirrorImage core.PetAgent
+	for _, petAgent := range mage.PetAgents {
+		switch petAgent.GetPet().Name {
+		case "Water Elemental":
+			waterElemental = petAgent
+		case "Mirror Image":
+			mirrorImage = petAgent
+		}
+	}
+	we, mi := waterElemental.GetPet(), mirrorImage.GetPet()
+	if !we.IsEnabled() || !we.HasLiveStatInheritance() || mi.HasLiveStatInheritance() {
+		t.Fatalf("Expected a live Water Elemental from the start and a snapshot Mirror Image")
+	}
+	mi.Enable(sim, mirrorImage)
+
+	ownerSpellPower := mage.GetStats()[stats.SpellPower]
+	weSpellPower, miSpellPower := we.GetStats()[stats.SpellPower], mi.GetStats()[stats.SpellPower]
+	if math.Abs(we.InheritedStats()[stats.SpellPower]-ownerSpellPower*0.333) > 0.01 {
+		t.Fatalf("Expected the Water Elemental to inherit a third of %0.0f spell power, got %0.2f", ownerSpellPower, we.InheritedStats()[stats.SpellPower])
+	}
+	// Hit capped owners have hit capped pets.
+	expectPetStat(t, we, stats.SpellHit, 17*core.SpellHitRatingPerHitChance)
+	expectPetStat(t, mi, stats.SpellHit, 17*core.SpellHitRatingPerHitChance)
+
+	mage.AddStatsDynamic(sim, stats.Stats{stats.SpellPower: 300})
+	gainedSpellPower := mage.GetStats()[stats.SpellPower] - ownerSpellPower
+	expectPetStat(t, we, stats.SpellPower, weSpellPower+gainedSpellPower*0.333)
+	expectPetStat(t, mi, stats.SpellPower, miSpellPower)
+
+	// A new summon takes a new snapshot.
+	mi.Disable(sim)
+	mi.Enable(sim, mirrorImage)
+	expectPetStat(t, mi, stats.SpellPower, miSpellPower+gainedSpellPower*0.33)
+}
@@ -70,7 +70,9 @@ func (discPriest *DisciplinePriest) Initialize() {
 	// discPriest.Priest.RegisterHealingSpells()
 
 	// // discPriest.ApplyRapture(discPriest.Options.RapturesPerMinute)
-	// discPriest.RegisterHymnOfHopeCD()
+	discPriest.RegisterHymnOfHopeCD()
+	discPriest.RegisterDivineHymnCD()
+	discPriest.RegisterPowerWordBarrierCD()
 }
 
 func (discPriest *DisciplinePriest) Reset(sim *core.Simulation) {
@@ -0,0 +1,88 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (priest *Priest) RegisterDivineHymnCD() {
+	// Divine Hymn heals the raid, so it is only used when raid cooldowns are simulated.
+	if !priest.CastsRaidCooldowns() {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 64843}
+	targets := priest.RaidCooldownTargets(core.RaidCooldownScopeRaid)
+	hymnAuras := priest.NewRaidCooldownAuras(core.RaidCooldownScopeRaid, func(unit *core.Unit) *core.Aura {
+		return core.DivineHymnAura(unit, priest.Index)
+	})
+
+	healSpell := priest.RegisterSpell(core.SpellConfig{
+		ActionID:    actionID.WithTag(1),
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultHealingCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: 0.429,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, 7398, spell.OutcomeHealingCrit)
+			hymnAuras.Get(target).Activate(sim)
+		},
+	})
+
+	// Heals the 3 players lowest on health every 2s while channeled.
+	var pa *core.PendingAction
+	channelAura := priest.RegisterAura(core.Aura{
+		Label:    "Divine Hymn",
+		ActionID: actionID,
+		Duration: time.Second * 8,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			pa = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				NumTicks: 4,
+				OnAction: func(sim *core.Simulation) {
+					for _, unit := range core.LowestUnitsBy(targets, 3, (*core.Unit).CurrentHealthPercent) {
+						healSpell.Cast(sim, unit)
+					}
+				},
+			})
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			pa.Cancel(sim)
+		},
+	})
+
+	divineHymnSpell := priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagChanneled,
+		ClassSpellMask: PriestSpellDivineHymn,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.36,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Minute * 8,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			channelAura.Activate(sim)
+			priest.WaitUntil(sim, channelAura.ExpiresAt())
+		},
+	})
+
+	priest.AddMajorCooldown(core.MajorCooldown{
+		Spell: divineHymnSpell,
+		Type:  core.CooldownTypeSurvival,
+	})
+}
@@ -47,7 +47,7 @@ func (priest *Priest) ApplyGlyphs() {
 			},
 
 			OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-				if spell.ClassSpellMask == PriestSpellShadowWordDeath && sim.IsExecutePhase25() && aura.Icd.IsReady(sim) {
+				if spell.ClassSpellMask == PriestSpellShadowWordDeath && sim.IsTargetExecutePhase25(result.Target) && aura.Icd.IsReady(sim) {
 					aura.Icd.Use(sim)
 					spell.CD.Reset()
 				}
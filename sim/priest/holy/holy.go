@@ -53,7 +53,8 @@ func (holyPriest *HolyPriest) Initialize() {
 	// holyPriest.RegisterHolyFireSpell()
 	// holyPriest.RegisterSmiteSpell()
 	// holyPriest.RegisterPenanceSpell()
-	// holyPriest.RegisterHymnOfHopeCD()
+	holyPriest.RegisterHymnOfHopeCD()
+	holyPriest.RegisterDivineHymnCD()
 }
 
 func (holyPriest *HolyPriest) Reset(sim *core.Simulation) {
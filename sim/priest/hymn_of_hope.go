@@ -0,0 +1,82 @@
+package priest
+
+import (
+	"slices"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (priest *Priest) RegisterHymnOfHopeCD() {
+	// Hymn of Hope restores mana to the raid, so it is only used when raid
+	// cooldowns are simulated. Otherwise hymn_of_hope_count stands in for it.
+	if !priest.CastsRaidCooldowns() {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 64901}
+
+	targets := priest.RaidCooldownTargets(core.RaidCooldownScopeRaid)
+	manaMetrics := make([]*core.ResourceMetrics, len(priest.Env.AllUnits))
+	for _, unit := range targets {
+		manaMetrics[unit.UnitIndex] = unit.NewManaMetrics(actionID)
+	}
+	hymnAuras := priest.NewRaidCooldownAuras(core.RaidCooldownScopeRaid, func(unit *core.Unit) *core.Aura {
+		return core.HymnOfHopeAura(unit, priest.Index)
+	})
+
+	// Restores mana to the 3 players lowest on mana, and raises their max mana.
+	var pa *core.PendingAction
+	channelAura := priest.RegisterAura(core.Aura{
+		Label:    "Hymn of Hope",
+		ActionID: actionID,
+		Duration: core.HymnOfHopeDuration,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			pa = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				NumTicks: 4,
+				OnAction: func(sim *core.Simulation) {
+					manaUsers := core.FilterSlice(targets, func(unit *core.Unit) bool { return unit.HasManaBar() })
+					for _, unit := range core.LowestUnitsBy(manaUsers, 3, (*core.Unit).CurrentManaPercent) {
+						hymnAuras.Get(unit).Activate(sim)
+						unit.AddMana(sim, unit.MaxMana()*0.03, manaMetrics[unit.UnitIndex])
+					}
+				},
+			})
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			pa.Cancel(sim)
+		},
+	})
+
+	hymnOfHopeSpell := priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagChanneled,
+		ClassSpellMask: PriestSpellHymnOfHope,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Minute * 6,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			channelAura.Activate(sim)
+			priest.WaitUntil(sim, channelAura.ExpiresAt())
+		},
+	})
+
+	priest.AddMajorCooldown(core.MajorCooldown{
+		Spell: hymnOfHopeSpell,
+		Type:  core.CooldownTypeMana,
+		ShouldActivate: func(sim *core.Simulation, character *core.Character) bool {
+			return slices.ContainsFunc(targets, func(unit *core.Unit) bool {
+				return unit.HasManaBar() && unit.CurrentManaPercent() < 0.1
+			})
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (priest *Priest) RegisterPowerWordBarrierCD() {
+	// Barrier protects the raid, so it is only used when raid cooldowns are simulated.
+	if !priest.Talents.PowerWordBarrier || !priest.CastsRaidCooldowns() {
+		return
+	}
+
+	barrierAuras := priest.NewRaidCooldownAuras(core.RaidCooldownScopeParty, func(unit *core.Unit) *core.Aura {
+		return core.PowerWordBarrierAura(unit, priest.Index)
+	})
+
+	barrierSpell := priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 62618},
+		Flags:          core.SpellFlagHelpful,
+		ClassSpellMask: PriestSpellPowerWordBarrier,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.30,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, unit := range priest.RaidCooldownTargets(core.RaidCooldownScopeParty) {
+				barrierAuras.Get(unit).Activate(sim)
+			}
+		},
+	})
+
+	priest.AddMajorCooldown(core.MajorCooldown{
+		Spell: barrierSpell,
+		Type:  core.CooldownTypeSurvival,
+	})
+}
@@ -21,7 +21,6 @@ type Priest struct {
 	ShadowfiendPet  *Shadowfiend
 
 	// cached cast stuff
-	// TODO: aoe multi-target situations will need multiple spells ticking for each target.
 	HolyEvangelismProcAura *core.Aura
 	DarkEvangelismProcAura *core.Aura
 
@@ -35,20 +35,23 @@ func (priest *Priest) registerShadowWordDeathSpell() {
 			},
 		},
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			if sim.IsExecutePhase25() {
+			// The hit itself can push the target into execute range.
+			isExecute := sim.IsTargetExecutePhase25(target)
+			if isExecute {
 				spell.DamageMultiplier *= 3
 			}
 			spell.CalcAndDealDamage(sim, target, priest.ClassSpellScaling*0.357, spell.OutcomeMagicHitAndCrit)
-			if sim.IsExecutePhase25() {
+			if isExecute {
 				spell.DamageMultiplier /= 3
 			}
 		},
 		ExpectedInitialDamage: func(sim *core.Simulation, target *core.Unit, spell *core.Spell, _ bool) *core.SpellResult {
-			if sim.IsExecutePhase25() {
+			isExecute := sim.IsTargetExecutePhase25(target)
+			if isExecute {
 				spell.DamageMultiplier *= 3
 			}
 			result := spell.CalcDamage(sim, target, priest.ClassSpellScaling*0.357, spell.OutcomeExpectedMagicHitAndCrit)
-			if sim.IsExecutePhase25() {
+			if isExecute {
 				spell.DamageMultiplier /= 3
 			}
 			return result
@@ -4,14 +4,15 @@ import (
 	"time"
 
 	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/stats"
 )
 
 // DISCLAIMER: Shadowfiend need some extensive research on Level 85
 // Proper Spell Scaling? Wiki says 37.5%, patch notes state 30%
 // WoW Sims implemented priest crit scaling but we do not
-// Right now Stats are inherited statically on spawn, but testing
-// indicates shadow fiend scales per hit based on owner spell power
+// Stats are inherited after a short delay and then follow the owner's stats,
+// as testing indicates shadow fiend scales per hit based on owner spell power
 type Shadowfiend struct {
 	core.Pet
 
@@ -42,7 +43,7 @@ func (priest *Priest) NewShadowfiend() *Shadowfiend {
 	}
 
 	shadowfiend.DelayInitialInheritance(time.Millisecond * 500)
-	manaMetric := priest.NewManaMetrics(core.ActionID{SpellID: 34433})
+	manaMetric := shadowfiend.NewOwnerResourceMetrics(core.ActionID{SpellID: 34433}, proto.ResourceType_ResourceTypeMana)
 	_ = core.MakePermanent(shadowfiend.GetOrRegisterAura(core.Aura{
 		Label: "Autoattack mana regen",
 		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
@@ -0,0 +1,91 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// A warrior and a priest with Power Word: Barrier in the first party, and a
+// shaman in the second.
+func raidCooldownsRequest(simulateRaidCooldowns bool) *proto.RaidSimRequest {
+	player := func(class proto.Class, talents string) *proto.Player {
+		return &proto.Player{
+			Class:         class,
+			Race:          proto.Race_RaceDwarf,
+			Equipment:     &proto.EquipmentSpec{},
+			Consumes:      &proto.Consumes{},
+			Buffs:         &proto.IndividualBuffs{},
+			TalentsString: talents,
+			Rotation:      &proto.APLRotation{Type: proto.APLRotation_TypeAPL},
+		}
+	}
+	warrior := player(proto.Class_ClassWarrior, "")
+	warrior.Spec = &proto.Player_ProtectionWarrior{ProtectionWarrior: &proto.ProtectionWarrior{
+		Options: &proto.ProtectionWarrior_Options{ClassOptions: &proto.WarriorOptions{}},
+	}}
+	priest := player(proto.Class_ClassPriest, "000000000000000000001--")
+	priest.Spec = &proto.Player_DisciplinePriest{DisciplinePriest: &proto.DisciplinePriest{
+		Options: &proto.DisciplinePriest_Options{ClassOptions: &proto.PriestOptions{}},
+	}}
+	shaman := player(proto.Class_ClassShaman, "")
+	shaman.Spec = &proto.Player_ElementalShaman{ElementalShaman: &proto.ElementalShaman{
+		Options: &proto.ElementalShaman_Options{ClassOptions: &proto.ShamanOptions{}},
+	}}
+
+	return &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{Players: []*proto.Player{warrior, priest}},
+				{Players: []*proto.Player{shaman}},
+			},
+			SimulateRaidCooldowns: simulateRaidCooldowns,
+		},
+		Encounter:  STEncounter,
+		SimOptions: SimOptions,
+	}
+}
+
+func TestSimulateRaidCooldowns(t *testing.T) {
+	rallyingCryID := core.ActionID{SpellID: 97462}
+	barrierID := core.ActionID{SpellID: 62618}
+	hymnOfHopeID := core.ActionID{SpellID: 64901}
+
+	sim := core.NewSim(raidCooldownsRequest(true))
+	sim.Reset()
+	warrior := sim.Raid.Parties[0].Players[0].GetCharacter()
+	priest := sim.Raid.Parties[0].Players[1].GetCharacter()
+	shaman := sim.Raid.Parties[1].Players[0].GetCharacter()
+
+	shamanHealth := shaman.MaxHealth()
+	warrior.GetSpell(rallyingCryID).Cast(sim, &warrior.Unit)
+	if shaman.MaxHealth() <= shamanHealth {
+		t.Fatalf("Expected Rallying Cry to raise the max health of the other party")
+	}
+
+	priest.GetSpell(barrierID).Cast(sim, &priest.Unit)
+	if warrior.PseudoStats.DamageTakenMultiplier != 0.75 || shaman.PseudoStats.DamageTakenMultiplier != 1 {
+		t.Fatalf("Expected Power Word: Barrier to only protect the priest's party, got %0.2f and %0.2f",
+			warrior.PseudoStats.DamageTakenMultiplier, shaman.PseudoStats.DamageTakenMultiplier)
+	}
+	if priest.GetSpell(hymnOfHopeID) == nil {
+		t.Fatalf("Expected Hymn of Hope to be cast by the priest")
+	}
+	if result := core.RunRaidSim(raidCooldownsRequest(true)); result.ErrorResult != "" {
+		t.Fatalf("Raid sim failed: %s", result.ErrorResult)
+	}
+
+	// Without the option, the raid gets these cooldowns from its buff settings instead.
+	sim = core.NewSim(raidCooldownsRequest(false))
+	for _, id := range []core.ActionID{rallyingCryID, barrierID, hymnOfHopeID} {
+		for _, unit := range sim.Raid.AllPlayerUnits {
+			if unit.GetSpell(id) != nil {
+				t.Fatalf("Expected %s not to be cast by %s without simulated raid cooldowns", id, unit.Label)
+			}
+		}
+	}
+	if result := core.RunRaidSim(raidCooldownsRequest(false)); result.ErrorResult != "" {
+		t.Fatalf("Raid sim failed: %s", result.ErrorResult)
+	}
+}
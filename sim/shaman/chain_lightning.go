@@ -8,7 +8,7 @@ import (
 )
 
 func (shaman *Shaman) registerChainLightningSpell() {
-	numHits := min(core.TernaryInt32(shaman.HasMajorGlyph(proto.ShamanMajorGlyph_GlyphOfChainLightning), 5, 3), shaman.Env.GetNumTargets())
+	numHits := min(core.TernaryInt32(shaman.HasMajorGlyph(proto.ShamanMajorGlyph_GlyphOfChainLightning), 5, 3), int32(len(shaman.Env.Encounter.TargetUnits)))
 	shaman.ChainLightning = shaman.newChainLightningSpell(false)
 	shaman.ChainLightningOverloads = []*core.Spell{}
 	for i := int32(0); i < numHits; i++ {
@@ -28,14 +28,14 @@ func (shaman *Shaman) newChainLightningSpell(isElementalOverload bool) *core.Spe
 	}
 
 	baseDamage := shaman.ClassSpellScaling * 1.08800005913
-	numHits := int32(3)
+	maxHits := int32(3)
 	if shaman.HasMajorGlyph(proto.ShamanMajorGlyph_GlyphOfChainLightning) {
 		baseDamage *= 0.90
-		numHits += 2
+		maxHits += 2
 	}
-	numHits = min(numHits, shaman.Env.GetNumTargets())
 
 	spellConfig.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		numHits := min(maxHits, sim.Environment.GetNumTargets())
 		bounceReduction := 1.0
 		curTarget := target
 		for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
@@ -63,7 +63,7 @@ func (fireElemental *FireElemental) registerFireNova() {
 		ThreatMultiplier: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				baseDamage := sim.Roll(1527, 1731) * sim.Encounter.AOECapMultiplier() //Estimated from beta testing
 				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
 			}
@@ -95,7 +95,7 @@ func (fireElemental *FireElemental) registerFireShieldAura() {
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
 				// TODO is this the right affect should it be Capped?
 				// TODO these are approximation, from base SP
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					//baseDamage *= sim.Encounter.AOECapMultiplier()
 					dot.Spell.CalcAndDealDamage(sim, aoeTarget, sim.Roll(107, 107), dot.Spell.OutcomeMagicCrit) //Estimated from beta testing
 				}
@@ -44,7 +44,7 @@ func (shaman *Shaman) registerSearingTotemSpell() {
 		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
 			shaman.MagmaTotem.AOEDot().Cancel(sim)
 			shaman.FireElemental.Disable(sim)
-			spell.Dot(sim.GetTargetUnit(0)).Apply(sim)
+			spell.Dot(sim.Encounter.ActiveTargetUnits[0]).Apply(sim)
 			duration := 60 * (1.0 + 0.20*float64(shaman.Talents.TotemicFocus))
 			shaman.TotemExpirations[FireTotem] = sim.CurrentTime + time.Duration(duration)*time.Second
 		},
@@ -81,7 +81,7 @@ func (shaman *Shaman) registerMagmaTotemSpell() {
 			BonusCoefficient: 0.08,
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
 				baseDamage := shaman.ClassSpellScaling * 0.26699998975 * sim.Encounter.AOECapMultiplier()
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					dot.Spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, dot.Spell.OutcomeMagicHitAndCrit)
 				}
 			},
@@ -33,9 +33,9 @@ func (shaman *Shaman) registerFireNovaSpell() {
 		BonusCoefficient: 0.164,
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			baseDamage := shaman.ClassSpellScaling * 0.78500002623
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				if shaman.FlameShock.Dot(aoeTarget).IsActive() {
-					for _, newTarget := range sim.Encounter.TargetUnits {
+					for _, newTarget := range sim.Encounter.ActiveTargetUnits {
 						if newTarget != aoeTarget {
 							spell.CalcAndDealDamage(sim, newTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
 						}
@@ -44,7 +44,7 @@ func (shaman *Shaman) registerFireNovaSpell() {
 			}
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				if shaman.FlameShock.Dot(aoeTarget).IsActive() {
 					return true
 				}
@@ -192,7 +192,7 @@ func (shaman *Shaman) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 		raidBuffs.ManaSpringTotem = true
 	}
 
-	if shaman.Talents.ManaTideTotem {
+	if shaman.Talents.ManaTideTotem && !shaman.CastsRaidCooldowns() {
 		raidBuffs.ManaTideTotemCount++
 	}
 
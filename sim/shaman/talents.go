@@ -652,7 +652,9 @@ func (shaman *Shaman) registerManaTideTotemCD() {
 		return
 	}
 
-	mttAura := core.ManaTideTotemAura(shaman.GetCharacter(), shaman.Index)
+	mttAuras := shaman.NewRaidCooldownAuras(core.RaidCooldownScopeRaid, func(unit *core.Unit) *core.Aura {
+		return core.ManaTideTotemAura(unit, shaman.Index)
+	})
 	mttSpell := shaman.RegisterSpell(core.SpellConfig{
 		ActionID: core.ManaTideTotemActionID,
 		Flags:    core.SpellFlagNoOnCastComplete,
@@ -667,7 +669,9 @@ func (shaman *Shaman) registerManaTideTotemCD() {
 			},
 		},
 		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
-			mttAura.Activate(sim)
+			for _, unit := range shaman.RaidCooldownTargets(core.RaidCooldownScopeRaid) {
+				mttAuras.Get(unit).Activate(sim)
+			}
 
 			// If healing stream is active, cancel it while mana tide is up.
 			if shaman.HealingStreamTotem.Hot(&shaman.Unit).IsActive() {
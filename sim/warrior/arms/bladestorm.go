@@ -12,8 +12,7 @@ func (war *ArmsWarrior) RegisterBladestorm() {
 		return
 	}
 	actionID := core.ActionID{SpellID: 46924}
-	numHits := war.Env.GetNumTargets() // 1 hit per target
-	results := make([]*core.SpellResult, numHits)
+	results := make([]*core.SpellResult, len(war.Env.Encounter.TargetUnits))
 
 	bladestorm := war.RegisterSpell(core.SpellConfig{
 		ActionID:       actionID,
@@ -47,18 +46,14 @@ func (war *ArmsWarrior) RegisterBladestorm() {
 			NumberOfTicks: 6,
 			TickLength:    time.Second * 1,
 			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
-				target := war.CurrentTarget
 				spell := dot.Spell
-				curTarget := target
-				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
+				targets := sim.Encounter.ActiveTargetUnits // 1 hit per target
+				for hitIndex, curTarget := range targets {
 					baseDamage := 1.5 * spell.Unit.MHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
 					results[hitIndex] = spell.CalcDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
-					curTarget = sim.Environment.NextTargetUnit(curTarget)
 				}
-				curTarget = target
-				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
-					spell.DealDamage(sim, results[hitIndex])
-					curTarget = sim.Environment.NextTargetUnit(curTarget)
+				for _, result := range results[:len(targets)] {
+					spell.DealDamage(sim, result)
 				}
 			},
 		},
@@ -34,11 +34,11 @@ func (war *ArmsWarrior) RegisterSweepingStrikes() {
 		ActionID: actionID,
 		Duration: time.Second * 10,
 		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if result.Damage <= 0 || !spell.ProcMask.Matches(core.ProcMaskMelee) || len(war.Env.Encounter.Targets) < 2 {
+			if result.Damage <= 0 || !spell.ProcMask.Matches(core.ProcMaskMelee) || sim.Environment.GetNumTargets() < 2 {
 				return
 			}
 
-			if (spell == war.Execute && !sim.IsExecutePhase20()) || spell == war.Whirlwind {
+			if (spell == war.Execute && !sim.IsTargetExecutePhase20(result.Target)) || spell == war.Whirlwind {
 				curDmg = spell.Unit.MHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
 			} else {
 				curDmg = result.Damage
@@ -28,7 +28,7 @@ func (warrior *Warrior) RegisterExecuteSpell() {
 			IgnoreHaste: true,
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return sim.IsExecutePhase20() && warrior.StanceMatches(BattleStance|BerserkerStance)
+			return sim.IsTargetExecutePhase20(target) && warrior.StanceMatches(BattleStance|BerserkerStance)
 		},
 
 		CritMultiplier:   warrior.DefaultMeleeCritMultiplier(),
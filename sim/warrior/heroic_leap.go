@@ -7,9 +7,7 @@ import (
 )
 
 func (warrior *Warrior) RegisterHeroicLeap() {
-
-	numHits := warrior.Env.GetNumTargets()
-	results := make([]*core.SpellResult, numHits)
+	results := make([]*core.SpellResult, len(warrior.Env.Encounter.TargetUnits))
 
 	warrior.RegisterSpell(core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: 6544},
@@ -39,17 +37,15 @@ func (warrior *Warrior) RegisterHeroicLeap() {
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			baseDamage := 1 + 0.5*spell.MeleeAttackPower()
-			curTarget := target
+			targets := sim.Encounter.ActiveTargetUnits
 
-			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
+			for hitIndex, curTarget := range targets {
 				results[hitIndex] = spell.CalcDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
-				curTarget = sim.Environment.NextTargetUnit(curTarget)
 			}
 
-			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
-				spell.DealDamage(sim, results[hitIndex])
+			for _, result := range results[:len(targets)] {
+				spell.DealDamage(sim, result)
 			}
-
 		},
 	})
 }
@@ -52,9 +52,8 @@ func (warrior *Warrior) RegisterHeroicStrikeSpell() {
 }
 
 func (warrior *Warrior) RegisterCleaveSpell() {
-	targets := core.TernaryInt32(warrior.HasMajorGlyph(proto.WarriorMajorGlyph_GlyphOfCleaving), 3, 2)
-	numHits := min(targets, warrior.Env.GetNumTargets())
-	results := make([]*core.SpellResult, numHits)
+	maxHits := core.TernaryInt32(warrior.HasMajorGlyph(proto.WarriorMajorGlyph_GlyphOfCleaving), 3, 2)
+	results := make([]*core.SpellResult, maxHits)
 
 	warrior.Cleave = warrior.RegisterSpell(core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: 845},
@@ -83,6 +82,7 @@ func (warrior *Warrior) RegisterCleaveSpell() {
 		CritMultiplier:   warrior.DefaultMeleeCritMultiplier(),
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			numHits := min(maxHits, sim.Environment.GetNumTargets())
 			curTarget := target
 			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 				baseDamage := 6 + (spell.MeleeAttackPower() * 0.45)
@@ -91,10 +91,8 @@ func (warrior *Warrior) RegisterCleaveSpell() {
 				curTarget = sim.Environment.NextTargetUnit(curTarget)
 			}
 
-			curTarget = target
-			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
-				spell.DealDamage(sim, results[hitIndex])
-				curTarget = sim.Environment.NextTargetUnit(curTarget)
+			for _, result := range results[:numHits] {
+				spell.DealDamage(sim, result)
 			}
 		},
 	})
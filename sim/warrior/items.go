@@ -270,16 +270,21 @@ var ItemSetColossalDragonplateArmor = core.NewItemSet(core.ItemSet{
 			character := agent.GetCharacter()
 			actionID := core.ActionID{SpellID: 105909}
 
-			//var shieldAmt float64 = 0.0
-			shieldAura := character.RegisterAura(core.Aura{
-				Label:    "Shield of Fury",
-				ActionID: actionID,
-				Duration: 6 * time.Second,
-				OnGain: func(aura *core.Aura, sim *core.Simulation) {
-					// TODO: Shield mechanics NYI
-				},
-				OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-					// TODO: Shield mechanics NYI
+			shieldSpell := character.RegisterSpell(core.SpellConfig{
+				ActionID:    actionID,
+				SpellSchool: core.SpellSchoolPhysical,
+				ProcMask:    core.ProcMaskSpellHealing,
+				Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagHelpful,
+
+				DamageMultiplier: 1,
+				ThreatMultiplier: 1,
+
+				Shield: core.ShieldConfig{
+					SelfOnly: true,
+					Aura: core.Aura{
+						Label:    "Shield of Fury",
+						Duration: 6 * time.Second,
+					},
 				},
 			})
 
@@ -290,8 +295,7 @@ var ItemSetColossalDragonplateArmor = core.NewItemSet(core.ItemSet{
 				ClassSpellMask: SpellMaskRevenge,
 				Outcome:        core.OutcomeLanded,
 				Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-					// shieldAmt = result.Damage * 0.2
-					shieldAura.Activate(sim)
+					shieldSpell.SelfShield().Apply(sim, result.Damage*0.2)
 				},
 			})
 		},
@@ -32,8 +32,9 @@ func (war *ProtectionWarrior) RegisterLastStand() {
 	})
 
 	lastStandSpell := war.RegisterSpell(core.SpellConfig{
-		ActionID:       actionID,
-		ClassSpellMask: warrior.SpellMaskLastStand,
+		ActionID:        actionID,
+		RelatedSelfBuff: lastStandAura,
+		ClassSpellMask:  warrior.SpellMaskLastStand,
 
 		Cast: core.CastConfig{
 			CD: core.Cooldown{
@@ -0,0 +1,48 @@
+package warrior
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (warrior *Warrior) RegisterRallyingCryCD() {
+	// Rallying Cry is for the raid, so it is only used when raid cooldowns are
+	// simulated, or by tanks as a defensive for themselves.
+	if !warrior.CastsRaidCooldowns() && !warrior.IsTanking() {
+		return
+	}
+
+	rcAuras := warrior.NewRaidCooldownAuras(core.RaidCooldownScopeRaid, func(unit *core.Unit) *core.Aura {
+		return core.RallyingCryAura(unit, warrior.Index)
+	})
+
+	rcSpell := warrior.RegisterSpell(core.SpellConfig{
+		ActionID:        core.ActionID{SpellID: 97462},
+		ClassSpellMask:  SpellMaskRallyingCry,
+		Flags:           core.SpellFlagHelpful,
+		RelatedSelfBuff: rcAuras.Get(&warrior.Unit),
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			IgnoreHaste: true,
+			CD: core.Cooldown{
+				Timer:    warrior.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, unit := range warrior.RaidCooldownTargets(core.RaidCooldownScopeRaid) {
+				rcAuras.Get(unit).Activate(sim)
+			}
+		},
+	})
+
+	warrior.AddMajorCooldown(core.MajorCooldown{
+		Spell: rcSpell,
+		Type:  core.CooldownTypeSurvival,
+	})
+}
@@ -25,7 +25,7 @@ func (warrior *Warrior) RegisterRevengeSpell() {
 		},
 	})
 
-	extraHit := warrior.Talents.ImprovedRevenge > 0 && warrior.Env.GetNumTargets() > 1
+	hasExtraHit := warrior.Talents.ImprovedRevenge > 0
 	extraHitMult := 0.5 * float64(warrior.Talents.ImprovedRevenge)
 
 	warrior.Revenge = warrior.RegisterSpell(core.SpellConfig{
@@ -66,7 +66,7 @@ func (warrior *Warrior) RegisterRevengeSpell() {
 				spell.IssueRefund(sim)
 			}
 
-			if extraHit {
+			if hasExtraHit && sim.Environment.GetNumTargets() > 1 {
 				otherTarget := sim.Environment.NextTargetUnit(target)
 				// TODO: Reimplement using scaling coefficients and variance once those stats are available
 				baseDamage := sim.Roll(1618.3, 1977.92) + ap
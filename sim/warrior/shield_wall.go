@@ -33,8 +33,9 @@ func (warrior *Warrior) RegisterShieldWallCD() {
 	cooldownDur := time.Minute * 5
 
 	swSpell := warrior.RegisterSpell(core.SpellConfig{
-		ActionID:       actionID,
-		ClassSpellMask: SpellMaskShieldWall,
+		ActionID:        actionID,
+		RelatedSelfBuff: swAura,
+		ClassSpellMask:  SpellMaskShieldWall,
 
 		Cast: core.CastConfig{
 			DefaultCast: core.Cast{
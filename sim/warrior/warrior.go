@@ -33,6 +33,7 @@ const (
 	SpellMaskShieldWall
 	SpellMaskLastStand
 	SpellMaskDeadlyCalm
+	SpellMaskRallyingCry
 
 	// Abilities that cost rage but aren't attacks
 	SpellMaskDemoShout
@@ -155,6 +156,7 @@ func (warrior *Warrior) Initialize() {
 	warrior.RegisterInnerRage()
 	warrior.RegisterOverpowerSpell()
 	warrior.RegisterRecklessnessCD()
+	warrior.RegisterRallyingCryCD()
 	warrior.RegisterRendSpell()
 	warrior.RegisterRevengeSpell()
 	warrior.RegisterShatteringThrowCD()
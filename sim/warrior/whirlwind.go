@@ -9,8 +9,7 @@ import (
 
 func (warrior *Warrior) RegisterWhirlwindSpell() {
 	actionID := core.ActionID{SpellID: 1680}
-	numHits := warrior.Env.GetNumTargets() // Whirlwind is uncapped in Cata
-	results := make([]*core.SpellResult, numHits)
+	results := make([]*core.SpellResult, len(warrior.Env.Encounter.TargetUnits))
 
 	var whirlwindOH *core.Spell
 	if warrior.AutoAttacks.IsDualWielding && warrior.GetOHWeapon().WeaponType != proto.WeaponType_WeaponTypeStaff &&
@@ -60,22 +59,18 @@ func (warrior *Warrior) RegisterWhirlwindSpell() {
 		BonusCoefficient: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			curTarget := target
+			targets := sim.Encounter.ActiveTargetUnits // Whirlwind is uncapped in Cata
 			numLandedHits := 0
-			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
+			for hitIndex, curTarget := range targets {
 				baseDamage := 0.65 * spell.Unit.MHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
 				results[hitIndex] = spell.CalcDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
 				if results[hitIndex].Landed() {
 					numLandedHits++
 				}
-
-				curTarget = sim.Environment.NextTargetUnit(curTarget)
 			}
 
-			curTarget = target
-			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
-				spell.DealDamage(sim, results[hitIndex])
-				curTarget = sim.Environment.NextTargetUnit(curTarget)
+			for _, result := range results[:len(targets)] {
+				spell.DealDamage(sim, result)
 			}
 
 			if numLandedHits > 4 {
@@ -83,18 +78,14 @@ func (warrior *Warrior) RegisterWhirlwindSpell() {
 			}
 
 			if whirlwindOH != nil {
-				curTarget = target
-				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
+				targets = sim.Encounter.ActiveTargetUnits
+				for hitIndex, curTarget := range targets {
 					baseDamage := 0.65 * spell.Unit.OHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
 					results[hitIndex] = whirlwindOH.CalcDamage(sim, curTarget, baseDamage, whirlwindOH.OutcomeMeleeWeaponSpecialHitAndCrit)
-
-					curTarget = sim.Environment.NextTargetUnit(curTarget)
 				}
 
-				curTarget = target
-				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
-					whirlwindOH.DealDamage(sim, results[hitIndex])
-					curTarget = sim.Environment.NextTargetUnit(curTarget)
+				for _, result := range results[:len(targets)] {
+					whirlwindOH.DealDamage(sim, result)
 				}
 			}
 		},
@@ -31,6 +31,16 @@ func main() {
 	js.Global().Set("statWeights", js.FuncOf(statWeights))
 	js.Global().Set("statWeightsAsync", js.FuncOf(statWeightsAsync))
 	js.Global().Set("bulkSimAsync", js.FuncOf(bulkSimAsync))
+	js.Global().Set("statCurveAsync", js.FuncOf(statCurveAsync))
+	js.Global().Set("tuneRotationAsync", js.FuncOf(tuneRotationAsync))
+	js.Global().Set("replayIterationAsync", js.FuncOf(replayIterationAsync))
+	js.Global().Set("compareProfessionsAsync", js.FuncOf(compareProfessionsAsync))
+	js.Global().Set("compareRacesAsync", js.FuncOf(compareRacesAsync))
+	js.Global().Set("buffValuesAsync", js.FuncOf(buffValuesAsync))
+	js.Global().Set("planPrepull", js.FuncOf(planPrepull))
+	js.Global().Set("startSession", js.FuncOf(startSession))
+	js.Global().Set("stepSession", js.FuncOf(stepSession))
+	js.Global().Set("endSession", js.FuncOf(endSession))
 	js.Global().Call("wasmready")
 	<-c
 }
@@ -217,6 +227,132 @@ func bulkSimAsync(this js.Value, args []js.Value) interface{} {
 	return result
 }
 
+func statCurveAsync(this js.Value, args []js.Value) interface{} {
+	scr := &proto.StatCurveRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), scr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.StatCurveAsync(scr, reporter)
+
+	result := processAsyncProgress(args[1], reporter)
+	return result
+}
+
+func tuneRotationAsync(this js.Value, args []js.Value) interface{} {
+	rtr := &proto.RotationTunerRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), rtr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.TuneRotationAsync(rtr, reporter)
+
+	result := processAsyncProgress(args[1], reporter)
+	return result
+}
+
+func replayIterationAsync(this js.Value, args []js.Value) interface{} {
+	rir := &proto.ReplayIterationRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), rir); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.ReplayIterationAsync(rir, reporter)
+
+	result := processAsyncProgress(args[1], reporter)
+	return result
+}
+
+func compareProfessionsAsync(this js.Value, args []js.Value) interface{} {
+	pcr := &proto.ProfessionComparisonRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), pcr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.CompareProfessionsAsync(pcr, reporter)
+
+	result := processAsyncProgress(args[1], reporter)
+	return result
+}
+
+func compareRacesAsync(this js.Value, args []js.Value) interface{} {
+	rcr := &proto.RaceComparisonRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), rcr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.CompareRacesAsync(rcr, reporter)
+
+	result := processAsyncProgress(args[1], reporter)
+	return result
+}
+
+func buffValuesAsync(this js.Value, args []js.Value) interface{} {
+	bvr := &proto.BuffValuesRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), bvr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.BuffValuesAsync(bvr, reporter)
+
+	result := processAsyncProgress(args[1], reporter)
+	return result
+}
+
+func planPrepull(this js.Value, args []js.Value) interface{} {
+	ppr := &proto.PrepullPlanRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), ppr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	return marshalResult(core.PlanPrepull(ppr))
+}
+
+func startSession(this js.Value, args []js.Value) interface{} {
+	ssr := &proto.StartSessionRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), ssr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	return marshalResult(core.StartInteractiveSession(ssr))
+}
+
+func stepSession(this js.Value, args []js.Value) interface{} {
+	ssr := &proto.SessionStepRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), ssr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	return marshalResult(core.StepInteractiveSession(ssr))
+}
+
+func endSession(this js.Value, args []js.Value) interface{} {
+	esr := &proto.EndSessionRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), esr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	return marshalResult(core.EndInteractiveSession(esr))
+}
+
+func marshalResult(result googleProto.Message) interface{} {
+	outbytes, err := googleProto.Marshal(result)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		return nil
+	}
+
+	outArray := js.Global().Get("Uint8Array").New(len(outbytes))
+	js.CopyBytesToJS(outArray, outbytes)
+	return outArray
+}
+
 // Assumes args[0] is a Uint8Array
 func getArgsBinary(value js.Value) []byte {
 	data := make([]byte, value.Get("length").Int())
@@ -248,7 +384,10 @@ reader:
 			js.CopyBytesToJS(outArray, outbytes)
 			progFunc.Invoke(outArray)
 
-			if progMetric.FinalWeightResult != nil || progMetric.FinalRaidResult != nil || progMetric.FinalBulkResult != nil {
+			if progMetric.FinalWeightResult != nil || progMetric.FinalRaidResult != nil || progMetric.FinalBulkResult != nil ||
+				progMetric.FinalStatCurveResult != nil || progMetric.FinalRotationTunerResult != nil || progMetric.FinalReplayResult != nil ||
+				progMetric.FinalProfessionComparisonResult != nil || progMetric.FinalRaceComparisonResult != nil ||
+				progMetric.FinalBuffValuesResult != nil {
 				return outArray
 			}
 		}
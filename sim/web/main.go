@@ -100,6 +100,36 @@ var handlers = map[string]apiHandler{
 	"/computeStats": {msg: func() googleProto.Message { return &proto.ComputeStatsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.ComputeStats(msg.(*proto.ComputeStatsRequest))
 	}},
+	"/statCurve": {msg: func() googleProto.Message { return &proto.StatCurveRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.StatCurve(msg.(*proto.StatCurveRequest))
+	}},
+	"/tuneRotation": {msg: func() googleProto.Message { return &proto.RotationTunerRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.TuneRotation(msg.(*proto.RotationTunerRequest))
+	}},
+	"/replayIteration": {msg: func() googleProto.Message { return &proto.ReplayIterationRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.ReplayIteration(msg.(*proto.ReplayIterationRequest))
+	}},
+	"/compareProfessions": {msg: func() googleProto.Message { return &proto.ProfessionComparisonRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.CompareProfessions(msg.(*proto.ProfessionComparisonRequest))
+	}},
+	"/compareRaces": {msg: func() googleProto.Message { return &proto.RaceComparisonRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.CompareRaces(msg.(*proto.RaceComparisonRequest))
+	}},
+	"/buffValues": {msg: func() googleProto.Message { return &proto.BuffValuesRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.BuffValues(msg.(*proto.BuffValuesRequest))
+	}},
+	"/planPrepull": {msg: func() googleProto.Message { return &proto.PrepullPlanRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.PlanPrepull(msg.(*proto.PrepullPlanRequest))
+	}},
+	"/startSession": {msg: func() googleProto.Message { return &proto.StartSessionRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.StartInteractiveSession(msg.(*proto.StartSessionRequest))
+	}},
+	"/stepSession": {msg: func() googleProto.Message { return &proto.SessionStepRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.StepInteractiveSession(msg.(*proto.SessionStepRequest))
+	}},
+	"/endSession": {msg: func() googleProto.Message { return &proto.EndSessionRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.EndInteractiveSession(msg.(*proto.EndSessionRequest))
+	}},
 }
 
 var asyncAPIHandlers = map[string]asyncAPIHandler{
@@ -109,6 +139,24 @@ var asyncAPIHandlers = map[string]asyncAPIHandler{
 	"/statWeightsAsync": {msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
 		core.StatWeightsAsync(msg.(*proto.StatWeightsRequest), reporter)
 	}},
+	"/statCurveAsync": {msg: func() googleProto.Message { return &proto.StatCurveRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.StatCurveAsync(msg.(*proto.StatCurveRequest), reporter)
+	}},
+	"/tuneRotationAsync": {msg: func() googleProto.Message { return &proto.RotationTunerRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.TuneRotationAsync(msg.(*proto.RotationTunerRequest), reporter)
+	}},
+	"/replayIterationAsync": {msg: func() googleProto.Message { return &proto.ReplayIterationRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.ReplayIterationAsync(msg.(*proto.ReplayIterationRequest), reporter)
+	}},
+	"/compareProfessionsAsync": {msg: func() googleProto.Message { return &proto.ProfessionComparisonRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.CompareProfessionsAsync(msg.(*proto.ProfessionComparisonRequest), reporter)
+	}},
+	"/compareRacesAsync": {msg: func() googleProto.Message { return &proto.RaceComparisonRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.CompareRacesAsync(msg.(*proto.RaceComparisonRequest), reporter)
+	}},
+	"/buffValuesAsync": {msg: func() googleProto.Message { return &proto.BuffValuesRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.BuffValuesAsync(msg.(*proto.BuffValuesRequest), reporter)
+	}},
 	"/bulkSimAsync": {msg: func() googleProto.Message { return &proto.BulkSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
 		// TODO: we can use context's to cancel stuff.
 		// We should have all the async APIs take in context and let it be cancelled via its async ID.
@@ -194,7 +242,7 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 				simProgress.latestProgress.Store(progMetric)
-				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil {
+				if isFinalProgress(progMetric) {
 					return
 				}
 			}
@@ -252,7 +300,7 @@ func (s *server) setupAsyncServer() {
 		}
 
 		// If this was the last result, delete the cache for this simulation.
-		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil {
+		if isFinalProgress(latest) {
 			s.progMut.Lock()
 			delete(s.asyncProgresses, msg.ProgressId)
 			s.progMut.Unlock()
@@ -261,6 +309,15 @@ func (s *server) setupAsyncServer() {
 		w.Write(outbytes)
 	})))
 }
+
+// Whether this is the last progress report of an async API, i.e. it carries a result.
+func isFinalProgress(progMetric *proto.ProgressMetrics) bool {
+	return progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil ||
+		progMetric.FinalStatCurveResult != nil || progMetric.FinalRotationTunerResult != nil || progMetric.FinalReplayResult != nil ||
+		progMetric.FinalProfessionComparisonResult != nil || progMetric.FinalRaceComparisonResult != nil ||
+		progMetric.FinalBuffValuesResult != nil
+}
+
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -237,7 +237,18 @@ func ReadDatabaseFromJson(jsonStr string) *WowDatabase {
 	if err := protojson.Unmarshal([]byte(jsonStr), dbProto); err != nil {
 		panic(err)
 	}
+	return databaseFromProto(dbProto)
+}
+
+func ReadDatabaseFromBinary(data []byte) *WowDatabase {
+	dbProto := &proto.UIDatabase{}
+	if err := googleProto.Unmarshal(data, dbProto); err != nil {
+		panic(err)
+	}
+	return databaseFromProto(dbProto)
+}
 
+func databaseFromProto(dbProto *proto.UIDatabase) *WowDatabase {
 	enchants := make(map[EnchantDBKey]*proto.UIEnchant, len(dbProto.Enchants))
 	for _, v := range dbProto.Enchants {
 		enchants[EnchantToDBKey(v)] = v
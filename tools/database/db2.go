@@ -0,0 +1,922 @@
+package database
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/tools"
+)
+
+// A client DB2 table exported as CSV with a header row, e.g. from wago.tools.
+// Columns are looked up by name, so exports of other builds with extra or
+// reordered columns still work.
+type DB2Table struct {
+	Name    string
+	columns map[string]int
+	rows    [][]string
+
+	indices map[string]map[int32]DB2Row
+}
+
+type DB2Row struct {
+	table  *DB2Table
+	values []string
+}
+
+func ParseDB2Table(name string, contents string) *DB2Table {
+	r := csv.NewReader(strings.NewReader(contents))
+	headers, err := r.Read()
+	if err != nil {
+		log.Fatalf("Cannot read %s csv header row: %v", name, err)
+	}
+
+	table := &DB2Table{
+		Name:    name,
+		columns: make(map[string]int, len(headers)),
+		indices: make(map[string]map[int32]DB2Row),
+	}
+	for i, header := range headers {
+		table.columns[header] = i
+	}
+
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Cannot read %s csv row: %v", name, err)
+		}
+		table.rows = append(table.rows, row)
+	}
+	return table
+}
+
+// Reads <dir>/<name>.csv.
+func ReadDB2Table(dir string, name string) *DB2Table {
+	return ParseDB2Table(name, tools.ReadFile(fmt.Sprintf("%s/%s.csv", dir, name)))
+}
+
+func (table *DB2Table) HasColumn(column string) bool {
+	_, ok := table.columns[column]
+	return ok
+}
+
+func (table *DB2Table) Rows() []DB2Row {
+	rows := make([]DB2Row, len(table.rows))
+	for i, values := range table.rows {
+		rows[i] = DB2Row{table: table, values: values}
+	}
+	return rows
+}
+
+// Rows by the value of an int column, e.g. ID. The index is built once per
+// column, so lookups can use this directly.
+func (table *DB2Table) RowsBy(column string) map[int32]DB2Row {
+	if rows, ok := table.indices[column]; ok {
+		return rows
+	}
+	rows := make(map[int32]DB2Row, len(table.rows))
+	for _, row := range table.Rows() {
+		rows[row.Int(column)] = row
+	}
+	table.indices[column] = rows
+	return rows
+}
+
+// Rows by item level. Game tables keyed by item level either have an
+// ItemLevel column or use the level as ID.
+func (table *DB2Table) RowsByItemLevel() map[int32]DB2Row {
+	if table.HasColumn("ItemLevel") {
+		return table.RowsBy("ItemLevel")
+	}
+	return table.RowsBy("ID")
+}
+
+func (row DB2Row) String(column string) string {
+	idx, ok := row.table.columns[column]
+	if !ok {
+		log.Fatalf("The %s csv does not have a %s column", row.table.Name, column)
+	}
+	return row.values[idx]
+}
+
+func (row DB2Row) Int(column string) int32 {
+	value, err := strconv.ParseInt(row.String(column), 10, 64)
+	if err != nil {
+		log.Fatalf("Cannot parse %s.%s from row %v: %v", row.table.Name, column, row.values, err)
+	}
+	// Flag and mask columns are unsigned, so keep their bits.
+	return int32(value)
+}
+
+func (row DB2Row) Float(column string) float64 {
+	value, err := strconv.ParseFloat(row.String(column), 64)
+	if err != nil {
+		log.Fatalf("Cannot parse %s.%s from row %v: %v", row.table.Name, column, row.values, err)
+	}
+	return value
+}
+
+// Columns of array fields are exported as <column>_<index>.
+func (row DB2Row) IntAt(column string, idx int) int32 {
+	return row.Int(fmt.Sprintf("%s_%d", column, idx))
+}
+func (row DB2Row) FloatAt(column string, idx int) float64 {
+	return row.Float(fmt.Sprintf("%s_%d", column, idx))
+}
+
+// The game tables the DB is built from.
+type DB2Tables struct {
+	Items                 *DB2Table
+	ItemSparse            *DB2Table
+	ItemEffects           *DB2Table
+	SpellEffects          *DB2Table
+	ItemSets              *DB2Table
+	RandPropPoints        *DB2Table
+	ItemSocketCosts       *DB2Table
+	ItemRandomSuffixes    *DB2Table
+	GemProperties         *DB2Table
+	SpellItemEnchantments *DB2Table
+
+	ArmorLocations     *DB2Table
+	ItemArmorQualities *DB2Table
+	ItemArmorShields   *DB2Table
+	ItemArmorTotals    *DB2Table
+
+	ItemDamageOneHand       *DB2Table
+	ItemDamageOneHandCaster *DB2Table
+	ItemDamageTwoHand       *DB2Table
+	ItemDamageTwoHandCaster *DB2Table
+
+	spellEffectsBySpellID    map[int32][]DB2Row
+	spellItemEnchantmentByID map[int32]DB2Row
+}
+
+// Reads the CSV exports of the game tables from a directory, named after the
+// tables, e.g. ItemSparse.csv.
+func ReadDB2Tables(dir string) *DB2Tables {
+	tables := &DB2Tables{
+		Items:                 ReadDB2Table(dir, "Item"),
+		ItemSparse:            ReadDB2Table(dir, "ItemSparse"),
+		ItemEffects:           ReadDB2Table(dir, "ItemEffect"),
+		SpellEffects:          ReadDB2Table(dir, "SpellEffect"),
+		ItemSets:              ReadDB2Table(dir, "ItemSet"),
+		RandPropPoints:        ReadDB2Table(dir, "RandPropPoints"),
+		ItemSocketCosts:       ReadDB2Table(dir, "ItemSocketCostPerLevel"),
+		ItemRandomSuffixes:    ReadDB2Table(dir, "ItemRandomSuffix"),
+		GemProperties:         ReadDB2Table(dir, "GemProperties"),
+		SpellItemEnchantments: ReadDB2Table(dir, "SpellItemEnchantment"),
+
+		ArmorLocations:     ReadDB2Table(dir, "ArmorLocation"),
+		ItemArmorQualities: ReadDB2Table(dir, "ItemArmorQuality"),
+		ItemArmorShields:   ReadDB2Table(dir, "ItemArmorShield"),
+		ItemArmorTotals:    ReadDB2Table(dir, "ItemArmorTotal"),
+
+		ItemDamageOneHand:       ReadDB2Table(dir, "ItemDamageOneHand"),
+		ItemDamageOneHandCaster: ReadDB2Table(dir, "ItemDamageOneHandCaster"),
+		ItemDamageTwoHand:       ReadDB2Table(dir, "ItemDamageTwoHand"),
+		ItemDamageTwoHandCaster: ReadDB2Table(dir, "ItemDamageTwoHandCaster"),
+	}
+
+	tables.spellEffectsBySpellID = make(map[int32][]DB2Row)
+	for _, row := range tables.SpellEffects.Rows() {
+		spellID := row.Int("SpellID")
+		tables.spellEffectsBySpellID[spellID] = append(tables.spellEffectsBySpellID[spellID], row)
+	}
+	tables.spellItemEnchantmentByID = tables.SpellItemEnchantments.RowsBy("ID")
+
+	fmt.Printf("\n--\nDB2 items loaded: %d\n--\n", len(tables.ItemSparse.rows))
+	return tables
+}
+
+func (tables *DB2Tables) FactionRestrictions() map[int32]proto.UIItem_FactionRestriction {
+	result := make(map[int32]proto.UIItem_FactionRestriction, len(tables.ItemSparse.rows))
+	for _, row := range tables.ItemSparse.Rows() {
+		result[row.Int(itemIDHeader)] = flags1ToFactionRestriction(int(row.Int(flags1Header)))
+	}
+	return result
+}
+
+func (tables *DB2Tables) RandPropPointsByIlvl() RandomPropAllocationsByIlvl {
+	allocations := make(RandomPropAllocationsByIlvl)
+	for ilvl, row := range tables.RandPropPoints.RowsBy("ID") {
+		var epic, superior, good [5]int32
+		for i := 0; i < 5; i++ {
+			epic[i] = row.IntAt("Epic", i)
+			superior[i] = row.IntAt("Superior", i)
+			good[i] = row.IntAt("Good", i)
+		}
+		// Legendaries and artifacts use the epic budget, heirlooms the superior one.
+		allocations[ilvl] = RandomPropAllocationMap{
+			proto.ItemQuality_ItemQualityArtifact:  epic,
+			proto.ItemQuality_ItemQualityLegendary: epic,
+			proto.ItemQuality_ItemQualityEpic:      epic,
+			proto.ItemQuality_ItemQualityHeirloom:  superior,
+			proto.ItemQuality_ItemQualityRare:      superior,
+			proto.ItemQuality_ItemQualityUncommon:  good,
+		}
+	}
+	return allocations
+}
+
+const (
+	db2ItemClassWeapon = 2
+	db2ItemClassGem    = 3
+	db2ItemClassArmor  = 4
+
+	db2ItemEffectTriggerOnEquip = 1
+
+	db2ItemNameDescriptionHeroic = 3
+
+	db2ItemFlags1CasterWeapon = 0x200
+)
+
+// Builds the items, gems and random suffixes of the DB from the game tables.
+// Only data the game has is filled in; icons, phases, sources and which
+// suffixes items roll come from elsewhere.
+func (tables *DB2Tables) ToDatabase() *WowDatabase {
+	db := NewWowDatabase()
+
+	itemSetNames := map[int32]string{}
+	for id, row := range tables.ItemSets.RowsBy("ID") {
+		itemSetNames[id] = row.String("Name_lang")
+	}
+	equipSpellsByItemID := map[int32][]int32{}
+	for _, row := range tables.ItemEffects.Rows() {
+		if row.Int("TriggerType") == db2ItemEffectTriggerOnEquip {
+			itemID := row.Int("ParentItemID")
+			equipSpellsByItemID[itemID] = append(equipSpellsByItemID[itemID], row.Int("SpellID"))
+		}
+	}
+	gemProperties := tables.GemProperties.RowsBy("ID")
+	randPropPoints := tables.RandPropPointsByIlvl()
+
+	items := tables.Items.RowsBy("ID")
+	for _, sparse := range tables.ItemSparse.Rows() {
+		itemRow, ok := items[sparse.Int("ID")]
+		if !ok {
+			continue
+		}
+
+		switch itemRow.Int("ClassID") {
+		case db2ItemClassWeapon, db2ItemClassArmor:
+			equipStats := Stats{}
+			for _, spellID := range equipSpellsByItemID[sparse.Int("ID")] {
+				equipStats = addStats(equipStats, tables.spellStats(spellID))
+			}
+			if item := tables.toItemProto(itemRow, sparse, randPropPoints, equipStats); item != nil {
+				item.SetName = itemSetNames[sparse.Int("ItemSet")]
+				db.Items[item.Id] = item
+			}
+		case db2ItemClassGem:
+			if properties, ok := gemProperties[sparse.Int("Gem_properties")]; ok {
+				db.Gems[sparse.Int("ID")] = tables.toGemProto(sparse, properties)
+			}
+		}
+	}
+
+	for _, row := range tables.ItemRandomSuffixes.Rows() {
+		suffix := tables.toRandomSuffixProto(row)
+		db.RandomSuffixes[suffix.Id] = suffix
+	}
+
+	return db
+}
+
+func (tables *DB2Tables) toItemProto(itemRow DB2Row, sparse DB2Row, randPropPoints RandomPropAllocationsByIlvl, equipStats Stats) *proto.UIItem {
+	itemClass := itemRow.Int("ClassID")
+	subclass := itemRow.Int("SubclassID")
+	inventoryType := sparse.Int("InventoryType")
+
+	item := &proto.UIItem{
+		Id:   sparse.Int("ID"),
+		Name: sparse.String("Display_lang"),
+
+		Type: db2InventoryTypeToItemType(inventoryType),
+
+		Ilvl:    sparse.Int("ItemLevel"),
+		Quality: proto.ItemQuality(sparse.Int("OverallQualityID")),
+		Heroic:  sparse.Int("ItemNameDescriptionID") == db2ItemNameDescriptionHeroic,
+
+		ClassAllowlist:     db2ClassMaskToClasses(sparse.Int("AllowableClass")),
+		RequiredProfession: db2SkillToProfession(sparse.Int("RequiredSkill")),
+		Expansion:          db2ExpansionToExpansion(sparse.Int("ExpansionID")),
+	}
+	if item.Type == proto.ItemType_ItemTypeUnknown {
+		return nil
+	}
+	item.Unique = sparse.Int("MaxCount") == 1 ||
+		(sparse.Int("LimitCategory") != 0 && item.RequiredProfession != proto.Profession_Jewelcrafting)
+
+	if itemClass == db2ItemClassArmor {
+		switch {
+		case subclass >= 1 && subclass <= 4:
+			item.ArmorType = proto.ArmorType(subclass)
+		case subclass == 6:
+			item.WeaponType = proto.WeaponType_WeaponTypeShield
+			item.HandType = proto.HandType_HandTypeOffHand
+		}
+	}
+	if inventoryType == 23 {
+		item.WeaponType = proto.WeaponType_WeaponTypeOffHand
+		item.HandType = proto.HandType_HandTypeOffHand
+	}
+	if itemClass == db2ItemClassWeapon {
+		item.WeaponType = db2WeaponSubclassToWeaponType(subclass)
+		item.HandType = db2InventoryTypeToHandType(inventoryType)
+		item.RangedWeaponType = db2WeaponSubclassToRangedWeaponType(subclass)
+		item.WeaponSpeed = float64(sparse.Int("ItemDelay")) / 1000
+		item.WeaponDamageMin, item.WeaponDamageMax = tables.weaponDamage(item, inventoryType, sparse)
+	}
+	if inventoryType == 28 {
+		item.RangedWeaponType = proto.RangedWeaponType_RangedWeaponTypeRelic
+	}
+
+	// Stats are fractions of the stat budget of the item level and slot,
+	// which is the same budget random suffixes use, less what the item's
+	// sockets are worth.
+	stats := equipStats
+	if budget := randPropPoints.CalcItemAllocation(item); budget > 0 {
+		socketCost := 0.0
+		if row, ok := tables.ItemSocketCosts.RowsByItemLevel()[item.Ilvl]; ok {
+			socketCost = row.Float("SocketCost")
+		}
+		for i := 0; i < 10; i++ {
+			allocation := sparse.IntAt("StatPercentEditor", i)
+			if allocation == 0 {
+				continue
+			}
+			value := math.Floor(float64(allocation)*float64(budget)*0.0001 - sparse.FloatAt("StatPercentageOfSocket", i)*socketCost + 0.5)
+			stats = addStats(stats, itemModStats(sparse.IntAt("StatModifier_bonusStat", i), value))
+		}
+	}
+	if itemClass == db2ItemClassArmor {
+		stats[proto.Stat_StatArmor] = tables.armor(item, subclass, inventoryType)
+	}
+	item.Stats = toSlice(stats)
+
+	for i := 0; i < 3; i++ {
+		if color := db2SocketTypeToGemColor(sparse.IntAt("SocketType", i)); color != proto.GemColor_GemColorUnknown {
+			item.GemSockets = append(item.GemSockets, color)
+		}
+	}
+	item.SocketBonus = toSlice(tables.enchantmentStats(sparse.Int("SocketMatch_enchantment_ID")))
+
+	return item
+}
+
+func (tables *DB2Tables) toGemProto(sparse DB2Row, properties DB2Row) *proto.UIGem {
+	profession := db2SkillToProfession(sparse.Int("RequiredSkill"))
+	return &proto.UIGem{
+		Id:    sparse.Int("ID"),
+		Name:  sparse.String("Display_lang"),
+		Color: db2GemTypeToGemColor(properties.Int("Type")),
+
+		Stats: toSlice(tables.enchantmentStats(properties.Int("Enchant_ID"))),
+
+		Quality:            proto.ItemQuality(sparse.Int("OverallQualityID")),
+		Unique:             sparse.Int("MaxCount") == 1 || (sparse.Int("LimitCategory") != 0 && profession != proto.Profession_Jewelcrafting),
+		RequiredProfession: profession,
+	}
+}
+
+// Random suffix stats are the fraction of the item's budget, in 1/10000, as the
+// sim expects.
+func (tables *DB2Tables) toRandomSuffixProto(row DB2Row) *proto.ItemRandomSuffix {
+	stats := Stats{}
+	for i := 0; i < 5; i++ {
+		enchantment, ok := tables.spellItemEnchantmentByID[row.IntAt("Enchantment", i)]
+		if !ok {
+			continue
+		}
+		allocation := float64(row.IntAt("AllocationPct", i))
+		for j := 0; j < 3; j++ {
+			stats = addStats(stats, enchantmentEffectStats(enchantment.IntAt("Effect", j), enchantment.IntAt("EffectArg", j), allocation))
+		}
+	}
+	return &proto.ItemRandomSuffix{
+		Id:    row.Int("ID"),
+		Name:  row.String("Name_lang"),
+		Stats: toSlice(stats),
+	}
+}
+
+// Armor of cloth to plate items is the total armor of the item level, scaled by
+// the slot and quality. Shields have their own table.
+func (tables *DB2Tables) armor(item *proto.UIItem, subclass int32, inventoryType int32) float64 {
+	quality := db2QualityIndex(item.Quality)
+
+	if subclass == 6 {
+		shield, ok := tables.ItemArmorShields.RowsByItemLevel()[item.Ilvl]
+		if !ok {
+			return 0
+		}
+		return math.Floor(shield.FloatAt("Quality", quality) + 0.5)
+	}
+	if subclass < 1 || subclass > 4 {
+		return 0
+	}
+
+	if inventoryType == 20 { // Robes use the chest modifiers.
+		inventoryType = 5
+	}
+	total, hasTotal := tables.ItemArmorTotals.RowsByItemLevel()[item.Ilvl]
+	qualityRow, hasQuality := tables.ItemArmorQualities.RowsByItemLevel()[item.Ilvl]
+	location, hasLocation := tables.ArmorLocations.RowsBy("ID")[inventoryType]
+	if !hasTotal || !hasQuality || !hasLocation {
+		return 0
+	}
+
+	armorColumns := [][2]string{{"Cloth", "Clothmodifier"}, {"Leather", "Leathermodifier"}, {"Mail", "Chainmodifier"}, {"Plate", "Platemodifier"}}[subclass-1]
+	return math.Floor(qualityRow.FloatAt("Qualitymod", quality)*total.Float(armorColumns[0])*location.Float(armorColumns[1]) + 0.5)
+}
+
+// Weapon damage is the DPS of the item level and quality for the kind of
+// weapon, spread around its average by the item's damage variance.
+func (tables *DB2Tables) weaponDamage(item *proto.UIItem, inventoryType int32, sparse DB2Row) (float64, float64) {
+	caster := sparse.IntAt("Flags", 1)&db2ItemFlags1CasterWeapon != 0
+
+	var damageTable *DB2Table
+	switch {
+	case item.RangedWeaponType == proto.RangedWeaponType_RangedWeaponTypeWand:
+		damageTable = tables.ItemDamageOneHandCaster
+	case item.Type == proto.ItemType_ItemTypeRanged || inventoryType == 17:
+		damageTable = core.Ternary(caster, tables.ItemDamageTwoHandCaster, tables.ItemDamageTwoHand)
+	default:
+		damageTable = core.Ternary(caster, tables.ItemDamageOneHandCaster, tables.ItemDamageOneHand)
+	}
+
+	row, ok := damageTable.RowsByItemLevel()[item.Ilvl]
+	if !ok || item.WeaponSpeed == 0 {
+		return 0, 0
+	}
+	avgDamage := row.FloatAt("Quality", db2QualityIndex(item.Quality)) * item.WeaponSpeed
+	variance := sparse.Float("DmgVariance")
+	return math.Floor(avgDamage * (1 - variance*0.5)), math.Floor(avgDamage*(1+variance*0.5) + 0.5)
+}
+
+// Armor and weapon damage tables have columns up to artifact quality. Heirlooms
+// scale like superior items.
+func db2QualityIndex(quality proto.ItemQuality) int {
+	if quality == proto.ItemQuality_ItemQualityHeirloom {
+		return int(proto.ItemQuality_ItemQualityRare)
+	}
+	return int(min(quality, proto.ItemQuality_ItemQualityArtifact))
+}
+
+// Stats of an enchantment, e.g. a gem, socket bonus or equip spell.
+func (tables *DB2Tables) enchantmentStats(enchantmentID int32) Stats {
+	stats := Stats{}
+	enchantment, ok := tables.spellItemEnchantmentByID[enchantmentID]
+	if !ok {
+		return stats
+	}
+	for i := 0; i < 3; i++ {
+		effect := enchantment.IntAt("Effect", i)
+		arg := enchantment.IntAt("EffectArg", i)
+		if effect == 3 { // Equip spell
+			stats = addStats(stats, tables.spellStats(arg))
+			continue
+		}
+		stats = addStats(stats, enchantmentEffectStats(effect, arg, float64(enchantment.IntAt("EffectPointsMin", i))))
+	}
+	return stats
+}
+
+func enchantmentEffectStats(effect int32, arg int32, value float64) Stats {
+	switch effect {
+	case 4: // Resistance
+		return resistanceStats(int32(1)<<arg, value)
+	case 5: // Stat
+		return itemModStats(arg, value)
+	}
+	return Stats{}
+}
+
+// Passive stats from the auras of an equip spell, as used by older items for
+// e.g. spell power or mp5.
+func (tables *DB2Tables) spellStats(spellID int32) Stats {
+	stats := Stats{}
+	for _, effect := range tables.spellEffectsBySpellID[spellID] {
+		if effect.Int("Effect") != 6 { // Apply aura
+			continue
+		}
+		value := effect.Float("EffectBasePoints")
+		misc := effect.IntAt("EffectMiscValue", 0)
+
+		switch effect.Int("EffectAura") {
+		case 13: // Mod damage done
+			if misc&126 == 126 {
+				stats[proto.Stat_StatSpellPower] += value
+			}
+		case 22: // Mod resistance
+			stats = addStats(stats, resistanceStats(misc, value))
+		case 29: // Mod stat
+			for i, stat := range []proto.Stat{proto.Stat_StatStrength, proto.Stat_StatAgility, proto.Stat_StatStamina, proto.Stat_StatIntellect, proto.Stat_StatSpirit} {
+				if misc == -1 || misc == int32(i) {
+					stats[stat] += value
+				}
+			}
+		case 85: // Mod power regen
+			if misc == 0 {
+				stats[proto.Stat_StatMP5] += value
+			}
+		case 99: // Mod attack power
+			stats[proto.Stat_StatAttackPower] += value
+			stats[proto.Stat_StatRangedAttackPower] += value
+		case 123: // Mod target resistance
+			stats[proto.Stat_StatSpellPenetration] -= value
+		case 124: // Mod ranged attack power
+			stats[proto.Stat_StatRangedAttackPower] += value
+		case 189: // Mod rating
+			stats = addStats(stats, combatRatingStats(misc, value))
+		}
+	}
+	return stats
+}
+
+// Item stat types, the ITEM_MOD values of item and enchantment stats.
+func itemModStats(itemMod int32, value float64) Stats {
+	stats := Stats{}
+	switch itemMod {
+	case 0:
+		stats[proto.Stat_StatMana] = value
+	case 1:
+		stats[proto.Stat_StatHealth] = value
+	case 3:
+		stats[proto.Stat_StatAgility] = value
+	case 4:
+		stats[proto.Stat_StatStrength] = value
+	case 5:
+		stats[proto.Stat_StatIntellect] = value
+	case 6:
+		stats[proto.Stat_StatSpirit] = value
+	case 7:
+		stats[proto.Stat_StatStamina] = value
+	case 12:
+		stats[proto.Stat_StatDefense] = value
+	case 13:
+		stats[proto.Stat_StatDodge] = value
+	case 14:
+		stats[proto.Stat_StatParry] = value
+	case 15:
+		stats[proto.Stat_StatBlock] = value
+	case 31:
+		stats[proto.Stat_StatMeleeHit] = value
+		stats[proto.Stat_StatSpellHit] = value
+	case 32:
+		stats[proto.Stat_StatMeleeCrit] = value
+		stats[proto.Stat_StatSpellCrit] = value
+	case 35:
+		stats[proto.Stat_StatResilience] = value
+	case 36:
+		stats[proto.Stat_StatMeleeHaste] = value
+		stats[proto.Stat_StatSpellHaste] = value
+	case 37:
+		stats[proto.Stat_StatExpertise] = value
+	case 38:
+		stats[proto.Stat_StatAttackPower] = value
+		stats[proto.Stat_StatRangedAttackPower] = value
+	case 39:
+		stats[proto.Stat_StatRangedAttackPower] = value
+	case 43:
+		stats[proto.Stat_StatMP5] = value
+	case 44:
+		stats[proto.Stat_StatArmorPenetration] = value
+	case 45:
+		stats[proto.Stat_StatSpellPower] = value
+	case 47:
+		stats[proto.Stat_StatSpellPenetration] = value
+	case 48:
+		stats[proto.Stat_StatBlockValue] = value
+	case 49:
+		stats[proto.Stat_StatMastery] = value
+	case 50:
+		stats[proto.Stat_StatBonusArmor] = value
+	case 51:
+		stats[proto.Stat_StatFireResistance] = value
+	case 52:
+		stats[proto.Stat_StatFrostResistance] = value
+	case 54:
+		stats[proto.Stat_StatShadowResistance] = value
+	case 55:
+		stats[proto.Stat_StatNatureResistance] = value
+	case 56:
+		stats[proto.Stat_StatArcaneResistance] = value
+	}
+	return stats
+}
+
+// Resistances by spell school mask, with armor as physical.
+func resistanceStats(schoolMask int32, value float64) Stats {
+	stats := Stats{}
+	for school, stat := range map[int32]proto.Stat{
+		1:  proto.Stat_StatBonusArmor,
+		4:  proto.Stat_StatFireResistance,
+		8:  proto.Stat_StatNatureResistance,
+		16: proto.Stat_StatFrostResistance,
+		32: proto.Stat_StatShadowResistance,
+		64: proto.Stat_StatArcaneResistance,
+	} {
+		if schoolMask&school != 0 {
+			stats[stat] += value
+		}
+	}
+	return stats
+}
+
+// Ratings by combat rating mask. Melee, ranged and spell variants of a rating
+// are the same stat.
+func combatRatingStats(ratingMask int32, value float64) Stats {
+	stats := Stats{}
+	for _, rating := range []struct {
+		mask  int32
+		stats []proto.Stat
+	}{
+		{1 << 1, []proto.Stat{proto.Stat_StatDefense}},
+		{1 << 2, []proto.Stat{proto.Stat_StatDodge}},
+		{1 << 3, []proto.Stat{proto.Stat_StatParry}},
+		{1 << 4, []proto.Stat{proto.Stat_StatBlock}},
+		{1<<5 | 1<<6 | 1<<7, []proto.Stat{proto.Stat_StatMeleeHit, proto.Stat_StatSpellHit}},
+		{1<<8 | 1<<9 | 1<<10, []proto.Stat{proto.Stat_StatMeleeCrit, proto.Stat_StatSpellCrit}},
+		{1 << 15, []proto.Stat{proto.Stat_StatResilience}},
+		{1<<17 | 1<<18 | 1<<19, []proto.Stat{proto.Stat_StatMeleeHaste, proto.Stat_StatSpellHaste}},
+		{1 << 23, []proto.Stat{proto.Stat_StatExpertise}},
+		{1 << 24, []proto.Stat{proto.Stat_StatArmorPenetration}},
+		{1 << 25, []proto.Stat{proto.Stat_StatMastery}},
+	} {
+		if ratingMask&rating.mask == 0 {
+			continue
+		}
+		for _, stat := range rating.stats {
+			stats[stat] += value
+		}
+	}
+	return stats
+}
+
+func addStats(a Stats, b Stats) Stats {
+	for i := range a {
+		a[i] += b[i]
+	}
+	return a
+}
+
+func db2InventoryTypeToItemType(inventoryType int32) proto.ItemType {
+	switch inventoryType {
+	case 1:
+		return proto.ItemType_ItemTypeHead
+	case 2:
+		return proto.ItemType_ItemTypeNeck
+	case 3:
+		return proto.ItemType_ItemTypeShoulder
+	case 5, 20:
+		return proto.ItemType_ItemTypeChest
+	case 6:
+		return proto.ItemType_ItemTypeWaist
+	case 7:
+		return proto.ItemType_ItemTypeLegs
+	case 8:
+		return proto.ItemType_ItemTypeFeet
+	case 9:
+		return proto.ItemType_ItemTypeWrist
+	case 10:
+		return proto.ItemType_ItemTypeHands
+	case 11:
+		return proto.ItemType_ItemTypeFinger
+	case 12:
+		return proto.ItemType_ItemTypeTrinket
+	case 16:
+		return proto.ItemType_ItemTypeBack
+	case 13, 14, 17, 21, 22, 23:
+		return proto.ItemType_ItemTypeWeapon
+	case 15, 25, 26, 28:
+		return proto.ItemType_ItemTypeRanged
+	}
+	return proto.ItemType_ItemTypeUnknown
+}
+
+func db2InventoryTypeToHandType(inventoryType int32) proto.HandType {
+	switch inventoryType {
+	case 13:
+		return proto.HandType_HandTypeOneHand
+	case 17:
+		return proto.HandType_HandTypeTwoHand
+	case 21:
+		return proto.HandType_HandTypeMainHand
+	case 14, 22, 23:
+		return proto.HandType_HandTypeOffHand
+	}
+	return proto.HandType_HandTypeUnknown
+}
+
+func db2WeaponSubclassToWeaponType(subclass int32) proto.WeaponType {
+	switch subclass {
+	case 0, 1:
+		return proto.WeaponType_WeaponTypeAxe
+	case 4, 5:
+		return proto.WeaponType_WeaponTypeMace
+	case 6:
+		return proto.WeaponType_WeaponTypePolearm
+	case 7, 8:
+		return proto.WeaponType_WeaponTypeSword
+	case 10:
+		return proto.WeaponType_WeaponTypeStaff
+	case 13:
+		return proto.WeaponType_WeaponTypeFist
+	case 15:
+		return proto.WeaponType_WeaponTypeDagger
+	}
+	return proto.WeaponType_WeaponTypeUnknown
+}
+
+func db2WeaponSubclassToRangedWeaponType(subclass int32) proto.RangedWeaponType {
+	switch subclass {
+	case 2:
+		return proto.RangedWeaponType_RangedWeaponTypeBow
+	case 3:
+		return proto.RangedWeaponType_RangedWeaponTypeGun
+	case 16:
+		return proto.RangedWeaponType_RangedWeaponTypeThrown
+	case 18:
+		return proto.RangedWeaponType_RangedWeaponTypeCrossbow
+	case 19:
+		return proto.RangedWeaponType_RangedWeaponTypeWand
+	}
+	return proto.RangedWeaponType_RangedWeaponTypeUnknown
+}
+
+// Item sockets are socket color indices.
+func db2SocketTypeToGemColor(socketType int32) proto.GemColor {
+	switch socketType {
+	case 1:
+		return proto.GemColor_GemColorMeta
+	case 2:
+		return proto.GemColor_GemColorRed
+	case 3:
+		return proto.GemColor_GemColorYellow
+	case 4:
+		return proto.GemColor_GemColorBlue
+	case 6:
+		return proto.GemColor_GemColorCogwheel
+	case 7:
+		return proto.GemColor_GemColorPrismatic
+	}
+	return proto.GemColor_GemColorUnknown
+}
+
+// Gem types are masks of the socket colors they match.
+func db2GemTypeToGemColor(gemType int32) proto.GemColor {
+	switch gemType {
+	case 1:
+		return proto.GemColor_GemColorMeta
+	case 2:
+		return proto.GemColor_GemColorRed
+	case 4:
+		return proto.GemColor_GemColorYellow
+	case 6:
+		return proto.GemColor_GemColorOrange
+	case 8:
+		return proto.GemColor_GemColorBlue
+	case 10:
+		return proto.GemColor_GemColorPurple
+	case 12:
+		return proto.GemColor_GemColorGreen
+	case 14:
+		return proto.GemColor_GemColorPrismatic
+	case 32:
+		return proto.GemColor_GemColorCogwheel
+	}
+	return proto.GemColor_GemColorUnknown
+}
+
+// Class masks use the game's class IDs.
+func db2ClassMaskToClasses(classMask int32) []proto.Class {
+	gameClasses := []proto.Class{
+		1:  proto.Class_ClassWarrior,
+		2:  proto.Class_ClassPaladin,
+		3:  proto.Class_ClassHunter,
+		4:  proto.Class_ClassRogue,
+		5:  proto.Class_ClassPriest,
+		6:  proto.Class_ClassDeathKnight,
+		7:  proto.Class_ClassShaman,
+		8:  proto.Class_ClassMage,
+		9:  proto.Class_ClassWarlock,
+		11: proto.Class_ClassDruid,
+	}
+
+	var classes []proto.Class
+	for classID, class := range gameClasses {
+		if class != proto.Class_ClassUnknown && classMask&(1<<(classID-1)) != 0 {
+			classes = append(classes, class)
+		}
+	}
+	// Items usable by every class have no restriction.
+	if len(classes) == len(proto.Class_name)-1 {
+		return nil
+	}
+	return classes
+}
+
+func db2SkillToProfession(skillLine int32) proto.Profession {
+	switch skillLine {
+	case 171:
+		return proto.Profession_Alchemy
+	case 164:
+		return proto.Profession_Blacksmithing
+	case 333:
+		return proto.Profession_Enchanting
+	case 202:
+		return proto.Profession_Engineering
+	case 182:
+		return proto.Profession_Herbalism
+	case 773:
+		return proto.Profession_Inscription
+	case 755:
+		return proto.Profession_Jewelcrafting
+	case 165:
+		return proto.Profession_Leatherworking
+	case 186:
+		return proto.Profession_Mining
+	case 393:
+		return proto.Profession_Skinning
+	case 197:
+		return proto.Profession_Tailoring
+	}
+	return proto.Profession_ProfessionUnknown
+}
+
+func db2ExpansionToExpansion(expansionID int32) proto.Expansion {
+	switch expansionID {
+	case 0:
+		return proto.Expansion_ExpansionVanilla
+	case 1:
+		return proto.Expansion_ExpansionTbc
+	case 2:
+		return proto.Expansion_ExpansionWotlk
+	case 3:
+		return proto.Expansion_ExpansionCata
+	}
+	return proto.Expansion_ExpansionUnknown
+}
+
+// Returns the item data a db built from Wowhead has that the DB2 tables don't,
+// like icons, phases and the random suffixes items roll. Like Wowhead's
+// gearplanner db, its items are also the ones obtainable in game.
+func (db *WowDatabase) ToDB2Metadata() *WowDatabase {
+	metadata := NewWowDatabase()
+	for id, item := range db.Items {
+		metadata.Items[id] = &proto.UIItem{
+			Id:                  id,
+			Icon:                item.Icon,
+			Phase:               item.Phase,
+			RandomSuffixOptions: item.RandomSuffixOptions,
+			Sources:             item.Sources,
+		}
+	}
+	for id, gem := range db.Gems {
+		metadata.Gems[id] = &proto.UIGem{Id: id, Icon: gem.Icon, Phase: gem.Phase}
+	}
+	metadata.ItemIcons = maps.Clone(db.ItemIcons)
+	metadata.SpellIcons = maps.Clone(db.SpellIcons)
+	return metadata
+}
+
+// Drops the items metadata doesn't list, which aren't obtainable in game, and
+// fills in the data the DB2 tables don't have.
+func (db *WowDatabase) ApplyDB2Metadata(metadata *WowDatabase) {
+	db.Items = core.FilterMap(db.Items, func(id int32, _ *proto.UIItem) bool {
+		_, ok := metadata.Items[id]
+		return ok
+	})
+	for id, item := range db.Items {
+		itemMetadata := metadata.Items[id]
+		item.Icon = itemMetadata.Icon
+		item.Phase = itemMetadata.Phase
+		item.RandomSuffixOptions = itemMetadata.RandomSuffixOptions
+		if len(item.Sources) == 0 {
+			item.Sources = itemMetadata.Sources
+		}
+	}
+	for id, gem := range db.Gems {
+		if gemMetadata, ok := metadata.Gems[id]; ok {
+			gem.Icon = gemMetadata.Icon
+			gem.Phase = gemMetadata.Phase
+		}
+	}
+	for id, icon := range metadata.ItemIcons {
+		db.ItemIcons[id] = icon
+	}
+	for id, icon := range metadata.SpellIcons {
+		db.SpellIcons[id] = icon
+	}
+}
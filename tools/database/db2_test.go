@@ -0,0 +1,231 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Writes <dir>/<name>.csv with the given columns. Columns a row doesn't set
+// are 0, like unused fields in client exports.
+func writeDB2Fixture(t *testing.T, dir string, name string, columns []string, rows ...map[string]string) {
+	lines := []string{strings.Join(columns, ",")}
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = "0"
+			if value, ok := row[column]; ok {
+				values[i] = value
+			}
+		}
+		lines = append(lines, strings.Join(values, ","))
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".csv"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Array columns, e.g. Epic_0 to Epic_4.
+func db2ArrayColumns(column string, n int) []string {
+	columns := make([]string, n)
+	for i := range columns {
+		columns[i] = fmt.Sprintf("%s_%d", column, i)
+	}
+	return columns
+}
+
+func db2Columns(columns ...any) []string {
+	var result []string
+	for _, column := range columns {
+		switch column := column.(type) {
+		case string:
+			result = append(result, column)
+		case []string:
+			result = append(result, column...)
+		}
+	}
+	return result
+}
+
+func writeDB2TablesFixture(t *testing.T) string {
+	dir := t.TempDir()
+
+	writeDB2Fixture(t, dir, "Item", []string{"ID", "ClassID", "SubclassID"},
+		map[string]string{"ID": "990001", "ClassID": "4", "SubclassID": "4"},
+		map[string]string{"ID": "990002", "ClassID": "2", "SubclassID": "10"},
+		map[string]string{"ID": "990003", "ClassID": "4", "SubclassID": "2"},
+	)
+	writeDB2Fixture(t, dir, "ItemSparse", db2Columns(
+		"ID", "Display_lang", "InventoryType", "ItemLevel", "OverallQualityID", "ItemNameDescriptionID",
+		"AllowableClass", "RequiredSkill", "ExpansionID", "MaxCount", "LimitCategory", "ItemSet", "Gem_properties",
+		"ItemDelay", "DmgVariance", "SocketMatch_enchantment_ID", db2ArrayColumns("Flags", 2),
+		db2ArrayColumns("StatPercentEditor", 10), db2ArrayColumns("StatModifier_bonusStat", 10),
+		db2ArrayColumns("StatPercentageOfSocket", 10), db2ArrayColumns("SocketType", 3)),
+		// An epic plate chest with two sockets.
+		map[string]string{
+			"ID": "990001", "Display_lang": "Socketed Battleplate", "InventoryType": "5", "ItemLevel": "378", "OverallQualityID": "4",
+			"AllowableClass": "-1", "ExpansionID": "3", "SocketMatch_enchantment_ID": "990010",
+			"StatPercentEditor_0": "4615", "StatModifier_bonusStat_0": "4", "StatPercentageOfSocket_0": "0.5",
+			"StatPercentEditor_1": "6923", "StatModifier_bonusStat_1": "7", "StatPercentageOfSocket_1": "0.75",
+			"StatPercentEditor_2": "3077", "StatModifier_bonusStat_2": "32", "StatPercentageOfSocket_2": "0.25",
+			"StatPercentEditor_3": "3077", "StatModifier_bonusStat_3": "49", "StatPercentageOfSocket_3": "0.25",
+			"SocketType_0": "2", "SocketType_1": "3",
+		},
+		// A legendary caster staff.
+		map[string]string{
+			"ID": "990002", "Display_lang": "Legendary Staff", "InventoryType": "17", "ItemLevel": "397", "OverallQualityID": "5",
+			"AllowableClass": "-1", "ExpansionID": "3", "MaxCount": "1", "ItemDelay": "3000", "DmgVariance": "0.4", "Flags_1": "512",
+			"StatPercentEditor_0": "5000", "StatModifier_bonusStat_0": "5",
+			"StatPercentEditor_1": "7500", "StatModifier_bonusStat_1": "7",
+		},
+		// A leather heirloom, scaling like a superior item.
+		map[string]string{
+			"ID": "990003", "Display_lang": "Heirloom Spaulders", "InventoryType": "3", "ItemLevel": "333", "OverallQualityID": "7",
+			"AllowableClass": "-1", "ExpansionID": "2",
+			"StatPercentEditor_0": "10000", "StatModifier_bonusStat_0": "3",
+		},
+	)
+	writeDB2Fixture(t, dir, "ItemEffect", []string{"ID", "ParentItemID", "TriggerType", "SpellID"})
+	writeDB2Fixture(t, dir, "SpellEffect", []string{"ID", "SpellID", "Effect", "EffectAura", "EffectBasePoints", "EffectMiscValue_0"})
+	writeDB2Fixture(t, dir, "ItemSet", []string{"ID", "Name_lang"})
+	writeDB2Fixture(t, dir, "RandPropPoints", db2Columns("ID", db2ArrayColumns("Epic", 5), db2ArrayColumns("Superior", 5), db2ArrayColumns("Good", 5)),
+		map[string]string{"ID": "333", "Epic_1": "400", "Superior_1": "300"},
+		map[string]string{"ID": "378", "Epic_0": "775"},
+		map[string]string{"ID": "397", "Epic_0": "925"},
+	)
+	writeDB2Fixture(t, dir, "ItemSocketCostPerLevel", []string{"ID", "SocketCost"},
+		map[string]string{"ID": "378", "SocketCost": "40"},
+	)
+	writeDB2Fixture(t, dir, "ItemRandomSuffix", db2Columns("ID", "Name_lang", db2ArrayColumns("Enchantment", 5), db2ArrayColumns("AllocationPct", 5)))
+	writeDB2Fixture(t, dir, "GemProperties", []string{"ID", "Enchant_ID", "Type"})
+	writeDB2Fixture(t, dir, "SpellItemEnchantment", db2Columns("ID", db2ArrayColumns("Effect", 3), db2ArrayColumns("EffectArg", 3), db2ArrayColumns("EffectPointsMin", 3)),
+		map[string]string{"ID": "990010", "Effect_0": "5", "EffectArg_0": "4", "EffectPointsMin_0": "20"},
+	)
+
+	writeDB2Fixture(t, dir, "ArmorLocation", []string{"ID", "Clothmodifier", "Leathermodifier", "Chainmodifier", "Platemodifier"},
+		map[string]string{"ID": "3", "Leathermodifier": "0.2"},
+		map[string]string{"ID": "5", "Platemodifier": "0.16"},
+	)
+	writeDB2Fixture(t, dir, "ItemArmorQuality", db2Columns("ID", db2ArrayColumns("Qualitymod", 7)),
+		map[string]string{"ID": "333", "Qualitymod_3": "0.9"},
+		map[string]string{"ID": "378", "Qualitymod_4": "1"},
+	)
+	writeDB2Fixture(t, dir, "ItemArmorShield", db2Columns("ID", db2ArrayColumns("Quality", 7)))
+	writeDB2Fixture(t, dir, "ItemArmorTotal", []string{"ID", "Cloth", "Leather", "Mail", "Plate"},
+		map[string]string{"ID": "333", "Leather": "1000"},
+		map[string]string{"ID": "378", "Plate": "5000"},
+	)
+
+	for _, name := range []string{"ItemDamageOneHand", "ItemDamageOneHandCaster", "ItemDamageTwoHand"} {
+		writeDB2Fixture(t, dir, name, db2Columns("ID", db2ArrayColumns("Quality", 7)))
+	}
+	writeDB2Fixture(t, dir, "ItemDamageTwoHandCaster", db2Columns("ID", db2ArrayColumns("Quality", 7)),
+		map[string]string{"ID": "397", "Quality_5": "800"},
+	)
+
+	return dir
+}
+
+func TestDB2ToDatabase(t *testing.T) {
+	db := ReadDB2Tables(writeDB2TablesFixture(t)).ToDatabase()
+
+	expected := []*proto.UIItem{
+		{
+			Id:        990001,
+			Name:      "Socketed Battleplate",
+			Type:      proto.ItemType_ItemTypeChest,
+			ArmorType: proto.ArmorType_ArmorTypePlate,
+			Ilvl:      378,
+			Quality:   proto.ItemQuality_ItemQualityEpic,
+			Expansion: proto.Expansion_ExpansionCata,
+			// Each stat gives up its share of the 40 the sockets are worth.
+			Stats: toSlice(Stats{
+				proto.Stat_StatStrength:  338,
+				proto.Stat_StatStamina:   507,
+				proto.Stat_StatMeleeCrit: 228,
+				proto.Stat_StatSpellCrit: 228,
+				proto.Stat_StatMastery:   228,
+				proto.Stat_StatArmor:     800,
+			}),
+			GemSockets:  []proto.GemColor{proto.GemColor_GemColorRed, proto.GemColor_GemColorYellow},
+			SocketBonus: toSlice(Stats{proto.Stat_StatStrength: 20}),
+		},
+		{
+			Id:              990002,
+			Name:            "Legendary Staff",
+			Type:            proto.ItemType_ItemTypeWeapon,
+			WeaponType:      proto.WeaponType_WeaponTypeStaff,
+			HandType:        proto.HandType_HandTypeTwoHand,
+			WeaponSpeed:     3,
+			WeaponDamageMin: 1920,
+			WeaponDamageMax: 2880,
+			Ilvl:            397,
+			Quality:         proto.ItemQuality_ItemQualityLegendary,
+			Unique:          true,
+			Expansion:       proto.Expansion_ExpansionCata,
+			Stats: toSlice(Stats{
+				proto.Stat_StatIntellect: 463,
+				proto.Stat_StatStamina:   694,
+			}),
+			SocketBonus: toSlice(Stats{}),
+		},
+		{
+			Id:          990003,
+			Name:        "Heirloom Spaulders",
+			Type:        proto.ItemType_ItemTypeShoulder,
+			ArmorType:   proto.ArmorType_ArmorTypeLeather,
+			Ilvl:        333,
+			Quality:     proto.ItemQuality_ItemQualityHeirloom,
+			Expansion:   proto.Expansion_ExpansionWotlk,
+			Stats:       toSlice(Stats{proto.Stat_StatAgility: 300, proto.Stat_StatArmor: 180}),
+			SocketBonus: toSlice(Stats{}),
+		},
+	}
+
+	ids := make([]int32, 0, len(db.Items))
+	for id := range db.Items {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []int32{990001, 990002, 990003}) {
+		t.Fatalf("Expected items 990001 to 990003, got %v", ids)
+	}
+	for _, expectedItem := range expected {
+		if item := db.Items[expectedItem.Id]; !googleProto.Equal(item, expectedItem) {
+			t.Errorf("Item %d differs from the expected DB item\nGot:      %v\nExpected: %v", expectedItem.Id, item, expectedItem)
+		}
+	}
+}
+
+func TestApplyDB2Metadata(t *testing.T) {
+	db := ReadDB2Tables(writeDB2TablesFixture(t)).ToDatabase()
+
+	wowheadDB := NewWowDatabase()
+	wowheadDB.Items[990001] = &proto.UIItem{Id: 990001, Name: "Socketed Battleplate", Icon: "inv_chest_plate", Phase: 2, RandomSuffixOptions: []int32{-1}}
+	wowheadDB.Items[990003] = &proto.UIItem{Id: 990003, Name: "Heirloom Spaulders", Icon: "inv_shoulder_leather"}
+	wowheadDB.SpellIcons[12345] = &proto.IconData{Id: 12345, Icon: "spell_icon"}
+	metadata := wowheadDB.ToDB2Metadata()
+	if metadata.Items[990001].Name != "" {
+		t.Fatalf("Expected the metadata to leave out data the DB2 tables have")
+	}
+
+	db.ApplyDB2Metadata(metadata)
+	if _, ok := db.Items[990002]; ok || len(db.Items) != 2 {
+		t.Fatalf("Expected only the items in the metadata to be kept, got %d items", len(db.Items))
+	}
+	if item := db.Items[990001]; item.Icon != "inv_chest_plate" || item.Phase != 2 || !slices.Equal(item.RandomSuffixOptions, []int32{-1}) {
+		t.Fatalf("Expected the icon, phase and random suffixes from the metadata, got %v", item)
+	}
+	if item := db.Items[990001]; item.Name != "Socketed Battleplate" || item.Ilvl != 378 {
+		t.Fatalf("Expected the DB2 data to be kept, got %v", item)
+	}
+	if db.SpellIcons[12345].GetIcon() != "spell_icon" {
+		t.Fatalf("Expected the spell icons from the metadata")
+	}
+}
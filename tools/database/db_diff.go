@@ -0,0 +1,282 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/tools"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Differences between two generated databases, for reviewing DB refreshes.
+type DatabaseDiff struct {
+	Items          EntityDiffs `json:"items"`
+	Gems           EntityDiffs `json:"gems"`
+	Enchants       EntityDiffs `json:"enchants"`
+	RandomSuffixes EntityDiffs `json:"randomSuffixes"`
+	ReforgeStats   EntityDiffs `json:"reforgeStats"`
+
+	Sims []*SimDiff `json:"sims,omitempty"`
+}
+
+type EntityDiffs struct {
+	Added   []*EntityChange `json:"added,omitempty"`
+	Removed []*EntityChange `json:"removed,omitempty"`
+	Changed []*EntityChange `json:"changed,omitempty"`
+}
+
+type EntityChange struct {
+	// ID of the entity, or effect/item/spell IDs for enchants.
+	Key  string `json:"key"`
+	Name string `json:"name,omitempty"`
+
+	Fields []*FieldDiff `json:"fields,omitempty"`
+}
+
+type FieldDiff struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Result of a sim request against both databases.
+type SimDiff struct {
+	Name          string  `json:"name"`
+	OldDps        float64 `json:"oldDps"`
+	NewDps        float64 `json:"newDps"`
+	ChangePercent float64 `json:"changePercent"`
+
+	// Whether the change is above the threshold, or either sim failed.
+	Flagged bool   `json:"flagged"`
+	Error   string `json:"error,omitempty"`
+}
+
+type NamedSimRequest struct {
+	Name    string
+	Request *proto.RaidSimRequest
+}
+
+func DiffDatabases(oldDB *WowDatabase, newDB *WowDatabase) *DatabaseDiff {
+	return &DatabaseDiff{
+		Items:          diffEntities(byStringKey(oldDB.Items), byStringKey(newDB.Items)),
+		Gems:           diffEntities(byStringKey(oldDB.Gems), byStringKey(newDB.Gems)),
+		Enchants:       diffEntities(enchantsByStringKey(oldDB.Enchants), enchantsByStringKey(newDB.Enchants)),
+		RandomSuffixes: diffEntities(byStringKey(oldDB.RandomSuffixes), byStringKey(newDB.RandomSuffixes)),
+		ReforgeStats:   diffEntities(byStringKey(oldDB.ReforgeStats), byStringKey(newDB.ReforgeStats)),
+	}
+}
+
+func byStringKey[T googleProto.Message](entities map[int32]T) map[string]T {
+	return core.MapMap(entities, func(id int32, entity T) (string, T) {
+		return strconv.Itoa(int(id)), entity
+	})
+}
+
+func enchantsByStringKey(enchants map[EnchantDBKey]*proto.UIEnchant) map[string]*proto.UIEnchant {
+	return core.MapMap(enchants, func(key EnchantDBKey, enchant *proto.UIEnchant) (string, *proto.UIEnchant) {
+		return fmt.Sprintf("%d/%d/%d", key.EffectID, key.ItemID, key.SpellID), enchant
+	})
+}
+
+func diffEntities[T googleProto.Message](oldEntities map[string]T, newEntities map[string]T) EntityDiffs {
+	var diffs EntityDiffs
+	for key, oldEntity := range oldEntities {
+		newEntity, ok := newEntities[key]
+		if !ok {
+			diffs.Removed = append(diffs.Removed, &EntityChange{Key: key, Name: entityName(oldEntity)})
+			continue
+		}
+		if googleProto.Equal(oldEntity, newEntity) {
+			continue
+		}
+		diffs.Changed = append(diffs.Changed, &EntityChange{
+			Key:    key,
+			Name:   entityName(newEntity),
+			Fields: diffFields(oldEntity.ProtoReflect(), newEntity.ProtoReflect()),
+		})
+	}
+	for key, newEntity := range newEntities {
+		if _, ok := oldEntities[key]; !ok {
+			diffs.Added = append(diffs.Added, &EntityChange{Key: key, Name: entityName(newEntity)})
+		}
+	}
+
+	for _, changes := range [][]*EntityChange{diffs.Added, diffs.Removed, diffs.Changed} {
+		slices.SortFunc(changes, func(a, b *EntityChange) int {
+			// Sorts numeric keys by value.
+			if len(a.Key) != len(b.Key) {
+				return len(a.Key) - len(b.Key)
+			}
+			return strings.Compare(a.Key, b.Key)
+		})
+	}
+	return diffs
+}
+
+func entityName(entity googleProto.Message) string {
+	message := entity.ProtoReflect()
+	if fd := message.Descriptor().Fields().ByName("name"); fd != nil {
+		return message.Get(fd).String()
+	}
+	return ""
+}
+
+// Field-level differences. Repeated doubles are stat arrays, so they're
+// compared per stat.
+func diffFields(oldMessage protoreflect.Message, newMessage protoreflect.Message) []*FieldDiff {
+	var diffs []*FieldDiff
+	fields := oldMessage.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		oldValue, newValue := oldMessage.Get(fd), newMessage.Get(fd)
+
+		if fd.IsList() && fd.Kind() == protoreflect.DoubleKind {
+			oldList, newList := oldValue.List(), newValue.List()
+			for stat := 0; stat < max(oldList.Len(), newList.Len()); stat++ {
+				oldStat, newStat := listFloat(oldList, stat), listFloat(newList, stat)
+				if oldStat != newStat {
+					diffs = append(diffs, &FieldDiff{
+						Field: fmt.Sprintf("%s.%s", fd.JSONName(), proto.Stat(stat)),
+						Old:   strconv.FormatFloat(oldStat, 'f', -1, 64),
+						New:   strconv.FormatFloat(newStat, 'f', -1, 64),
+					})
+				}
+			}
+			continue
+		}
+
+		if oldStr, newStr := formatField(fd, oldValue), formatField(fd, newValue); oldStr != newStr {
+			diffs = append(diffs, &FieldDiff{Field: fd.JSONName(), Old: oldStr, New: newStr})
+		}
+	}
+	return diffs
+}
+
+func listFloat(list protoreflect.List, idx int) float64 {
+	if idx >= list.Len() {
+		return 0
+	}
+	return list.Get(idx).Float()
+}
+
+func formatField(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	if !fd.IsList() {
+		return formatFieldValue(fd, value)
+	}
+	list := value.List()
+	values := make([]string, list.Len())
+	for i := range values {
+		values[i] = formatFieldValue(fd, list.Get(i))
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+func formatFieldValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if enumValue := fd.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return strconv.Itoa(int(value.Enum()))
+	case protoreflect.MessageKind:
+		data, err := protojson.Marshal(value.Message().Interface())
+		if err != nil {
+			panic(err)
+		}
+		return string(data)
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// Runs each request against both databases and flags DPS changes above the
+// threshold. Leaves the sim with the new database.
+func (diff *DatabaseDiff) CompareSims(oldDB *WowDatabase, newDB *WowDatabase, requests []NamedSimRequest, thresholdPercent float64) {
+	runAll := func(db *WowDatabase) []*proto.RaidSimResult {
+		core.ReplaceDatabase(core.NewSimDatabase(db.ToUIProto()))
+		return core.MapSlice(requests, func(request NamedSimRequest) *proto.RaidSimResult {
+			return core.RunRaidSim(googleProto.Clone(request.Request).(*proto.RaidSimRequest))
+		})
+	}
+	oldResults := runAll(oldDB)
+	newResults := runAll(newDB)
+
+	for i, request := range requests {
+		simDiff := &SimDiff{Name: request.Name}
+		diff.Sims = append(diff.Sims, simDiff)
+
+		oldResult, newResult := oldResults[i], newResults[i]
+		if oldResult.ErrorResult != "" || newResult.ErrorResult != "" {
+			simDiff.Flagged = true
+			simDiff.Error = strings.TrimSpace(oldResult.ErrorResult + "\n" + newResult.ErrorResult)
+			continue
+		}
+
+		simDiff.OldDps = oldResult.RaidMetrics.Dps.Avg
+		simDiff.NewDps = newResult.RaidMetrics.Dps.Avg
+		if simDiff.OldDps != 0 {
+			simDiff.ChangePercent = (simDiff.NewDps - simDiff.OldDps) / simDiff.OldDps * 100
+		}
+		simDiff.Flagged = math.Abs(simDiff.ChangePercent) > thresholdPercent
+	}
+}
+
+func (diff *DatabaseDiff) WriteJson(jsonFilePath string) {
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	tools.WriteFile(jsonFilePath, string(data))
+}
+
+// Human-readable report of the differences, for pasting into reviews.
+func (diff *DatabaseDiff) Report() string {
+	var sb strings.Builder
+	for _, section := range []struct {
+		name  string
+		diffs EntityDiffs
+	}{
+		{"Items", diff.Items},
+		{"Gems", diff.Gems},
+		{"Enchants", diff.Enchants},
+		{"Random suffixes", diff.RandomSuffixes},
+		{"Reforge stats", diff.ReforgeStats},
+	} {
+		fmt.Fprintf(&sb, "== %s: %d added, %d removed, %d changed ==\n", section.name, len(section.diffs.Added), len(section.diffs.Removed), len(section.diffs.Changed))
+		for _, change := range section.diffs.Added {
+			fmt.Fprintf(&sb, "  + %s %s\n", change.Key, change.Name)
+		}
+		for _, change := range section.diffs.Removed {
+			fmt.Fprintf(&sb, "  - %s %s\n", change.Key, change.Name)
+		}
+		for _, change := range section.diffs.Changed {
+			fmt.Fprintf(&sb, "  ~ %s %s\n", change.Key, change.Name)
+			for _, field := range change.Fields {
+				fmt.Fprintf(&sb, "      %s: %s -> %s\n", field.Field, field.Old, field.New)
+			}
+		}
+	}
+
+	if len(diff.Sims) > 0 {
+		sb.WriteString("== Sims ==\n")
+		for _, sim := range diff.Sims {
+			flag := "  "
+			if sim.Flagged {
+				flag = "! "
+			}
+			if sim.Error != "" {
+				fmt.Fprintf(&sb, "%s%s: error: %s\n", flag, sim.Name, sim.Error)
+				continue
+			}
+			fmt.Fprintf(&sb, "%s%s: %.2f -> %.2f DPS (%+.2f%%)\n", flag, sim.Name, sim.OldDps, sim.NewDps, sim.ChangePercent)
+		}
+	}
+	return sb.String()
+}
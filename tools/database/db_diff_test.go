@@ -0,0 +1,156 @@
+package database
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/cata/sim"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func diffKeys(changes []*EntityChange) []string {
+	return core.MapSlice(changes, func(change *EntityChange) string {
+		return change.Key
+	})
+}
+
+func TestDiffDatabases(t *testing.T) {
+	oldDB := NewWowDatabase()
+	newDB := NewWowDatabase()
+
+	for _, db := range []*WowDatabase{oldDB, newDB} {
+		db.Items[9] = &proto.UIItem{Id: 9, Name: "Unchanged Ring", Type: proto.ItemType_ItemTypeFinger}
+	}
+	oldDB.Items[10] = &proto.UIItem{Id: 10, Name: "Removed Helm", Type: proto.ItemType_ItemTypeHead}
+	oldDB.Items[100] = &proto.UIItem{
+		Id:         100,
+		Name:       "Old Chestguard",
+		Stats:      toSlice(Stats{proto.Stat_StatStamina: 10, proto.Stat_StatStrength: 5, proto.Stat_StatMastery: 3}),
+		GemSockets: []proto.GemColor{proto.GemColor_GemColorRed},
+		Quality:    proto.ItemQuality_ItemQualityRare,
+	}
+	newDB.Items[100] = &proto.UIItem{
+		Id:   100,
+		Name: "New Chestguard",
+		// Stat arrays of older DBs can be shorter.
+		Stats:      toSlice(Stats{proto.Stat_StatStamina: 20, proto.Stat_StatStrength: 5})[:proto.Stat_StatStamina+1],
+		GemSockets: []proto.GemColor{proto.GemColor_GemColorRed, proto.GemColor_GemColorPrismatic},
+		Quality:    proto.ItemQuality_ItemQualityEpic,
+	}
+	newDB.Items[1000] = &proto.UIItem{Id: 1000, Name: "Added Legguards"}
+	newDB.Items[11] = &proto.UIItem{Id: 11, Name: "Added Boots"}
+
+	oldDB.Enchants[EnchantDBKey{EffectID: 4200, SpellID: 75000}] = &proto.UIEnchant{EffectId: 4200, SpellId: 75000, Name: "Enchant Chest"}
+	newDB.Enchants[EnchantDBKey{EffectID: 4200, SpellID: 75000}] = &proto.UIEnchant{EffectId: 4200, SpellId: 75000, Name: "Enchant Chest - Greater"}
+	newDB.Enchants[EnchantDBKey{EffectID: 4201, ItemID: 52000}] = &proto.UIEnchant{EffectId: 4201, ItemId: 52000, Name: "Armor Kit"}
+
+	diff := DiffDatabases(oldDB, newDB)
+
+	if keys := diffKeys(diff.Items.Added); !reflect.DeepEqual(keys, []string{"11", "1000"}) {
+		t.Fatalf("Expected items 11 and 1000 added in ID order, got %v", keys)
+	}
+	if keys := diffKeys(diff.Items.Removed); !reflect.DeepEqual(keys, []string{"10"}) {
+		t.Fatalf("Expected item 10 removed, got %v", keys)
+	}
+	if len(diff.Items.Changed) != 1 {
+		t.Fatalf("Expected only item 100 changed, got %v", diffKeys(diff.Items.Changed))
+	}
+
+	changed := diff.Items.Changed[0]
+	if changed.Key != "100" || changed.Name != "New Chestguard" {
+		t.Fatalf("Expected the changed item under its new name, got %s %s", changed.Key, changed.Name)
+	}
+	expectedFields := []*FieldDiff{
+		{Field: "name", Old: "Old Chestguard", New: "New Chestguard"},
+		{Field: "stats.StatStamina", Old: "10", New: "20"},
+		{Field: "stats.StatMastery", Old: "3", New: "0"},
+		{Field: "gemSockets", Old: "[GemColorRed]", New: "[GemColorRed, GemColorPrismatic]"},
+		{Field: "quality", Old: "ItemQualityRare", New: "ItemQualityEpic"},
+	}
+	if !reflect.DeepEqual(changed.Fields, expectedFields) {
+		t.Fatalf("Unexpected field diffs: %v", core.MapSlice(changed.Fields, func(field *FieldDiff) FieldDiff { return *field }))
+	}
+
+	if keys := diffKeys(diff.Enchants.Added); !reflect.DeepEqual(keys, []string{"4201/52000/0"}) {
+		t.Fatalf("Expected enchants keyed by effect/item/spell, got %v", keys)
+	}
+	if keys := diffKeys(diff.Enchants.Changed); !reflect.DeepEqual(keys, []string{"4200/0/75000"}) {
+		t.Fatalf("Expected the renamed enchant to change, got %v", keys)
+	}
+
+	report := diff.Report()
+	for _, line := range []string{
+		"== Items: 2 added, 1 removed, 1 changed ==",
+		"  - 10 Removed Helm",
+		"      stats.StatStamina: 10 -> 20",
+		"== Gems: 0 added, 0 removed, 0 changed ==",
+	} {
+		if !strings.Contains(report, line+"\n") {
+			t.Errorf("Expected the report to contain %q, got:\n%s", line, report)
+		}
+	}
+}
+
+func TestCompareSims(t *testing.T) {
+	sim.RegisterAll()
+	t.Cleanup(func() { core.ReplaceDatabase(&proto.SimDatabase{}) })
+
+	weapon := func(damage float64) *WowDatabase {
+		db := NewWowDatabase()
+		db.Items[990100] = &proto.UIItem{
+			Id:              990100,
+			Name:            "Fixture Sword",
+			Type:            proto.ItemType_ItemTypeWeapon,
+			WeaponType:      proto.WeaponType_WeaponTypeSword,
+			HandType:        proto.HandType_HandTypeOneHand,
+			WeaponSpeed:     2.6,
+			WeaponDamageMin: damage,
+			WeaponDamageMax: damage,
+			Stats:           toSlice(Stats{}),
+		}
+		return db
+	}
+
+	// A warrior without a rotation only auto attacks, so its DPS follows the weapon damage.
+	equipment := &proto.EquipmentSpec{Items: make([]*proto.ItemSpec, proto.ItemSlot_ItemSlotMainHand+1)}
+	equipment.Items[proto.ItemSlot_ItemSlotMainHand] = &proto.ItemSpec{Id: 990100}
+	request := &proto.RaidSimRequest{
+		Raid: core.SinglePlayerRaidProto(&proto.Player{
+			Class:     proto.Class_ClassWarrior,
+			Race:      proto.Race_RaceDwarf,
+			Equipment: equipment,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Rotation:  &proto.APLRotation{Type: proto.APLRotation_TypeAPL},
+			Spec: &proto.Player_ProtectionWarrior{ProtectionWarrior: &proto.ProtectionWarrior{
+				Options: &proto.ProtectionWarrior_Options{ClassOptions: &proto.WarriorOptions{}},
+			}},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Duration: 60,
+			Targets:  []*proto.Target{{Name: "target", Level: 88, MobType: proto.MobType_MobTypeMechanical}},
+		},
+		SimOptions: &proto.SimOptions{Iterations: 10, RandomSeed: 101},
+	}
+	requests := []NamedSimRequest{{Name: "Auto attacks", Request: request}}
+
+	diff := &DatabaseDiff{}
+	diff.CompareSims(weapon(1000), weapon(1010), requests, 5)
+	diff.CompareSims(weapon(1000), weapon(2000), requests, 5)
+	if len(diff.Sims) != 2 {
+		t.Fatalf("Expected a sim diff per comparison, got %d", len(diff.Sims))
+	}
+	for _, simDiff := range diff.Sims {
+		if simDiff.Error != "" || simDiff.OldDps <= 0 {
+			t.Fatalf("Expected the fixture sims to deal damage, got %+v", simDiff)
+		}
+	}
+	if small := diff.Sims[0]; small.Flagged || small.ChangePercent <= 0 {
+		t.Fatalf("Expected a small DPS gain below the threshold not to be flagged, got %+v", small)
+	}
+	if large := diff.Sims[1]; !large.Flagged || large.ChangePercent < 5 {
+		t.Fatalf("Expected doubling the weapon damage to be flagged, got %+v", large)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/wowsims/cata/sim"
+	"github.com/wowsims/cata/sim/core/proto"
+	_ "github.com/wowsims/cata/sim/encounters" // Needed for preset encounters.
+	"github.com/wowsims/cata/tools/database"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Compares two generated databases, e.g. before and after rerunning gen_db:
+// go run ./tools/database/diff_db -old=/tmp/old_db.json -new=assets/database/db.json
+//
+// To also compare sim results, pass a directory of RaidSimRequest JSON files:
+// go run ./tools/database/diff_db -old=/tmp/old_db.json -new=assets/database/db.json -requests=/tmp/requests -threshold=0.5
+
+var oldDBPath = flag.String("old", "", "Path to the old db, as .json or .bin.")
+var newDBPath = flag.String("new", "assets/database/db.json", "Path to the new db, as .json or .bin.")
+var requestsDir = flag.String("requests", "", "Directory of RaidSimRequest .json files to sim against both dbs.")
+var threshold = flag.Float64("threshold", 1, "DPS change in percent above which a sim is flagged.")
+var outFile = flag.String("outFile", "", "Path to also write the diff to as JSON.")
+
+func main() {
+	flag.Parse()
+	if *oldDBPath == "" || *newDBPath == "" {
+		log.Fatalf("Both -old and -new are required")
+	}
+
+	oldDB := readDatabase(*oldDBPath)
+	newDB := readDatabase(*newDBPath)
+	diff := database.DiffDatabases(oldDB, newDB)
+
+	if *requestsDir != "" {
+		sim.RegisterAll()
+		diff.CompareSims(oldDB, newDB, readRequests(*requestsDir), *threshold)
+	}
+
+	fmt.Print(diff.Report())
+	if *outFile != "" {
+		diff.WriteJson(*outFile)
+	}
+}
+
+func readDatabase(path string) *database.WowDatabase {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("Failed to read db %s: %s", path, err)
+	}
+	if strings.HasSuffix(path, ".bin") {
+		return database.ReadDatabaseFromBinary(data)
+	}
+	return database.ReadDatabaseFromJson(string(data))
+}
+
+func readRequests(dir string) []database.NamedSimRequest {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		log.Fatalf("Failed to list requests in %s: %s", dir, err)
+	}
+	sort.Strings(paths)
+
+	var requests []database.NamedSimRequest
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to read request %s: %s", path, err)
+		}
+		request := &proto.RaidSimRequest{}
+		if err := protojson.Unmarshal(data, request); err != nil {
+			log.Fatalf("Failed to parse request %s: %s", path, err)
+		}
+		requests = append(requests, database.NamedSimRequest{
+			Name:    strings.TrimSuffix(filepath.Base(path), ".json"),
+			Request: request,
+		})
+	}
+	return requests
+}
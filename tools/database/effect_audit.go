@@ -0,0 +1,245 @@
+package database
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+	"github.com/wowsims/cata/tools"
+)
+
+// Effects of items, sets and enchants in the DB which the sim doesn't implement,
+// i.e. which it silently ignores when simming gear that has them.
+type EffectAudit struct {
+	Missing []*MissingEffect `json:"missing"`
+}
+
+type MissingEffectKind string
+
+const (
+	MissingTrinket  MissingEffectKind = "Trinket"
+	MissingProc     MissingEffectKind = "Proc"
+	MissingSetBonus MissingEffectKind = "SetBonus"
+	MissingEnchant  MissingEffectKind = "Enchant"
+)
+
+type MissingEffect struct {
+	Kind MissingEffectKind `json:"kind"`
+
+	// Item ID for trinkets and procs, enchant effect ID for enchants and the ID
+	// of any set piece for set bonuses.
+	ID    int32  `json:"id"`
+	Name  string `json:"name"`
+	Phase int32  `json:"phase"`
+	Ilvl  int32  `json:"ilvl,omitempty"`
+
+	// Classes which can use the item, empty if any class can.
+	Classes []string `json:"classes,omitempty"`
+
+	// Number of pieces, for set bonuses.
+	NumPieces int32 `json:"numPieces,omitempty"`
+
+	// Tooltip text of the effects, if known.
+	Effects []string `json:"effects,omitempty"`
+}
+
+var tooltipEffectRegex = regexp.MustCompile(`(Equip|Use|Chance on hit): (.*?)(</span>|<br|$)`)
+var tooltipSetBonusRegex = regexp.MustCompile(`\(([0-9])\) Set ?: (.*?)(</span>|<br|$)`)
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// Equip lines which are just stats, which the DB already parses into item stats.
+var statEffectRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`^(Increases|Improves) [A-Za-z ]+ by [0-9,]+\.$`),
+	regexp.MustCompile(`^Restores [0-9]+ mana per 5 sec\.$`),
+	regexp.MustCompile(`^Has [0-9]+ bonus armor`),
+}
+
+// Effects which can't matter to the sim.
+var nonSimEffectRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(movement|run|mount|swim) speed`),
+	regexp.MustCompile(`(?i)fishing|underwater breathing|reputation|experience gained`),
+}
+
+func tooltipText(html string) string {
+	return strings.TrimSpace(htmlTagRegex.ReplaceAllString(html, ""))
+}
+
+// Effect lines of an item's tooltip which the sim would need to implement.
+func (item WowheadItemResponse) GetSimmableEffects() []string {
+	var effects []string
+	for _, match := range tooltipEffectRegex.FindAllStringSubmatch(item.TooltipWithoutSetBonus(), -1) {
+		text := tooltipText(match[2])
+		if text == "" {
+			continue
+		}
+		if match[1] == "Equip" && slices.ContainsFunc(statEffectRegexes, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(text)
+		}) {
+			continue
+		}
+		if slices.ContainsFunc(nonSimEffectRegexes, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(text)
+		}) {
+			continue
+		}
+		effects = append(effects, match[1]+": "+text)
+	}
+	return effects
+}
+
+// Set bonuses of an item's tooltip, by number of pieces.
+func (item WowheadItemResponse) GetSetBonuses() map[int32]string {
+	bonuses := map[int32]string{}
+	for _, match := range tooltipSetBonusRegex.FindAllStringSubmatch(item.Tooltip, -1) {
+		numPieces, _ := strconv.Atoi(match[1])
+		bonuses[int32(numPieces)] = tooltipText(match[2])
+	}
+	return bonuses
+}
+
+// Cross-references the items, sets and enchants of the DB against the effects
+// registered in the sim.
+func AuditEffects(db *WowDatabase, itemTooltips map[int32]WowheadItemResponse) *EffectAudit {
+	audit := &EffectAudit{}
+
+	auditedSets := map[string]bool{}
+	for _, item := range db.Items {
+		tooltip, ok := itemTooltips[item.Id]
+		if !ok {
+			continue
+		}
+
+		if effects := tooltip.GetSimmableEffects(); len(effects) > 0 && !core.HasItemEffect(item.Id) {
+			kind := MissingProc
+			if item.Type == proto.ItemType_ItemTypeTrinket {
+				kind = MissingTrinket
+			}
+			audit.Missing = append(audit.Missing, &MissingEffect{
+				Kind:    kind,
+				ID:      item.Id,
+				Name:    item.Name,
+				Phase:   item.Phase,
+				Ilvl:    item.Ilvl,
+				Classes: classNames(item.ClassAllowlist),
+				Effects: effects,
+			})
+		}
+
+		if item.SetName == "" || auditedSets[item.SetName] {
+			continue
+		}
+		auditedSets[item.SetName] = true
+
+		set := core.GetItemSetByName(item.SetName)
+		for numPieces, bonus := range tooltip.GetSetBonuses() {
+			if set != nil && set.Bonuses[numPieces] != nil {
+				continue
+			}
+			audit.Missing = append(audit.Missing, &MissingEffect{
+				Kind:      MissingSetBonus,
+				ID:        item.Id,
+				Name:      item.SetName,
+				Phase:     item.Phase,
+				Classes:   classNames(item.ClassAllowlist),
+				NumPieces: numPieces,
+				Effects:   []string{bonus},
+			})
+		}
+	}
+
+	// Enchants without stats have nothing but their effect.
+	for _, enchant := range db.Enchants {
+		if !stats.FromFloatArray(enchant.Stats).Equals(stats.Stats{}) ||
+			core.HasEnchantEffect(enchant.EffectId) || core.HasWeaponEffect(enchant.EffectId) {
+			continue
+		}
+		if slices.ContainsFunc(nonSimEffectRegexes, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(enchant.Name)
+		}) {
+			continue
+		}
+		audit.Missing = append(audit.Missing, &MissingEffect{
+			Kind:    MissingEnchant,
+			ID:      enchant.EffectId,
+			Name:    enchant.Name,
+			Phase:   enchant.Phase,
+			Classes: classNames(enchant.ClassAllowlist),
+		})
+	}
+
+	slices.SortFunc(audit.Missing, func(a, b *MissingEffect) int {
+		if a.Phase != b.Phase {
+			return cmp.Compare(a.Phase, b.Phase)
+		} else if a.Kind != b.Kind {
+			return cmp.Compare(a.Kind, b.Kind)
+		} else if a.Name != b.Name {
+			return cmp.Compare(a.Name, b.Name)
+		} else if a.NumPieces != b.NumPieces {
+			return cmp.Compare(a.NumPieces, b.NumPieces)
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return audit
+}
+
+func classNames(classes []proto.Class) []string {
+	return core.MapSlice(classes, func(class proto.Class) string {
+		return strings.TrimPrefix(class.String(), "Class")
+	})
+}
+
+func (audit *EffectAudit) WriteJson(jsonFilePath string) {
+	data, err := json.MarshalIndent(audit, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	tools.WriteFile(jsonFilePath, string(data))
+}
+
+// Human-readable report of the missing effects, by class and then phase.
+func (audit *EffectAudit) Report() string {
+	byClass := map[string][]*MissingEffect{}
+	for _, missing := range audit.Missing {
+		if len(missing.Classes) == 0 {
+			byClass["All classes"] = append(byClass["All classes"], missing)
+		}
+		for _, class := range missing.Classes {
+			byClass[class] = append(byClass[class], missing)
+		}
+	}
+
+	classes := make([]string, 0, len(byClass))
+	for class := range byClass {
+		classes = append(classes, class)
+	}
+	slices.Sort(classes)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d unimplemented effects\n", len(audit.Missing))
+	for _, class := range classes {
+		fmt.Fprintf(&sb, "\n== %s ==\n", class)
+		phase := int32(-1)
+		for _, missing := range byClass[class] {
+			if missing.Phase != phase {
+				phase = missing.Phase
+				fmt.Fprintf(&sb, "-- Phase %d --\n", phase)
+			}
+			name := missing.Name
+			if missing.Kind == MissingSetBonus {
+				name = fmt.Sprintf("%s (%d)", name, missing.NumPieces)
+			}
+			fmt.Fprintf(&sb, "  [%s] %s (%d)\n", missing.Kind, name, missing.ID)
+			for _, effect := range missing.Effects {
+				fmt.Fprintf(&sb, "      %s\n", effect)
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func TestGetSimmableEffects(t *testing.T) {
+	item := WowheadItemResponse{Tooltip: `<span>Equip: Increases your haste rating by 321.</span><br>` +
+		`<span>Equip: Your spells have a chance to grant <!--x-->1,926 spell power for 20 sec.</span><br>` +
+		`<span>Use: Increases run speed by 40% for 10 sec.</span><br>` +
+		`<span>Use: Grants 1,425 critical strike rating for 15 sec.</span>` +
+		`<span>(2) Set : Your Lava Burst deals 10% more damage.</span><br>` +
+		`<span>(4) Set : Reduces the cooldown of Elemental Mastery by 30 sec.</span>`}
+
+	effects := item.GetSimmableEffects()
+	expected := []string{
+		"Equip: Your spells have a chance to grant 1,926 spell power for 20 sec.",
+		"Use: Grants 1,425 critical strike rating for 15 sec.",
+	}
+	if !slices.Equal(effects, expected) {
+		t.Fatalf("Expected effects %q, got %q", expected, effects)
+	}
+
+	bonuses := item.GetSetBonuses()
+	if len(bonuses) != 2 || bonuses[2] != "Your Lava Burst deals 10% more damage." || bonuses[4] != "Reduces the cooldown of Elemental Mastery by 30 sec." {
+		t.Fatalf("Unexpected set bonuses %v", bonuses)
+	}
+}
+
+func TestAuditEffects(t *testing.T) {
+	core.NewItemEffect(990001, func(agent core.Agent) {})
+	core.NewItemSet(core.ItemSet{
+		Name:    "Audited Regalia",
+		Bonuses: map[int32]core.ApplyEffect{2: func(agent core.Agent) {}},
+	})
+
+	db := NewWowDatabase()
+	db.Items[990001] = &proto.UIItem{Id: 990001, Name: "Implemented Trinket", Type: proto.ItemType_ItemTypeTrinket, Phase: 1}
+	db.Items[990002] = &proto.UIItem{Id: 990002, Name: "Missing Trinket", Type: proto.ItemType_ItemTypeTrinket, Phase: 2}
+	db.Items[990003] = &proto.UIItem{Id: 990003, Name: "Stat Stick", Type: proto.ItemType_ItemTypeWeapon, Phase: 1}
+	db.Items[990004] = &proto.UIItem{Id: 990004, Name: "Audited Hood", Type: proto.ItemType_ItemTypeHead, Phase: 1,
+		SetName: "Audited Regalia", ClassAllowlist: []proto.Class{proto.Class_ClassShaman}}
+	db.Enchants[EnchantDBKey{EffectID: 990005}] = &proto.UIEnchant{EffectId: 990005, Name: "Mysterious Enchant", Phase: 1}
+	db.Enchants[EnchantDBKey{EffectID: 990006}] = &proto.UIEnchant{EffectId: 990006, Name: "Minor Run Speed", Phase: 1}
+	db.Enchants[EnchantDBKey{EffectID: 990007}] = &proto.UIEnchant{EffectId: 990007, Name: "Stat Enchant", Phase: 1,
+		Stats: stats.Stats{stats.Stamina: 30}.ToFloatArray()}
+
+	onUse := `<span>Use: Grants 1,425 critical strike rating for 15 sec.</span>`
+	tooltips := map[int32]WowheadItemResponse{
+		990001: {Tooltip: onUse},
+		990002: {Tooltip: onUse},
+		990003: {Tooltip: `<span>Equip: Increases your mastery rating by 100.</span>`},
+		990004: {Tooltip: `<span>(2) Set : Your Lava Burst deals 10% more damage.</span><br><span>(4) Set : Reduces the cooldown of Elemental Mastery by 30 sec.</span>`},
+	}
+
+	audit := AuditEffects(db, tooltips)
+	type missingSummary struct {
+		Kind      MissingEffectKind
+		ID        int32
+		NumPieces int32
+	}
+	summary := core.MapSlice(audit.Missing, func(missing *MissingEffect) missingSummary {
+		return missingSummary{Kind: missing.Kind, ID: missing.ID, NumPieces: missing.NumPieces}
+	})
+	// Sorted by phase, then kind.
+	expected := []missingSummary{
+		{Kind: MissingEnchant, ID: 990005},
+		{Kind: MissingSetBonus, ID: 990004, NumPieces: 4},
+		{Kind: MissingTrinket, ID: 990002},
+	}
+	if !slices.Equal(summary, expected) {
+		t.Fatalf("Expected missing effects %v, got %v", expected, summary)
+	}
+	if classes := audit.Missing[1].Classes; !slices.Equal(classes, []string{"Shaman"}) {
+		t.Fatalf("Expected the set bonus to be reported for shamans, got %v", classes)
+	}
+}
@@ -25,12 +25,21 @@ import (
 // go run ./tools/database/gen_db -outDir=assets -gen=wowhead-gearplannerdb
 // go run ./tools/database/gen_db -outDir=assets -gen=wago-db2-items
 // go run ./tools/database/gen_db -outDir=assets -gen=db
+//
+// To build the db offline from game data instead, export the DB2 tables listed in database.ReadDB2Tables
+// as CSV (e.g. from wago.tools) into assets/db_inputs/db2, then:
+// go run ./tools/database/gen_db -outDir=assets -gen=db2
+// Icons, phases, random suffixes and which items are obtainable come from assets/db_inputs/db2_metadata.json,
+// which -gen=db writes and is checked in.
+//
+// To list item, set and enchant effects the sim doesn't implement, after generating the db:
+// go run ./tools/database/gen_db -outDir=assets -gen=audit
 
 var exactId = flag.Int("id", 0, "ID to scan for")
 var minId = flag.Int("minid", 0, "Minimum ID to scan for")
 var maxId = flag.Int("maxid", 0, "Maximum ID to scan for")
 var outDir = flag.String("outDir", "assets", "Path to output directory for writing generated .go files.")
-var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'cata-items', and 'wago-db2-items'")
+var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'cata-items', 'wago-db2-items', 'db2', and 'audit'")
 
 func main() {
 	flag.Parse()
@@ -67,6 +76,17 @@ func main() {
 		//Todo: fill this when we have information from wowhead @ Neteyes - Gehennas
 		// For now, the version we have was taken from https://web.archive.org/web/20120201045249js_/http://www.wowhead.com/data=item-scaling
 		return
+	} else if *genAsset == "db2" {
+		genDBFromDB2(dbDir, inputsDir)
+		return
+	} else if *genAsset == "audit" {
+		// Audits the generated db rather than regenerating it, since effects only need to be implemented for simmable items.
+		db := database.ReadDatabaseFromJson(tools.ReadFile(fmt.Sprintf("%s/db.json", dbDir)))
+		itemTooltips := database.NewWowheadItemTooltipManager(fmt.Sprintf("%s/wowhead_item_tooltips.csv", inputsDir)).Read()
+		audit := database.AuditEffects(db, itemTooltips)
+		audit.WriteJson(fmt.Sprintf("%s/effect_audit.json", dbDir))
+		fmt.Print(audit.Report())
+		return
 	} else if *genAsset != "db" {
 		panic("Invalid gen value")
 	}
@@ -171,6 +191,62 @@ func main() {
 	db.MergeZones(atlasDBProto.Zones)
 	db.MergeNpcs(atlasDBProto.Npcs)
 
+	db.WriteBinaryAndJson(fmt.Sprintf("%s/db.bin", dbDir), fmt.Sprintf("%s/db.json", dbDir))
+	db.ToDB2Metadata().WriteJson(fmt.Sprintf("%s/db2_metadata.json", inputsDir))
+}
+
+// Builds the db from local CSV exports of the game's DB2 tables, without fetching anything. Stats, sockets,
+// weapon damage and random suffixes come from the game tables; icons, phases, which suffixes items roll and
+// which items are obtainable aren't part of them, so they come from the checked in db2_metadata.json.
+func genDBFromDB2(dbDir string, inputsDir string) {
+	tables := database.ReadDB2Tables(fmt.Sprintf("%s/db2", inputsDir))
+	atlaslootDB := database.ReadDatabaseFromJson(tools.ReadFile(fmt.Sprintf("%s/atlasloot_db.json", inputsDir)))
+	reforgeStats := database.ParseWowheadReforgeStats(tools.ReadFile(fmt.Sprintf("%s/wowhead_reforge_stats.json", inputsDir)))
+
+	db := tables.ToDatabase()
+	db.Encounters = core.PresetEncounters
+	db.GlyphIDs = getGlyphIDsFromJson(fmt.Sprintf("%s/glyph_id_map.json", inputsDir))
+	db.ReforgeStats = reforgeStats.ToProto()
+
+	db.ApplyDB2Metadata(database.ReadDatabaseFromJson(tools.ReadFile(fmt.Sprintf("%s/db2_metadata.json", inputsDir))))
+
+	for _, item := range atlaslootDB.Items {
+		if _, ok := db.Items[item.Id]; ok {
+			db.MergeItem(item)
+		}
+	}
+
+	db.MergeItems(database.ItemOverrides)
+	db.MergeGems(database.GemOverrides)
+	db.MergeEnchants(database.EnchantOverrides)
+	ApplyGlobalFilters(db)
+	AttachFactionInformation(db, tables.FactionRestrictions())
+
+	leftovers := db.Clone()
+	ApplyNonSimmableFilters(leftovers)
+	leftovers.WriteBinaryAndJson(fmt.Sprintf("%s/leftover_db.bin", dbDir), fmt.Sprintf("%s/leftover_db.json", dbDir))
+
+	ApplySimmableFilters(db)
+
+	// Only keep the random suffixes which items can roll.
+	randPropPoints := tables.RandPropPointsByIlvl()
+	usedRandomSuffixes := map[int32]bool{}
+	for _, item := range db.Items {
+		for _, randomSuffixID := range item.RandomSuffixOptions {
+			usedRandomSuffixes[randomSuffixID] = true
+		}
+		if len(item.RandomSuffixOptions) > 0 {
+			item.RandPropPoints = randPropPoints.CalcItemAllocation(item)
+		}
+	}
+	db.RandomSuffixes = core.FilterMap(db.RandomSuffixes, func(id int32, _ *proto.ItemRandomSuffix) bool {
+		return usedRandomSuffixes[id]
+	})
+
+	atlasDBProto := atlaslootDB.ToUIProto()
+	db.MergeZones(atlasDBProto.Zones)
+	db.MergeNpcs(atlasDBProto.Npcs)
+
 	db.WriteBinaryAndJson(fmt.Sprintf("%s/db.bin", dbDir), fmt.Sprintf("%s/db.json", dbDir))
 }
 
@@ -90,6 +90,9 @@ func (allocationMap RandomPropAllocationsByIlvl) CalcItemAllocation(item *proto.
 			}
 		}
 	}
+	if idx == -1 {
+		return 0
+	}
 
 	return allocationMap[item.Ilvl][item.Quality][idx]
 }
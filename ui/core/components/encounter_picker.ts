@@ -227,6 +227,7 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 	private readonly dualWieldPicker: Input<null, boolean>;
 	private readonly dwMissPenaltyPicker: Input<null, boolean>;
 	private readonly parryHastePicker: Input<null, boolean>;
+	private readonly optionalKillPicker: Input<null, boolean>;
 	private readonly spellSchoolPicker: Input<null, number>;
 	private readonly damageSpreadPicker: Input<null, number>;
 	private readonly targetInputPickers: ListPicker<Encounter, TargetInput>;
@@ -435,6 +436,19 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 				encounter.targetsChangeEmitter.emit(eventID);
 			},
 		});
+		this.optionalKillPicker = new BooleanPicker(section3, null, {
+			label: 'Optional Kill',
+			labelTooltip: 'In health fights, whether the fight can end without killing this enemy.',
+			inline: true,
+			reverse: true,
+			changedEvent: () => TypedEvent.onAny([encounter.targetsChangeEmitter, encounter.changeEmitter]),
+			getValue: () => this.getTarget().optionalKill,
+			setValue: (eventID: EventID, _: null, newValue: boolean) => {
+				this.getTarget().optionalKill = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+			showWhen: () => encounter.getUseHealth(),
+		});
 		this.spellSchoolPicker = new EnumPicker<null>(section3, null, {
 			label: 'Spell School',
 			labelTooltip: 'Type of damage caused by auto attacks. This is usually Physical, but some enemies have elemental attacks.',
@@ -472,6 +486,7 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 			dualWield: this.dualWieldPicker.getInputValue(),
 			dualWieldPenalty: this.dwMissPenaltyPicker.getInputValue(),
 			parryHaste: this.parryHastePicker.getInputValue(),
+			optionalKill: this.optionalKillPicker.getInputValue(),
 			spellSchool: this.spellSchoolPicker.getInputValue(),
 			damageSpread: this.damageSpreadPicker.getInputValue(),
 			stats: this.statPickers
@@ -495,6 +510,7 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 		this.dualWieldPicker.setInputValue(newValue.dualWield);
 		this.dwMissPenaltyPicker.setInputValue(newValue.dualWieldPenalty);
 		this.parryHastePicker.setInputValue(newValue.parryHaste);
+		this.optionalKillPicker.setInputValue(newValue.optionalKill);
 		this.spellSchoolPicker.setInputValue(newValue.spellSchool);
 		this.damageSpreadPicker.setInputValue(newValue.damageSpread);
 		ALL_TARGET_STATS.forEach((statData, i) => this.statPickers[i].setInputValue(newValue.stats[statData.stat]));
@@ -11,6 +11,7 @@ import {
 	APLActionChangeTarget,
 	APLActionChannelSpell,
 	APLActionCustomRotation,
+	APLActionCycleTargets,
 	APLActionItemSwap,
 	APLActionItemSwap_SwapSet as ItemSwapSet,
 	APLActionMultidot,
@@ -19,6 +20,7 @@ import {
 	APLActionSchedule,
 	APLActionSequence,
 	APLActionStrictSequence,
+	APLActionTaunt,
 	APLActionTriggerICD,
 	APLActionWait,
 	APLActionWaitUntil,
@@ -376,6 +378,22 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 			}),
 		],
 	}),
+	['cycleTargets']: inputBuilder({
+		label: 'Cycle Targets',
+		submenu: ['Casting'],
+		shortDescription: 'Performs the inner action on the first target it is ready for, starting with the current target.',
+		fullDescription: `
+			<p>While checking each target, the inner action and its conditions treat that target as the <b>Current Target</b>. For example, casting a DoT with the condition <b>Dot Remaining Time</b> &lt; 3s will refresh the DoT on whichever target needs it.</p>
+		`,
+		includeIf: (player: Player<any>, isPrepull: boolean) => !isPrepull,
+		newValue: () =>
+			APLActionCycleTargets.create({
+				action: {
+					action: { oneofKind: 'castSpell', castSpell: {} },
+				},
+			}),
+		fields: [actionFieldConfig('action')],
+	}),
 	['multishield']: inputBuilder({
 		label: 'Multi Shield',
 		submenu: ['Casting'],
@@ -573,6 +591,17 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 		newValue: () => APLActionItemSwap.create(),
 		fields: [itemSwapSetFieldConfig('swapSet')],
 	}),
+	['taunt']: inputBuilder({
+		label: 'Taunt',
+		submenu: ['Misc'],
+		shortDescription: "Casts your class taunt, forcing the target to attack you. Only available to players in the raid's tanks.",
+		fullDescription: `
+			<p>The taunt also raises your threat on the target to that of its highest threat tank. Use with conditions on time or debuff stacks to set up tank swaps.</p>
+		`,
+		includeIf: (_player: Player<any>, isPrepull: boolean) => !isPrepull,
+		newValue: () => APLActionTaunt.create(),
+		fields: [AplHelpers.unitFieldConfig('target', 'targets')],
+	}),
 
 	['customRotation']: inputBuilder({
 		label: 'Custom Rotation',
@@ -11,7 +11,9 @@ import {
 	APLValueAuraShouldRefresh,
 	APLValueAutoTimeToNext,
 	APLValueBossSpellIsCasting,
+	APLValueBossNextDamageSpikeDamage,
 	APLValueBossSpellTimeToReady,
+	APLValueBossTimeToNextDamageSpike,
 	APLValueCatExcessEnergy,
 	APLValueCatNewSavageRoarDuration,
 	APLValueChannelClipDelay,
@@ -37,6 +39,7 @@ import {
 	APLValueCurrentTime,
 	APLValueCurrentTimePercent,
 	APLValueDotIsActive,
+	APLValueDotMissingTargets,
 	APLValueDotRemainingTime,
 	APLValueDotTickFrequency,
 	APLValueFrontOfTarget,
@@ -573,6 +576,27 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		newValue: APLValueBossSpellTimeToReady.create,
 		fields: [AplHelpers.unitFieldConfig('targetUnit', 'targets'), AplHelpers.actionIdFieldConfig('spellId', 'spells', 'targetUnit', 'currentTarget')],
 	}),
+	bossTimeToNextDamageSpike: inputBuilder({
+		label: 'Time to Next Damage Spike',
+		submenu: ['Boss'],
+		shortDescription: 'Time until the next damage spike of the encounter that will hit you, e.g. a big hit or an enrage.',
+		fullDescription: `
+			<p>Only encounters that publish their damage spikes are supported. Spikes dealing less than <b>Min Damage</b> per hit are ignored.</p>
+		`,
+		newValue: APLValueBossTimeToNextDamageSpike.create,
+		fields: [
+			AplHelpers.numberFieldConfig('minDamage', true, {
+				label: 'Min Damage',
+			}),
+		],
+	}),
+	bossNextDamageSpikeDamage: inputBuilder({
+		label: 'Next Damage Spike Damage',
+		submenu: ['Boss'],
+		shortDescription: 'Rough damage per hit of the next damage spike of the encounter that will hit you, or 0 if there is none.',
+		newValue: APLValueBossNextDamageSpikeDamage.create,
+		fields: [],
+	}),
 
 	// Resources
 	currentHealth: inputBuilder({
@@ -918,6 +942,29 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		newValue: APLValueDotTickFrequency.create,
 		fields: [AplHelpers.unitFieldConfig('targetUnit', 'targets'), AplHelpers.actionIdFieldConfig('spellId', 'dot_spells', '')],
 	}),
+	dotMissingTargets: inputBuilder({
+		label: 'Dot Missing Targets',
+		submenu: ['DoT'],
+		shortDescription: 'Number of targets on which the specified dot is not ticking, or will expire within the overlap.',
+		newValue: () =>
+			APLValueDotMissingTargets.create({
+				maxOverlap: {
+					value: {
+						oneofKind: 'const',
+						const: {
+							val: '0ms',
+						},
+					},
+				},
+			}),
+		fields: [
+			AplHelpers.actionIdFieldConfig('spellId', 'dot_spells', ''),
+			valueFieldConfig('maxOverlap', {
+				label: 'Overlap',
+				labelTooltip: 'Maximum amount of time before a DoT expires when it counts as missing.',
+			}),
+		],
+	}),
 	sequenceIsComplete: inputBuilder({
 		label: 'Sequence Is Complete',
 		submenu: ['Sequence'],
@@ -1,22 +1,29 @@
 import { IndividualSimUI } from '../../individual_sim_ui';
-import { BulkComboResult, BulkSettings, ItemSpecWithSlot, ProgressMetrics, TalentLoadout } from '../../proto/api';
-import { EquipmentSpec, GemColor, ItemSlot, ItemSpec, SimDatabase, SimEnchant, SimGem, SimItem } from '../../proto/common';
+import { BulkComboResult, BulkSettings, ItemSpecWithSlot, ProfessionPair, ProgressMetrics, TalentLoadout } from '../../proto/api';
+import { EquipmentSpec, GemColor, ItemSlot, ItemSpec, Profession, SimDatabase, SimEnchant, SimGem } from '../../proto/common';
 import { SavedTalents, UIEnchant, UIGem, UIItem, UIItem_FactionRestriction } from '../../proto/ui';
 import { ActionId } from '../../proto_utils/action_id';
 import { Database } from '../../proto_utils/database';
 import { EquippedItem } from '../../proto_utils/equipped_item';
+import { itemToSimItem } from '../../proto_utils/gear';
 import { getEmptyGemSocketIconUrl } from '../../proto_utils/gems';
+import { professionNames } from '../../proto_utils/names';
 import { canEquipItem, getEligibleItemSlots } from '../../proto_utils/utils';
 import { TypedEvent } from '../../typed_event';
 import { EventID } from '../../typed_event.js';
+import { getEnumValues } from '../../utils';
 import { BaseModal } from '../base_modal';
 import { BooleanPicker } from '../boolean_picker';
 import { Component } from '../component';
 import { ContentBlock } from '../content_block';
+import { EnumPicker } from '../enum_picker';
 import { ItemData, ItemList, ItemRenderer, SelectorModal, SelectorModalTabs } from '../gear_picker';
 import { Importer } from '../importers';
+import { Input } from '../input';
+import { ListItemPickerConfig, ListPicker } from '../list_picker';
 import { ResultsViewer } from '../results_viewer';
 import { SimTab } from '../sim_tab';
+import { StringPicker } from '../string_picker';
 
 export class BulkGearJsonImporter extends Importer {
 	private readonly simUI: IndividualSimUI<any>;
@@ -217,10 +224,75 @@ export class BulkItemPicker extends Component {
 	}
 }
 
+// Picks one of the profession pairs to sim in the batch sim.
+class ProfessionPairPicker extends Input<BulkTab, ProfessionPair> {
+	private readonly bulkTab: BulkTab;
+	private readonly pairIndex: number;
+
+	private readonly profession1Picker: Input<null, number>;
+	private readonly profession2Picker: Input<null, number>;
+
+	private getPair(): ProfessionPair {
+		return this.bulkTab.getProfessionsToSim()[this.pairIndex] || ProfessionPair.create();
+	}
+
+	constructor(parent: HTMLElement, bulkTab: BulkTab, pairIndex: number, config: ListItemPickerConfig<BulkTab, ProfessionPair>) {
+		super(parent, 'profession-pair-picker-root', bulkTab, config);
+		this.bulkTab = bulkTab;
+		this.pairIndex = pairIndex;
+
+		const professions = (getEnumValues(Profession) as Array<Profession>).map(p => {
+			return {
+				name: professionNames.get(p)!,
+				value: p,
+			};
+		});
+		this.profession1Picker = new EnumPicker<null>(this.rootElem, null, {
+			label: 'Profession 1',
+			values: professions,
+			changedEvent: () => bulkTab.settingsChangedEmitter,
+			getValue: () => this.getPair().profession1,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				this.getPair().profession1 = newValue;
+				bulkTab.settingsChangedEmitter.emit(eventID);
+			},
+		});
+		this.profession2Picker = new EnumPicker<null>(this.rootElem, null, {
+			label: 'Profession 2',
+			values: professions,
+			changedEvent: () => bulkTab.settingsChangedEmitter,
+			getValue: () => this.getPair().profession2,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				this.getPair().profession2 = newValue;
+				bulkTab.settingsChangedEmitter.emit(eventID);
+			},
+		});
+		this.init();
+	}
+
+	getInputElem(): HTMLElement | null {
+		return this.rootElem;
+	}
+	getInputValue(): ProfessionPair {
+		return ProfessionPair.create({
+			profession1: this.profession1Picker.getInputValue(),
+			profession2: this.profession2Picker.getInputValue(),
+		});
+	}
+	setInputValue(newValue: ProfessionPair) {
+		if (!newValue) {
+			return;
+		}
+		this.profession1Picker.setInputValue(newValue.profession1);
+		this.profession2Picker.setInputValue(newValue.profession2);
+	}
+}
+
 export class BulkTab extends SimTab {
 	readonly simUI: IndividualSimUI<any>;
 
 	readonly itemsChangedEmitter = new TypedEvent<void>();
+	readonly settingsChangedEmitter = new TypedEvent<void>();
 
 	readonly leftPanel: HTMLElement;
 	readonly rightPanel: HTMLElement;
@@ -238,6 +310,9 @@ export class BulkTab extends SimTab {
 	private autoGem: boolean;
 	private simTalents: boolean;
 	private autoEnchant: boolean;
+	private expandRandomSuffixes: boolean;
+	private randomSuffixFilters: string[];
+	private professionsToSim: ProfessionPair[];
 	private defaultGems: SimGem[];
 	private savedTalents: TalentLoadout[];
 	private gemIconElements: HTMLImageElement[];
@@ -266,6 +341,9 @@ export class BulkTab extends SimTab {
 		this.fastMode = true;
 		this.autoGem = true;
 		this.autoEnchant = true;
+		this.expandRandomSuffixes = false;
+		this.randomSuffixFilters = [];
+		this.professionsToSim = [];
 		this.savedTalents = [];
 		this.simTalents = false;
 		this.defaultGems = [UIGem.create(), UIGem.create(), UIGem.create(), UIGem.create()];
@@ -277,6 +355,10 @@ export class BulkTab extends SimTab {
 		});
 	}
 
+	getProfessionsToSim(): ProfessionPair[] {
+		return this.professionsToSim;
+	}
+
 	private getSettingsKey(): string {
 		return this.simUI.getStorageKey('bulk-settings.v1');
 	}
@@ -291,6 +373,9 @@ export class BulkTab extends SimTab {
 			this.doCombos = settings.combinations;
 			this.fastMode = settings.fastMode;
 			this.autoEnchant = settings.autoEnchant;
+			this.expandRandomSuffixes = settings.expandRandomSuffixes;
+			this.randomSuffixFilters = settings.randomSuffixFilters;
+			this.professionsToSim = settings.professionsToSim;
 			this.savedTalents = settings.talentsToSim;
 			this.autoGem = settings.autoGem;
 			this.simTalents = settings.simTalents;
@@ -308,6 +393,7 @@ export class BulkTab extends SimTab {
 						this.gemIconElements[idx].src = filledId.iconUrl;
 					});
 			});
+			this.settingsChangedEmitter.emit(TypedEvent.nextEventID());
 		}
 	}
 
@@ -325,6 +411,9 @@ export class BulkTab extends SimTab {
 			combinations: this.doCombos,
 			fastMode: this.fastMode,
 			autoEnchant: this.autoEnchant,
+			expandRandomSuffixes: this.expandRandomSuffixes,
+			randomSuffixFilters: this.randomSuffixFilters,
+			professionsToSim: this.professionsToSim,
 			autoGem: this.autoGem,
 			simTalents: this.simTalents,
 			talentsToSim: this.savedTalents,
@@ -343,7 +432,18 @@ export class BulkTab extends SimTab {
 			if (!item) {
 				throw new Error(`item with ID ${is.id} not found in database`);
 			}
-			itemsDb.items.push(SimItem.fromJson(UIItem.toJson(item.item), { ignoreUnknownFields: true }));
+			itemsDb.items.push(itemToSimItem(item.item));
+			if (item.randomSuffix) {
+				itemsDb.randomSuffixes.push(item.randomSuffix);
+			}
+			if (this.expandRandomSuffixes) {
+				for (const suffixId of item.item.randomSuffixOptions) {
+					const suffix = this.simUI.sim.db.getRandomSuffixById(suffixId);
+					if (suffix) {
+						itemsDb.randomSuffixes.push(suffix);
+					}
+				}
+			}
 			if (item.enchant) {
 				itemsDb.enchants.push(
 					SimEnchant.fromJson(UIEnchant.toJson(item.enchant), {
@@ -836,6 +936,55 @@ export class BulkTab extends SimTab {
 				}
 			},
 		});
+		new BooleanPicker<BulkTab>(settingsBlock.bodyElement, this, {
+			label: 'All Random Suffixes',
+			labelTooltip: 'When checked bulk simulator will sim items that can have a random suffix with each of their suffixes, unless one is chosen.',
+			changedEvent: (_obj: BulkTab) => this.itemsChangedEmitter,
+			getValue: _obj => this.expandRandomSuffixes,
+			setValue: (id: EventID, obj: BulkTab, value: boolean) => {
+				obj.expandRandomSuffixes = value;
+				obj.settingsChangedEmitter.emit(id);
+			},
+		});
+		new StringPicker<BulkTab>(settingsBlock.bodyElement, this, {
+			label: 'Random Suffix Filters',
+			labelTooltip: 'Comma separated suffix names, e.g. "of the Bandit, of the Feverflare". If set, only suffixes whose name contains one of these are simmed.',
+			changedEvent: (_obj: BulkTab) => this.settingsChangedEmitter,
+			getValue: _obj => this.randomSuffixFilters.join(', '),
+			setValue: (id: EventID, obj: BulkTab, value: string) => {
+				obj.randomSuffixFilters = value
+					.split(',')
+					.map(filter => filter.trim())
+					.filter(filter => filter.length > 0);
+			},
+			showWhen: _obj => this.expandRandomSuffixes,
+		});
+		new ListPicker<BulkTab, ProfessionPair>(settingsBlock.bodyElement, this, {
+			extraCssClasses: ['professions-to-sim-picker'],
+			title: 'Professions to Sim',
+			titleTooltip: 'Each combination is also simmed with each of these profession pairs, with the gear adjusted to the professions.',
+			itemLabel: 'Professions',
+			changedEvent: (_obj: BulkTab) => this.settingsChangedEmitter,
+			getValue: _obj => this.professionsToSim,
+			setValue: (id: EventID, obj: BulkTab, value: ProfessionPair[]) => {
+				obj.professionsToSim = value;
+				obj.settingsChangedEmitter.emit(id);
+			},
+			newItem: () =>
+				ProfessionPair.create({
+					profession1: this.simUI.player.getProfession1(),
+					profession2: this.simUI.player.getProfession2(),
+				}),
+			copyItem: (oldItem: ProfessionPair) => ProfessionPair.clone(oldItem),
+			newItemPicker: (
+				parent: HTMLElement,
+				_listPicker: ListPicker<BulkTab, ProfessionPair>,
+				index: number,
+				config: ListItemPickerConfig<BulkTab, ProfessionPair>,
+			) => new ProfessionPairPicker(parent, this, index, config),
+			allowedActions: ['create', 'delete', 'copy'],
+			inlineMenuBar: true,
+		});
 		new BooleanPicker<BulkTab>(settingsBlock.bodyElement, this, {
 			label: 'Auto Gem',
 			labelTooltip: 'When checked bulk simulator will fill any un-filled gem sockets with default gems.',
@@ -48,6 +48,7 @@ export class CooldownsPicker extends Component {
 			}
 			row.appendChild(label);
 
+			const syncPicker = this.makeSyncPicker(row, i);
 			const timingsPicker = this.makeTimingsPicker(row, i);
 
 			let deleteButtonFragment = document.createElement('fragment');
@@ -126,9 +127,42 @@ export class CooldownsPicker extends Component {
 			},
 			enableWhen: (player: Player<any>) => {
 				const curCooldown = player.getSimpleCooldowns().cooldowns[cooldownIndex];
-				return curCooldown && !ActionIdProto.equals(curCooldown.id, ActionIdProto.create());
+				return curCooldown && !ActionIdProto.equals(curCooldown.id, ActionIdProto.create()) && !curCooldown.syncWith;
 			},
 		});
 		return actionPicker;
 	}
+
+	// Picks another major cooldown this one is only used together with. Timings are ignored while synced.
+	private makeSyncPicker(parentElem: HTMLElement, cooldownIndex: number): IconEnumPicker<Player<any>, ActionIdProto> {
+		const availableCooldowns = this.player.getMetadata().getSpells().filter(spell => spell.data.isMajorCooldown).map(spell => spell.id);
+
+		return new IconEnumPicker<Player<any>, ActionIdProto>(parentElem, this.player, {
+			extraCssClasses: [
+				'cooldown-sync-picker',
+			],
+			numColumns: 3,
+			tooltip: 'Only use together with',
+			values: ([
+				{ color: '#grey', value: ActionIdProto.create() },
+			] as Array<IconEnumValueConfig<Player<any>, ActionIdProto>>).concat(availableCooldowns.map(cooldownAction => {
+				return { actionId: cooldownAction, value: cooldownAction.toProto() };
+			})),
+			equals: (a: ActionIdProto, b: ActionIdProto) => ActionIdProto.equals(a, b),
+			zeroValue: ActionIdProto.create(),
+			backupIconUrl: (value: ActionIdProto) => ActionId.fromProto(value),
+			changedEvent: (player: Player<any>) => player.rotationChangeEmitter,
+			getValue: (player: Player<any>) => player.getSimpleCooldowns().cooldowns[cooldownIndex]?.syncWith || ActionIdProto.create(),
+			setValue: (eventID: EventID, player: Player<any>, newValue: ActionIdProto) => {
+				const newCooldowns = player.getSimpleCooldowns();
+				const isUnsynced = ActionIdProto.equals(newValue, ActionIdProto.create());
+				newCooldowns.cooldowns[cooldownIndex].syncWith = isUnsynced ? undefined : newValue;
+				player.setSimpleCooldowns(eventID, newCooldowns);
+			},
+			enableWhen: (player: Player<any>) => {
+				const curCooldown = player.getSimpleCooldowns().cooldowns[cooldownIndex];
+				return curCooldown && !ActionIdProto.equals(curCooldown.id, ActionIdProto.create());
+			},
+		});
+	}
 }
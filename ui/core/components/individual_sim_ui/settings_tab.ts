@@ -1,7 +1,7 @@
 import * as Tooltips from '../../constants/tooltips.js';
 import { Encounter } from '../../encounter';
 import { IndividualSimUI, InputSection } from '../../individual_sim_ui';
-import { Consumes, Debuffs, HealingModel, IndividualBuffs, ItemSwap, PartyBuffs, Profession, RaidBuffs } from '../../proto/common';
+import { Consumes, Debuffs, HealingModel, IndividualBuffs, ItemSwap, PartyBuffs, PlayerModel, Profession, RaidBuffs } from '../../proto/common';
 import { SavedEncounter, SavedSettings } from '../../proto/ui';
 import { professionNames, raceNames } from '../../proto_utils/names';
 import { EventID, TypedEvent } from '../../typed_event';
@@ -11,6 +11,7 @@ import { ContentBlock } from '../content_block';
 import { EncounterPicker } from '../encounter_picker.js';
 import { EnumPicker } from '../enum_picker';
 import * as IconInputs from '../icon_inputs.js';
+import * as OtherInputs from '../other_inputs.js';
 import { Input } from '../input';
 import * as BuffDebuffInputs from '../inputs/buffs_debuffs';
 import { relevantStatOptions } from '../inputs/stat_options';
@@ -67,6 +68,7 @@ export class SettingsTab extends SimTab {
 		this.buildCustomSettingsSections();
 		this.buildConsumesSection();
 		this.buildOtherSettings();
+		this.buildPlayerModelSettings();
 
 		if (!this.simUI.isWithinRaidSim) {
 			this.buildBuffsSettings();
@@ -191,6 +193,24 @@ export class SettingsTab extends SimTab {
 		}
 	}
 
+	private buildPlayerModelSettings() {
+		const column = this.simUI.isWithinRaidSim ? this.column4! : this.column2;
+		const contentBlock = new ContentBlock(column, 'player-model-settings', {
+			header: { title: 'Player Model', tooltip: Tooltips.PLAYER_MODEL_SECTION },
+		});
+
+		this.configureInputSection(contentBlock.bodyElement, {
+			inputs: [
+				OtherInputs.PlayerModelLatency,
+				OtherInputs.PlayerModelSpellQueueWindow,
+				OtherInputs.PlayerModelDelayDistribution,
+				OtherInputs.PlayerModelGcdDelay,
+				OtherInputs.PlayerModelGcdDelaySpread,
+				OtherInputs.PlayerModelMissedGcdChance,
+			],
+		});
+	}
+
 	private buildBuffsSettings() {
 		const contentBlock = new ContentBlock(this.column3, 'buffs-settings', {
 			header: { title: 'Raid Buffs', tooltip: Tooltips.BUFFS_SECTION },
@@ -275,6 +295,7 @@ export class SettingsTab extends SimTab {
 					inFrontOfTarget: player.getInFrontOfTarget(),
 					distanceFromTarget: player.getDistanceFromTarget(),
 					healingModel: player.getHealingModel(),
+					playerModel: player.getPlayerModel(),
 					darkIntentUptime: player.getDarkIntentUptime(),
 				});
 			},
@@ -297,6 +318,7 @@ export class SettingsTab extends SimTab {
 					simUI.player.setInFrontOfTarget(eventID, newSettings.inFrontOfTarget);
 					simUI.player.setDistanceFromTarget(eventID, newSettings.distanceFromTarget);
 					simUI.player.setHealingModel(eventID, newSettings.healingModel || HealingModel.create());
+					simUI.player.setPlayerModel(eventID, newSettings.playerModel || PlayerModel.create());
 					simUI.player.setDarkIntentUptime(eventID, newSettings.darkIntentUptime);
 				});
 			},
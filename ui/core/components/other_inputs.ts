@@ -1,7 +1,7 @@
 import { BooleanPicker } from '../components/boolean_picker.js';
 import { EnumPicker } from '../components/enum_picker.js';
 import { Player } from '../player.js';
-import { ItemSlot, UnitReference } from '../proto/common.js';
+import { ItemSlot, PlayerModel_DelayDistribution as DelayDistribution, UnitReference } from '../proto/common.js';
 import { emptyUnitReference } from '../proto_utils/utils.js';
 import { Sim } from '../sim.js';
 import { EventID, TypedEvent } from '../typed_event.js';
@@ -101,6 +101,93 @@ export const ChannelClipDelay = {
 	},
 };
 
+export const PlayerModelLatency = {
+	type: 'number' as const,
+	label: 'Latency',
+	labelTooltip: 'Network latency in milliseconds, added to actions which are pressed too late to be spell queued.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getPlayerModel().latencyMs,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const playerModel = player.getPlayerModel();
+		playerModel.latencyMs = newValue;
+		player.setPlayerModel(eventID, playerModel);
+	},
+};
+
+export const PlayerModelSpellQueueWindow = {
+	type: 'number' as const,
+	label: 'Spell Queue Window',
+	labelTooltip: 'How early, in milliseconds, the next action can be pressed before the GCD or a cast ends. Actions pressed within this window start on time.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getPlayerModel().spellQueueWindowMs,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const playerModel = player.getPlayerModel();
+		playerModel.spellQueueWindowMs = newValue;
+		player.setPlayerModel(eventID, playerModel);
+	},
+};
+
+export const PlayerModelDelayDistribution = {
+	type: 'enum' as const,
+	label: 'GCD Delay Distribution',
+	labelTooltip: 'How the delay between being free to act and pressing the next action is distributed.',
+	values: [
+		{ name: 'Fixed', value: DelayDistribution.Fixed },
+		{ name: 'Uniform', value: DelayDistribution.Uniform },
+		{ name: 'Normal', value: DelayDistribution.Normal },
+		{ name: 'Exponential', value: DelayDistribution.Exponential },
+	],
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getPlayerModel().gcdDelayDistribution,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const playerModel = player.getPlayerModel();
+		playerModel.gcdDelayDistribution = newValue;
+		player.setPlayerModel(eventID, playerModel);
+	},
+};
+
+export const PlayerModelGcdDelay = {
+	type: 'number' as const,
+	label: 'GCD Delay',
+	labelTooltip: 'Average delay in milliseconds between being free to act, e.g. when the GCD ends, and pressing the next action.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getPlayerModel().gcdDelayMs,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const playerModel = player.getPlayerModel();
+		playerModel.gcdDelayMs = newValue;
+		player.setPlayerModel(eventID, playerModel);
+	},
+};
+
+export const PlayerModelGcdDelaySpread = {
+	type: 'number' as const,
+	label: 'GCD Delay +/-',
+	labelTooltip: 'Spread of the GCD delay in milliseconds: the half-width for Uniform delays, or the standard deviation for Normal ones. Delays below zero are presses before the GCD ends, which only start on time within the spell queue window.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getPlayerModel().gcdDelaySpreadMs,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const playerModel = player.getPlayerModel();
+		playerModel.gcdDelaySpreadMs = newValue;
+		player.setPlayerModel(eventID, playerModel);
+	},
+	enableWhen: (player: Player<any>) =>
+		[DelayDistribution.Uniform, DelayDistribution.Normal].includes(player.getPlayerModel().gcdDelayDistribution),
+};
+
+export const PlayerModelMissedGcdChance = {
+	type: 'number' as const,
+	float: true,
+	label: 'Missed GCD %',
+	labelTooltip: 'Chance to miss a GCD entirely, idling for a full GCD before the next action.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getPlayerModel().missedGcdChance * 100,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const playerModel = player.getPlayerModel();
+		playerModel.missedGcdChance = newValue / 100;
+		player.setPlayerModel(eventID, playerModel);
+	},
+};
+
 export const DarkIntentUptime = {
 	type: 'number' as const,
 	label: 'Dark Intent Uptime',
@@ -257,6 +344,22 @@ export const HpPercentForDefensives = {
 	},
 };
 
+export const PlanDefensivesForDamageSpikes = {
+	type: 'boolean' as const,
+	label: 'Plan Defensive CDs for Damage Spikes',
+	labelTooltip: `
+		<p>Save defensive cooldowns for the damage spikes of the encounter (big hits, enrages, ...), and use them just before each spike.</p>
+		<p>Encounters without damage spikes use the HP % threshold instead.</p>
+	`,
+	changedEvent: (player: Player<any>) => player.rotationChangeEmitter,
+	getValue: (player: Player<any>) => player.getSimpleCooldowns().planDefensivesForDamageSpikes,
+	setValue: (eventID: EventID, player: Player<any>, newValue: boolean) => {
+		const cooldowns = player.getSimpleCooldowns();
+		cooldowns.planDefensivesForDamageSpikes = newValue;
+		player.setSimpleCooldowns(eventID, cooldowns);
+	},
+};
+
 export const InspirationUptime = {
 	type: 'number' as const,
 	float: true,
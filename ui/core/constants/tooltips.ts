@@ -1,5 +1,6 @@
 export const BUFFS_SECTION = 'Buffs provided by other party/raid members.';
 export const DEBUFFS_SECTION = 'Debuffs applied by other raid members.';
+export const PLAYER_MODEL_SECTION = 'Models the delays of a human player executing the rotation, to quantify how much is lost to imperfect play. All 0 means perfect play.';
 export const COOLDOWNS_SECTION = 'Specify cooldown timings, in seconds. Cooldowns will be used as soon as possible after their specified timings. When not specified, cooldowns will be used when ready and it is sensible to do so.<br><br>Multiple timings can be provided by separating with commas. Any cooldown usages after the last provided timing will use the default logic.';
 export const BLESSINGS_SECTION = 'Specify Paladin Blessings for each role, in order of priority. Blessings in the 1st column will be used if there is at least 1 Paladin in the raid, 2nd column if at least 2, etc.';
 
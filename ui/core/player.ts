@@ -26,6 +26,7 @@ import {
 	IndividualBuffs,
 	ItemRandomSuffix,
 	ItemSlot,
+	PlayerModel,
 	Profession,
 	PseudoStat,
 	Race,
@@ -249,6 +250,7 @@ export class Player<SpecType extends Spec> {
 	private distanceFromTarget = 0;
 	private darkIntentUptime = 100;
 	private healingModel: HealingModel = HealingModel.create();
+	private playerModel: PlayerModel = PlayerModel.create();
 	private healingEnabled = false;
 
 	private readonly autoRotationGenerator: AutoRotationGenerator<SpecType> | null = null;
@@ -1023,6 +1025,19 @@ export class Player<SpecType extends Spec> {
 		this.healingModelChangeEmitter.emit(eventID);
 	}
 
+	getPlayerModel(): PlayerModel {
+		// Make a defensive copy
+		return PlayerModel.clone(this.playerModel);
+	}
+
+	setPlayerModel(eventID: EventID, newPlayerModel: PlayerModel) {
+		if (PlayerModel.equals(this.playerModel, newPlayerModel)) return;
+
+		// Make a defensive copy
+		this.playerModel = PlayerModel.clone(newPlayerModel);
+		this.miscOptionsChangeEmitter.emit(eventID);
+	}
+
 	computeStatsEP(stats?: Stats): number {
 		if (stats == undefined) {
 			return 0;
@@ -1415,6 +1430,7 @@ export class Player<SpecType extends Spec> {
 			PlayerProto.mergePartial(player, {
 				cooldowns: Cooldowns.create({
 					hpPercentForDefensives: this.getSimpleCooldowns().hpPercentForDefensives,
+					planDefensivesForDamageSpikes: this.getSimpleCooldowns().planDefensivesForDamageSpikes,
 				}),
 				rotation: aplRotation,
 			});
@@ -1435,6 +1451,7 @@ export class Player<SpecType extends Spec> {
 				inFrontOfTarget: this.getInFrontOfTarget(),
 				distanceFromTarget: this.getDistanceFromTarget(),
 				healingModel: this.getHealingModel(),
+				playerModel: this.getPlayerModel(),
 				darkIntentUptime: this.getDarkIntentUptime(),
 			});
 			player = withSpec(this.getSpec(), player, this.getSpecOptions());
@@ -1485,6 +1502,7 @@ export class Player<SpecType extends Spec> {
 				this.setInFrontOfTarget(eventID, proto.inFrontOfTarget);
 				this.setDistanceFromTarget(eventID, proto.distanceFromTarget);
 				this.setHealingModel(eventID, proto.healingModel || HealingModel.create());
+				this.setPlayerModel(eventID, proto.playerModel || PlayerModel.create());
 				this.setDarkIntentUptime(eventID, proto.darkIntentUptime);
 			}
 			if (loadCategory(SimSettingCategories.External)) {
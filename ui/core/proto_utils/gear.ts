@@ -1,5 +1,5 @@
-import { EquipmentSpec, GemColor, ItemSlot, ItemSpec, ItemSwap, Profession, SimDatabase, SimEnchant, SimGem, SimItem } from '../proto/common.js';
-import { UIEnchant as Enchant, UIGem as Gem, UIItem as Item } from '../proto/ui.js';
+import { EquipmentSpec, Faction, GemColor, ItemSlot, ItemSpec, ItemSwap, Profession, SimDatabase, SimEnchant, SimGem, SimItem } from '../proto/common.js';
+import { UIEnchant as Enchant, UIGem as Gem, UIItem as Item, UIItem_FactionRestriction } from '../proto/ui.js';
 import { isBluntWeaponType, isSharpWeaponType } from '../proto_utils/utils.js';
 import { Sim } from '../sim';
 import { distinct, equalsOrBothNull, getEnumValues } from '../utils.js';
@@ -11,6 +11,18 @@ import { validWeaponCombo } from './utils.js';
 
 type InternalGear = Record<ItemSlot, EquippedItem | null>;
 
+// Converts a UI item to the item the sim needs. The faction restriction uses a
+// different enum in the sim, so it isn't carried over by the JSON conversion.
+export function itemToSimItem(item: Item): SimItem {
+	const simItem = SimItem.fromJson(Item.toJson(item), { ignoreUnknownFields: true });
+	if (item.factionRestriction == UIItem_FactionRestriction.ALLIANCE_ONLY) {
+		simItem.faction = Faction.Alliance;
+	} else if (item.factionRestriction == UIItem_FactionRestriction.HORDE_ONLY) {
+		simItem.faction = Faction.Horde;
+	}
+	return simItem;
+}
+
 abstract class BaseGear {
 	protected readonly gear: InternalGear;
 
@@ -107,7 +119,7 @@ abstract class BaseGear {
 	toDatabase(db: Database): SimDatabase {
 		const equippedItems = this.asArray().filter(ei => ei != null) as Array<EquippedItem>;
 		return SimDatabase.create({
-			items: distinct(equippedItems.map(ei => BaseGear.itemToDB(ei.item))),
+			items: distinct(equippedItems.map(ei => itemToSimItem(ei.item))),
 			randomSuffixes: distinct(equippedItems.filter(ei => ei.randomSuffix).map(ei => ei.randomSuffix!)),
 			reforgeStats: distinct(equippedItems.filter(ei => ei.reforging).map(ei => db.getReforge(ei.reforging) ?? {})),
 			enchants: distinct(equippedItems.filter(ei => ei.enchant).map(ei => BaseGear.enchantToDB(ei.enchant!))),
@@ -115,10 +127,6 @@ abstract class BaseGear {
 		});
 	}
 
-	private static itemToDB(item: Item): SimItem {
-		return SimItem.fromJson(Item.toJson(item), { ignoreUnknownFields: true });
-	}
-
 	private static enchantToDB(enchant: Enchant): SimEnchant {
 		return SimEnchant.fromJson(Enchant.toJson(enchant), { ignoreUnknownFields: true });
 	}
@@ -20,6 +20,7 @@ export class Raid {
 	private debuffs: Debuffs = Debuffs.create();
 	private tanks: Array<UnitReference> = [];
 	private targetDummies = 0;
+	private simulateRaidCooldowns = false;
 	private numActiveParties = 5;
 
 	// Emits when a raid member is added/removed/moved.
@@ -29,6 +30,7 @@ export class Raid {
 	readonly debuffsChangeEmitter = new TypedEvent<void>();
 	readonly tanksChangeEmitter = new TypedEvent<void>();
 	readonly targetDummiesChangeEmitter = new TypedEvent<void>();
+	readonly simulateRaidCooldownsChangeEmitter = new TypedEvent<void>();
 	readonly numActivePartiesChangeEmitter = new TypedEvent<void>();
 
 	// Emits when anything in the raid changes.
@@ -61,6 +63,7 @@ export class Raid {
 			this.debuffsChangeEmitter,
 			this.tanksChangeEmitter,
 			this.targetDummiesChangeEmitter,
+			this.simulateRaidCooldownsChangeEmitter,
 		], 'RaidChange');
 
 		this.changeEmitter.on(() => {
@@ -171,6 +174,18 @@ export class Raid {
 		this.targetDummiesChangeEmitter.emit(eventID);
 	}
 
+	getSimulateRaidCooldowns(): boolean {
+		return this.simulateRaidCooldowns;
+	}
+
+	setSimulateRaidCooldowns(eventID: EventID, newSimulateRaidCooldowns: boolean) {
+		if (this.simulateRaidCooldowns == newSimulateRaidCooldowns)
+			return;
+
+		this.simulateRaidCooldowns = newSimulateRaidCooldowns;
+		this.simulateRaidCooldownsChangeEmitter.emit(eventID);
+	}
+
 	getNumActiveParties(): number {
 		return this.numActiveParties;
 	}
@@ -198,6 +213,7 @@ export class Raid {
 			debuffs: this.getDebuffs(),
 			tanks: this.getTanks(),
 			targetDummies: this.getTargetDummies(),
+			simulateRaidCooldowns: this.getSimulateRaidCooldowns(),
 			numActiveParties: this.getNumActiveParties(),
 		});
 	}
@@ -208,6 +224,7 @@ export class Raid {
 			this.setDebuffs(eventID, proto.debuffs || Debuffs.create());
 			this.setTanks(eventID, proto.tanks);
 			this.setTargetDummies(eventID, proto.targetDummies);
+			this.setSimulateRaidCooldowns(eventID, proto.simulateRaidCooldowns);
 			this.setNumActiveParties(eventID, proto.numActiveParties || 5);
 
 			for (let i = 0; i < MAX_NUM_PARTIES; i++) {
@@ -0,0 +1,52 @@
+// Package trees embeds the talent tree definitions of the UI's talent picker,
+// so the sim can use the same layouts.
+package trees
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+//go:embed *.json
+var treeFiles embed.FS
+
+var camelCaseBoundary = regexp.MustCompile("([a-z])([A-Z])")
+
+// Returns the file name of a class's trees, e.g. death_knight.json.
+func classFileName(class proto.Class) string {
+	className := strings.TrimPrefix(class.String(), "Class")
+	return strings.ToLower(camelCaseBoundary.ReplaceAllString(className, "${1}_${2}")) + ".json"
+}
+
+// Parses talent tree definitions in the UI's JSON format.
+func Parse(data []byte) ([]*proto.TalentTreeLayout, error) {
+	var rawTrees []json.RawMessage
+	if err := json.Unmarshal(data, &rawTrees); err != nil {
+		return nil, err
+	}
+
+	var trees []*proto.TalentTreeLayout
+	for _, rawTree := range rawTrees {
+		tree := &proto.TalentTreeLayout{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(rawTree, tree); err != nil {
+			return nil, err
+		}
+		trees = append(trees, tree)
+	}
+	return trees, nil
+}
+
+// Returns the talent trees of a class.
+func ForClass(class proto.Class) ([]*proto.TalentTreeLayout, error) {
+	data, err := treeFiles.ReadFile(classFileName(class))
+	if err != nil {
+		return nil, fmt.Errorf("no talent trees for class %s", class)
+	}
+	return Parse(data)
+}
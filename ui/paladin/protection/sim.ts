@@ -176,6 +176,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecProtectionPaladin, {
 			OtherInputs.HealingCadenceVariation,
 			OtherInputs.BurstWindow,
 			OtherInputs.HpPercentForDefensives,
+			OtherInputs.PlanDefensivesForDamageSpikes,
 			OtherInputs.InspirationUptime,
 			PaladinInputs.UseAvengingWrath(),
 			OtherInputs.InFrontOfTarget,
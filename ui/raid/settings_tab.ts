@@ -1,3 +1,4 @@
+import { BooleanPicker } from '../core/components/boolean_picker';
 import { ContentBlock } from '../core/components/content_block';
 import { EncounterPicker } from '../core/components/encounter_picker';
 import { IconPicker } from '../core/components/icon_picker';
@@ -90,6 +91,16 @@ export class SettingsTab extends SimTab {
 			header: { title: 'Other' },
 		});
 
+		new BooleanPicker(contentBlock.bodyElement, this.simUI.sim.raid, {
+			label: 'Simulate Raid Cooldowns',
+			labelTooltip: 'Raid cooldowns cast by players in the raid, like Mana Tide Totem or Rallying Cry, apply to their party or raid when cast. Raid buffs for these then only stand in for players who are not simulated.',
+			changedEvent: (raid: Raid) => raid.simulateRaidCooldownsChangeEmitter,
+			getValue: (raid: Raid) => raid.getSimulateRaidCooldowns(),
+			setValue: (eventID: EventID, raid: Raid, newValue: boolean) => {
+				raid.setSimulateRaidCooldowns(eventID, newValue);
+			},
+		});
+
 		// new BooleanPicker(contentBlock.bodyElement, this.simUI.sim.raid, {
 		// 	label: 'Stagger Stormstrikes',
 		// 	labelTooltip: 'When there are multiple Enhancement Shaman in the raid, causes them to coordinate their Stormstrike casts for optimal SS charge usage.',
@@ -147,6 +147,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecProtectionWarrior, {
 			OtherInputs.HealingCadenceVariation,
 			OtherInputs.BurstWindow,
 			OtherInputs.HpPercentForDefensives,
+			OtherInputs.PlanDefensivesForDamageSpikes,
 			OtherInputs.InspirationUptime,
 			ProtectionWarriorInputs.StartingRage(),
 			OtherInputs.InFrontOfTarget,